@@ -0,0 +1,65 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MutatingMethods contains the HTTP methods for which RequireScopesOnMutatingMethods requires scopes.
+var MutatingMethods = []string{"POST", "PUT", "PATCH", "DELETE"}
+
+// Defines the constraints for the rules of APIRules in the namespace of the APIRulePolicy.
+type APIRulePolicySpec struct {
+	// Forbids rules that disable authorization with `noAuth: true`. The default value is `false`.
+	ForbidNoAuth bool `json:"forbidNoAuth,omitempty"`
+	// Specifies the JWT issuers that rules may use in **jwt.authentications** and **extAuth.restrictions.authentications**.
+	// If the list is empty, all issuers are allowed.
+	AllowedJwtIssuers []string `json:"allowedJwtIssuers,omitempty"`
+	// Requires rules that allow one of the methods `POST`, `PUT`, `PATCH`, or `DELETE` to define at least one JWT
+	// authorization with **requiredScopes**. The default value is `false`.
+	RequireScopesOnMutatingMethods bool `json:"requireScopesOnMutatingMethods,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIRulePolicy defines security constraints for all APIRules in its namespace.
+// If there are multiple APIRulePolicies in a namespace, the APIRules must fulfill all of them.
+// +kubebuilder:printcolumn:name="Forbid NoAuth",type="boolean",JSONPath=".spec.forbidNoAuth"
+// +kubebuilder:printcolumn:name="Require Scopes",type="boolean",JSONPath=".spec.requireScopesOnMutatingMethods"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type APIRulePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Defines the desired state of the APIRulePolicy CR.
+	Spec APIRulePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// APIRulePolicyList contains a list of APIRulePolicy custom resources.
+type APIRulePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIRulePolicy `json:"items"`
+}
+
+// AllowsIssuer returns true if rules may use JWTs issued by the issuer.
+func (p *APIRulePolicy) AllowsIssuer(issuer string) bool {
+	return len(p.Spec.AllowedJwtIssuers) == 0 || slices.Contains(p.Spec.AllowedJwtIssuers, issuer)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defines which namespaces and Gateways may expose hosts matching the host patterns.
+type HostPolicySpec struct {
+	// Specifies the host patterns to which the policy applies. A pattern is either a fully qualified domain name,
+	// for example, `payments.example.com`, or a wildcard, for example, `*.payments.example.com`, that matches all
+	// subdomains of the domain.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`
+	Hosts []string `json:"hosts"`
+	// Specifies the namespaces in which APIRules may expose the matching hosts.
+	// If the list is empty, APIRules in all namespaces may expose the hosts.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// Specifies the Gateways or ExternalGateways in the `namespace/name` format through which the matching hosts may be exposed.
+	// If the list is empty, the hosts may be exposed through all Gateways.
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	AllowedGateways []string `json:"allowedGateways,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// HostPolicy restricts the namespaces and Gateways from which APIRules may expose hosts.
+// If a host matches multiple HostPolicies, the APIRule must be allowed by all of them.
+// +kubebuilder:printcolumn:name="Hosts",type="string",JSONPath=".spec.hosts"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type HostPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Defines the desired state of the HostPolicy CR.
+	Spec HostPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// HostPolicyList contains a list of HostPolicy custom resources.
+type HostPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostPolicy `json:"items"`
+}
+
+// MatchesHost returns true if the host matches one of the host patterns of the policy. A wildcard host, for example,
+// `*.example.com`, matches all patterns that overlap with its subdomains, for example, `payments.example.com` or
+// `*.payments.example.com`.
+func (p *HostPolicy) MatchesHost(host string) bool {
+	for _, pattern := range p.Spec.Hosts {
+		if hostDomain, isWildcardHost := strings.CutPrefix(host, "*."); isWildcardHost && strings.HasSuffix(pattern, "."+hostDomain) {
+			return true
+		}
+		if domain, isWildcard := strings.CutPrefix(pattern, "*."); isWildcard {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsNamespace returns true if APIRules in the namespace may expose the hosts of the policy.
+func (p *HostPolicy) AllowsNamespace(namespace string) bool {
+	return len(p.Spec.AllowedNamespaces) == 0 || slices.Contains(p.Spec.AllowedNamespaces, namespace)
+}
+
+// AllowsGateway returns true if the hosts of the policy may be exposed through the Gateway in the `namespace/name` format.
+func (p *HostPolicy) AllowsGateway(gateway string) bool {
+	return len(p.Spec.AllowedGateways) == 0 || slices.Contains(p.Spec.AllowedGateways, gateway)
+}
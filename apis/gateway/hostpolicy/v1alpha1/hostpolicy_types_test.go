@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestMatchesHost(t *testing.T) {
+	policy := HostPolicy{
+		Spec: HostPolicySpec{
+			Hosts: []string{"payments.example.com", "*.payments.example.com"},
+		},
+	}
+
+	tests := []struct {
+		host    string
+		matches bool
+	}{
+		{host: "payments.example.com", matches: true},
+		{host: "api.payments.example.com", matches: true},
+		{host: "v1.api.payments.example.com", matches: true},
+		{host: "orders.example.com", matches: false},
+		{host: "mypayments.example.com", matches: false},
+		{host: "payments.example.com.evil.com", matches: false},
+		{host: "*.example.com", matches: true},
+		{host: "*.payments.example.com", matches: true},
+		{host: "*.api.payments.example.com", matches: true},
+		{host: "*.orders.example.com", matches: false},
+		{host: "*.com.evil.com", matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := policy.MatchesHost(tt.host); got != tt.matches {
+				t.Errorf("MatchesHost(%q) = %v, expected %v", tt.host, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	restricted := HostPolicy{
+		Spec: HostPolicySpec{
+			AllowedNamespaces: []string{"payments"},
+			AllowedGateways:   []string{"kyma-system/kyma-gateway"},
+		},
+	}
+	unrestricted := HostPolicy{}
+
+	if !restricted.AllowsNamespace("payments") || restricted.AllowsNamespace("team-a") {
+		t.Error("expected only namespace payments to be allowed")
+	}
+	if !restricted.AllowsGateway("kyma-system/kyma-gateway") || restricted.AllowsGateway("team-a/gateway") {
+		t.Error("expected only Gateway kyma-system/kyma-gateway to be allowed")
+	}
+	if !unrestricted.AllowsNamespace("team-a") || !unrestricted.AllowsGateway("team-a/gateway") {
+		t.Error("expected policy without restrictions to allow all namespaces and Gateways")
+	}
+}
@@ -74,7 +74,7 @@ type APIRuleSpec struct {
 	// +kubebuilder:validation:XValidation:rule=`self.matches('^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?/([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)$')`,message="ExternalGateway must be in the namespace/name format"
 	// +optional
 	ExternalGateway *string `json:"externalGateway,omitempty"`
-	// Allows configuring CORS headers sent with the response. If **corsPolicy** is not defined, the CORS headers are removed from the response.
+	// Allows configuring CORS headers sent with the response. If **corsPolicy** is not defined, the default CORS policy configured in the **apiRules** section of the APIGateway CR applies. If no default CORS policy is configured, the CORS headers are removed from the response.
 	// +optional
 	CorsPolicy *CorsPolicy `json:"corsPolicy,omitempty"`
 	/* Defines an ordered list of access rules. Each rule is an atomic configuration that
@@ -84,7 +84,7 @@ type APIRuleSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	Rules []Rule `json:"rules"`
 	// Specifies the timeout for HTTP requests in seconds for all rules.
-	// You can override the value for each rule. If no timeout is specified, the default timeout of 180 seconds applies.
+	// You can override the value for each rule. If no timeout is specified, the default timeout configured in the **apiRules** section of the APIGateway CR applies, which defaults to 180 seconds.
 	// +optional
 	Timeout *Timeout `json:"timeout,omitempty"`
 }
@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 )
 
 type State string
@@ -44,9 +46,190 @@ type APIGatewaySpec struct {
 	// Specifies whether the default Kyma Gateway `kyma-gateway` in `kyma-system` namespace is created.
 	// +optional
 	EnableKymaGateway *bool `json:"enableKymaGateway,omitempty"`
+	// Configures the Kyma Gateway. The configuration is only applied if **enableKymaGateway** is `true`.
+	// +optional
+	KymaGateway *KymaGatewayConfig `json:"kymaGateway,omitempty"`
+	// Specifies additional Istio Gateways that are managed by the module together with their certificates and DNS
+	// records. A Gateway that is removed from the list is deleted unless it is still used by APIRules or
+	// VirtualServices.
+	// +optional
+	Gateways []ManagedGateway `json:"gateways,omitempty"`
 	// Enables network policy reconciliation support for the API Gateway module.
 	// +kubebuilder:validation:Optional
 	NetworkPoliciesEnabled *bool `json:"networkPoliciesEnabled,omitempty"`
+	// Configures the reconciliation of all APIRules in the cluster.
+	// +optional
+	APIRules *APIRulesConfig `json:"apiRules,omitempty"`
+}
+
+// Defines the configuration of the Kyma Gateway `kyma-gateway` in the `kyma-system` namespace. Invalid configuration is
+// not applied and is reported in the conditions of the APIGateway CR.
+type KymaGatewayConfig struct {
+	// Specifies the TLS settings of the HTTPS server of the Kyma Gateway.
+	// +optional
+	TLS *KymaGatewayTLS `json:"tls,omitempty"`
+	// Specifies additional hosts exposed by the Kyma Gateway besides the wildcard host of the Kyma domain.
+	// Only the external-dns DNS provider, the cert-manager certificate provider and the self-signed default certificate
+	// cover the additional hosts.
+	// +optional
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+	// Specifies the labels of the Istio ingress gateway workload that serves the Kyma Gateway.
+	// Defaults to `app: istio-ingressgateway` and `istio: ingressgateway`.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+	// Specifies the name of a Secret in the `istio-system` namespace that contains the TLS certificate of the Kyma
+	// Gateway in the `tls.crt` and `tls.key` keys. If set, the module doesn't create a certificate for the Kyma Gateway.
+	// +optional
+	CertificateSecretName string `json:"certificateSecretName,omitempty"`
+	// Configures the certificate of the Kyma Gateway that is issued by the module. It is ignored if
+	// **certificateSecretName** is set.
+	// +optional
+	Certificate *KymaGatewayCertificate `json:"certificate,omitempty"`
+	// Configures the DNS record of the Kyma Gateway that is created by the module.
+	// +optional
+	DNS *KymaGatewayDNS `json:"dns,omitempty"`
+}
+
+// Defines how the DNS record of the Kyma Gateway is created.
+type KymaGatewayDNS struct {
+	// Specifies the provider that creates the DNS record. The possible values are `gardener` and `external-dns`.
+	// If not set, Gardener is used if it is installed in the cluster, otherwise external-dns is used if it is installed.
+	// +kubebuilder:validation:Enum=gardener;external-dns
+	// +optional
+	Provider string `json:"provider,omitempty"`
+}
+
+// Defines how the certificate of the Kyma Gateway is issued.
+type KymaGatewayCertificate struct {
+	// Specifies the provider that issues the certificate. The possible values are `gardener` and `cert-manager`.
+	// If not set, Gardener is used if it is installed in the cluster, and cert-manager is used if an **issuer** is set.
+	// +kubebuilder:validation:Enum=gardener;cert-manager
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// Specifies the cert-manager Issuer or ClusterIssuer that issues the certificate. An Issuer must be in the
+	// `istio-system` namespace. Required if the provider is `cert-manager`.
+	// +optional
+	Issuer *CertificateIssuerRef `json:"issuer,omitempty"`
+}
+
+// References a cert-manager Issuer or ClusterIssuer.
+type CertificateIssuerRef struct {
+	// Specifies the name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Specifies the kind of the issuer. The possible values are `Issuer` and `ClusterIssuer`. Defaults to `ClusterIssuer`.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// Defines the TLS settings of the HTTPS server of the Kyma Gateway.
+type KymaGatewayTLS struct {
+	// Specifies the minimum TLS protocol version.
+	// +kubebuilder:validation:Enum=TLSV1_0;TLSV1_1;TLSV1_2;TLSV1_3
+	// +optional
+	MinProtocolVersion string `json:"minProtocolVersion,omitempty"`
+	// Specifies the maximum TLS protocol version.
+	// +kubebuilder:validation:Enum=TLSV1_0;TLSV1_1;TLSV1_2;TLSV1_3
+	// +optional
+	MaxProtocolVersion string `json:"maxProtocolVersion,omitempty"`
+	// Specifies the cipher suites used for TLS versions lower than TLS 1.3, for example, `ECDHE-RSA-AES256-GCM-SHA384`.
+	// If not set, the default cipher suites of Envoy are used.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// Defines an additional Istio Gateway that is managed by the module. The Gateway serves the wildcard host of its domain
+// on port `443` (HTTPS) and redirects port `80` (HTTP) to HTTPS.
+type ManagedGateway struct {
+	// Specifies the name of the Istio Gateway.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Specifies the namespace of the Istio Gateway. The namespace must exist.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace"`
+	// Specifies the domain of the Gateway, for example, `partner.example.com`. The Gateway serves `*.{domain}`.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`
+	Domain string `json:"domain"`
+	// Specifies the labels of the Istio ingress gateway workload that serves the Gateway.
+	// Defaults to `app: istio-ingressgateway` and `istio: ingressgateway`.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+	// Specifies the Service of the Istio ingress gateway workload that serves the Gateway. The DNS record of the Gateway
+	// points to the load balancer of this Service. Defaults to the `istio-ingressgateway` Service in the
+	// `istio-system` namespace.
+	// +optional
+	IngressService *IngressServiceRef `json:"ingressService,omitempty"`
+	// Specifies the TLS mode of the HTTPS server. The possible values are `SIMPLE` and `MUTUAL`. Defaults to `SIMPLE`.
+	// The `MUTUAL` mode requires **certificateSecretName** with a Secret that contains the CA of the client
+	// certificates in the `ca.crt` key.
+	// +kubebuilder:validation:Enum=SIMPLE;MUTUAL
+	// +optional
+	TLSMode string `json:"tlsMode,omitempty"`
+	// Specifies the name of a Secret in the `istio-system` namespace that contains the TLS certificate of the Gateway
+	// in the `tls.crt` and `tls.key` keys. If set, the module doesn't create a certificate for the Gateway.
+	// +optional
+	CertificateSecretName string `json:"certificateSecretName,omitempty"`
+	// Configures the certificate of the Gateway that is issued by the module. It is ignored if
+	// **certificateSecretName** is set.
+	// +optional
+	Certificate *KymaGatewayCertificate `json:"certificate,omitempty"`
+	// Configures the DNS record of the Gateway that is created by the module.
+	// +optional
+	DNS *KymaGatewayDNS `json:"dns,omitempty"`
+}
+
+// References the Service of an Istio ingress gateway workload.
+type IngressServiceRef struct {
+	// Specifies the name of the Service.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Specifies the namespace of the Service.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// Defines the settings applied to all APIRules. Invalid settings are not applied and are reported in the conditions of
+// the APIGateway CR.
+type APIRulesConfig struct {
+	// Specifies the JWT handler of APIRules in version `v1beta1`. The possible values are `ory` and `istio`.
+	// If not set, the **jwtHandler** of the `api-gateway-config` ConfigMap in the `kyma-system` namespace is used.
+	// +optional
+	JWTHandler string `json:"jwtHandler,omitempty"`
+	// Specifies the timeout in seconds for requests of APIRules that don't define a timeout. Defaults to `180`.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3900
+	// +optional
+	DefaultTimeout *uint32 `json:"defaultTimeout,omitempty"`
+	// Specifies the CORS policy of APIRules that don't define a CORS policy.
+	// +optional
+	DefaultCorsPolicy *gatewayv2alpha1.CorsPolicy `json:"defaultCorsPolicy,omitempty"`
+	// Specifies the issuers that APIRules can use in JWT authentications. If not set, all issuers are allowed.
+	// +optional
+	AllowedJWTIssuers []string `json:"allowedJwtIssuers,omitempty"`
+	// Specifies the validation checks that aren't enforced when APIRules are reconciled, for example, `sidecarInjection`.
+	// +optional
+	DisabledValidationChecks []string `json:"disabledValidationChecks,omitempty"`
+	// Configures NetworkPolicies that restrict the ingress traffic of the workloads exposed by APIRules to the Istio
+	// ingress gateway, so the authorization of the APIRules can't be bypassed from inside the cluster.
+	// +optional
+	BackendNetworkPolicies *BackendNetworkPoliciesConfig `json:"backendNetworkPolicies,omitempty"`
+}
+
+// Defines the NetworkPolicies that are created for the workloads exposed by APIRules. The setting can be overridden per
+// APIRule with the `gateway.kyma-project.io/backend-network-policy` annotation set to `true` or `false`.
+type BackendNetworkPoliciesConfig struct {
+	// Enables a NetworkPolicy for each workload exposed by an APIRule that only allows ingress traffic from the Istio
+	// ingress gateway. Defaults to `false`.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Additionally allows ingress traffic from all workloads in the service mesh, that is, Pods with an Istio sidecar.
+	// Defaults to `false`.
+	// +optional
+	AllowMeshTraffic bool `json:"allowMeshTraffic,omitempty"`
 }
 
 // Defines the observed state of APIGateway CR.
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -92,11 +93,28 @@ func (in *APIGatewaySpec) DeepCopyInto(out *APIGatewaySpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.KymaGateway != nil {
+		in, out := &in.KymaGateway, &out.KymaGateway
+		*out = new(KymaGatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]ManagedGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NetworkPoliciesEnabled != nil {
 		in, out := &in.NetworkPoliciesEnabled, &out.NetworkPoliciesEnabled
 		*out = new(bool)
 		**out = **in
 	}
+	if in.APIRules != nil {
+		in, out := &in.APIRules, &out.APIRules
+		*out = new(APIRulesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIGatewaySpec.
@@ -130,3 +148,222 @@ func (in *APIGatewayStatus) DeepCopy() *APIGatewayStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIRulesConfig) DeepCopyInto(out *APIRulesConfig) {
+	*out = *in
+	if in.DefaultTimeout != nil {
+		in, out := &in.DefaultTimeout, &out.DefaultTimeout
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.DefaultCorsPolicy != nil {
+		in, out := &in.DefaultCorsPolicy, &out.DefaultCorsPolicy
+		*out = new(v2alpha1.CorsPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedJWTIssuers != nil {
+		in, out := &in.AllowedJWTIssuers, &out.AllowedJWTIssuers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisabledValidationChecks != nil {
+		in, out := &in.DisabledValidationChecks, &out.DisabledValidationChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackendNetworkPolicies != nil {
+		in, out := &in.BackendNetworkPolicies, &out.BackendNetworkPolicies
+		*out = new(BackendNetworkPoliciesConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIRulesConfig.
+func (in *APIRulesConfig) DeepCopy() *APIRulesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APIRulesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendNetworkPoliciesConfig) DeepCopyInto(out *BackendNetworkPoliciesConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendNetworkPoliciesConfig.
+func (in *BackendNetworkPoliciesConfig) DeepCopy() *BackendNetworkPoliciesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendNetworkPoliciesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerRef) DeepCopyInto(out *CertificateIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerRef.
+func (in *CertificateIssuerRef) DeepCopy() *CertificateIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressServiceRef) DeepCopyInto(out *IngressServiceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressServiceRef.
+func (in *IngressServiceRef) DeepCopy() *IngressServiceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressServiceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KymaGatewayCertificate) DeepCopyInto(out *KymaGatewayCertificate) {
+	*out = *in
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(CertificateIssuerRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KymaGatewayCertificate.
+func (in *KymaGatewayCertificate) DeepCopy() *KymaGatewayCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(KymaGatewayCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KymaGatewayConfig) DeepCopyInto(out *KymaGatewayConfig) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(KymaGatewayTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalHosts != nil {
+		in, out := &in.AdditionalHosts, &out.AdditionalHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(KymaGatewayCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(KymaGatewayDNS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KymaGatewayConfig.
+func (in *KymaGatewayConfig) DeepCopy() *KymaGatewayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KymaGatewayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KymaGatewayDNS) DeepCopyInto(out *KymaGatewayDNS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KymaGatewayDNS.
+func (in *KymaGatewayDNS) DeepCopy() *KymaGatewayDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(KymaGatewayDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KymaGatewayTLS) DeepCopyInto(out *KymaGatewayTLS) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KymaGatewayTLS.
+func (in *KymaGatewayTLS) DeepCopy() *KymaGatewayTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(KymaGatewayTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedGateway) DeepCopyInto(out *ManagedGateway) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IngressService != nil {
+		in, out := &in.IngressService, &out.IngressService
+		*out = new(IngressServiceRef)
+		**out = **in
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(KymaGatewayCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(KymaGatewayDNS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedGateway.
+func (in *ManagedGateway) DeepCopy() *ManagedGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedGateway)
+	in.DeepCopyInto(out)
+	return out
+}
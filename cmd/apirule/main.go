@@ -0,0 +1,137 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command apirule validates APIRules and renders the Istio resources generated for them without a cluster.
+//
+// Usage:
+//
+//	apirule render -f apirule.yaml -f gateway.yaml -f service.yaml
+//
+// The exit code is 0 if all APIRules are valid, 1 if at least one APIRule has validation failures
+// and 2 if the input could not be processed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/internal/render"
+)
+
+const (
+	exitOK                = 0
+	exitValidationFailure = 1
+	exitError             = 2
+)
+
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "render" {
+		_, _ = fmt.Fprintln(stderr, "Usage: apirule render -f <file> [-f <file>...]")
+		return exitError
+	}
+
+	var files fileList
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&files, "f", "File containing APIRule, Gateway, ExternalGateway, Service or other manifests. Use - to read from stdin. Can be repeated.")
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitError
+	}
+	files = append(files, flags.Args()...)
+
+	if len(files) == 0 {
+		_, _ = fmt.Fprintln(stderr, "At least one file must be provided")
+		return exitError
+	}
+
+	objs, err := readObjects(files, stdin)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	results, err := render.Render(context.Background(), objs)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	if err := render.WriteManifests(stdout, results); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	if err := render.WriteFailures(stderr, results); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	for _, result := range results {
+		if result.HasFailures() {
+			return exitValidationFailure
+		}
+	}
+
+	return exitOK
+}
+
+func readObjects(files []string, stdin io.Reader) ([]client.Object, error) {
+	var objs []client.Object
+	for _, file := range files {
+		fileObjs, err := readFile(file, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+		objs = append(objs, fileObjs...)
+	}
+	return objs, nil
+}
+
+// readFile decodes the objects of a single file, so the file is closed before the next one is opened.
+func readFile(file string, stdin io.Reader) ([]client.Object, error) {
+	if file == "-" {
+		return render.Decode(stdin)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return render.Decode(f)
+}
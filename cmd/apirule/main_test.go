@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	gatewayManifest = `
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+  name: kyma-gateway
+  namespace: kyma-system
+spec:
+  selector:
+    istio: ingressgateway
+  servers:
+  - hosts: ["*.local.kyma.dev"]
+    port: {number: 443, name: https, protocol: HTTPS}
+`
+	serviceManifest = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: test
+spec:
+  selector:
+    app: httpbin
+  ports:
+  - port: 8000
+`
+	apiRuleManifest = `
+apiVersion: gateway.kyma-project.io/v2
+kind: APIRule
+metadata:
+  name: httpbin
+  namespace: test
+spec:
+  hosts: [httpbin]
+  gateway: kyma-system/kyma-gateway
+  service:
+    name: httpbin
+    port: 8000
+  rules:
+  - path: /anything
+    methods: [GET]
+    noAuth: true
+`
+)
+
+var _ = Describe("run", func() {
+	writeFile := func(name, content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("should exit with 0 and write the manifests if all APIRules are valid", func() {
+		// given
+		apiRule := writeFile("apirule.yaml", apiRuleManifest)
+		gateway := writeFile("gateway.yaml", gatewayManifest)
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"render", "-f", apiRule, "-f", gateway, "-f", "-"}, strings.NewReader(serviceManifest), &stdout, &stderr)
+
+		// then
+		Expect(code).To(Equal(exitOK))
+		Expect(stdout.String()).To(ContainSubstring("kind: VirtualService"))
+		Expect(stderr.String()).To(BeEmpty())
+	})
+
+	It("should exit with 1 if an APIRule has validation failures", func() {
+		// given
+		apiRule := writeFile("apirule.yaml", apiRuleManifest)
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"render", "-f", apiRule}, strings.NewReader(""), &stdout, &stderr)
+
+		// then
+		Expect(code).To(Equal(exitValidationFailure))
+		Expect(stderr.String()).ToNot(BeEmpty())
+	})
+
+	It("should exit with 2 if no file is provided", func() {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"render"}, strings.NewReader(""), &stdout, &stderr)
+
+		// then
+		Expect(code).To(Equal(exitError))
+		Expect(stderr.String()).To(ContainSubstring("At least one file must be provided"))
+	})
+
+	It("should exit with 2 if a file does not exist", func() {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"render", "-f", filepath.Join(GinkgoT().TempDir(), "missing.yaml")}, strings.NewReader(""), &stdout, &stderr)
+
+		// then
+		Expect(code).To(Equal(exitError))
+		Expect(stdout.String()).To(BeEmpty())
+	})
+
+	It("should exit with 2 if the command is unknown", func() {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"validate"}, strings.NewReader(""), &stdout, &stderr)
+
+		// then
+		Expect(code).To(Equal(exitError))
+		Expect(stderr.String()).To(ContainSubstring("Usage: apirule render"))
+	})
+})
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/kyma-project/api-gateway/tests"
+)
+
+func TestApiRuleCLI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "APIRule CLI Suite")
+}
+
+var _ = ReportAfterSuite("custom reporter", func(report types.Report) {
+	tests.GenerateGinkgoJunitReport("apirule-cli-suite", report)
+})
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/kyma-project/api-gateway/internal/controller/gateway/external"
+	"github.com/kyma-project/api-gateway/internal/controller/gateway/janitor"
 	"github.com/kyma-project/api-gateway/internal/controller/gateway/ratelimit"
 	"github.com/kyma-project/api-gateway/internal/controller/operator"
 	"github.com/kyma-project/api-gateway/internal/reconciliations/oathkeeper"
@@ -33,6 +34,7 @@ import (
 
 	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
 	"github.com/kyma-project/api-gateway/internal/memlimit"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 	"github.com/kyma-project/api-gateway/internal/version"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
@@ -73,12 +75,16 @@ import (
 
 	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
 	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
+const tracingShutdownTimeout = 5 * time.Second
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -95,6 +101,21 @@ type FlagVar struct {
 	rateLimiterBurst            int
 	reconciliationInterval      time.Duration
 	migrationInterval           time.Duration
+	apiRuleAdmissionChecks      string
+	orphanCleanupInterval       time.Duration
+	orphanCleanupGracePeriod    time.Duration
+	orphanCleanupReportOnly     bool
+	otlpEndpoint                string
+	otlpInsecure                bool
+	tracingSampleRatio          float64
+	consolidateAPs              bool
+	leaderElectionID            string
+	apiRuleConcurrency          int
+	rateLimitConcurrency        int
+	externalGatewayConcurrency  int
+	watchNamespaces             string
+	watchLabelSelector          string
+	clusterControllers          bool
 }
 
 func init() {
@@ -117,6 +138,8 @@ func init() {
 	utilruntime.Must(networkingv1.AddToScheme(scheme))
 	utilruntime.Must(vpav1.AddToScheme(scheme))
 	utilruntime.Must(externalv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(hostpolicyv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(apirulepolicyv1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -142,6 +165,36 @@ func defineFlagVar() *FlagVar {
 		"Indicates the time based reconciliation interval of APIRule.")
 	flag.DurationVar(&flagVar.migrationInterval, "migration-interval", 1*time.Minute,
 		"Indicates the time taken between steps of APIRule version migration.")
+	flag.StringVar(&flagVar.apiRuleAdmissionChecks, "apirule-admission-checks", "",
+		"Comma separated list of check=mode pairs that define whether a failed APIRule validation check rejects the request (reject), returns a warning (warn) or is skipped (ignore) at admission.")
+	flag.DurationVar(&flagVar.orphanCleanupInterval, "orphan-cleanup-interval", janitor.DefaultInterval,
+		"Indicates the interval in which subresources of no longer existing APIRules are cleaned up. Set to 0 to disable the cleanup.")
+	flag.DurationVar(&flagVar.orphanCleanupGracePeriod, "orphan-cleanup-grace-period", janitor.DefaultGracePeriod,
+		"Indicates the minimum age of a subresource before it is considered orphaned.")
+	flag.BoolVar(&flagVar.orphanCleanupReportOnly, "orphan-cleanup-report-only", false,
+		"Only report orphaned subresources in logs, events and metrics instead of deleting them.")
+	flag.StringVar(&flagVar.otlpEndpoint, "otlp-endpoint", "",
+		"The OTLP/HTTP endpoint the reconciliation traces are exported to, e.g. otel-collector.kyma-system:4318. Overrides OTEL_EXPORTER_OTLP_ENDPOINT. Tracing is disabled if no endpoint is configured.")
+	flag.BoolVar(&flagVar.otlpInsecure, "otlp-insecure", false,
+		"Use HTTP instead of HTTPS to export traces to an OTLP endpoint without scheme.")
+	flag.Float64Var(&flagVar.tracingSampleRatio, "tracing-sample-ratio", 1,
+		"The fraction of reconciliations that are traced, between 0 and 1.")
+	flag.BoolVar(&flagVar.consolidateAPs, "consolidate-authorization-policies", false,
+		"Merge AuthorizationPolicies of an APIRule that only differ in their rules. Can be overridden per APIRule with the gateway.kyma-project.io/consolidate-authorization-policies annotation.")
+	flag.StringVar(&flagVar.leaderElectionID, "leader-election-id", "69358922.kyma-project.io",
+		"The name of the resource used for leader election. Operator instances with different watch scopes must use different IDs.")
+	flag.IntVar(&flagVar.apiRuleConcurrency, "apirule-max-concurrent-reconciles", 1,
+		"The maximum number of APIRules reconciled at the same time.")
+	flag.IntVar(&flagVar.rateLimitConcurrency, "ratelimit-max-concurrent-reconciles", 1,
+		"The maximum number of RateLimits reconciled at the same time.")
+	flag.IntVar(&flagVar.externalGatewayConcurrency, "externalgateway-max-concurrent-reconciles", 1,
+		"The maximum number of ExternalGateways reconciled at the same time.")
+	flag.StringVar(&flagVar.watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of namespaces in which APIRules, RateLimits, ExternalGateways and the subresources of the APIRules are watched. All namespaces are watched if not set.")
+	flag.StringVar(&flagVar.watchLabelSelector, "watch-label-selector", "",
+		"Label selector restricting the watched APIRules, RateLimits and ExternalGateways, e.g. tenant-group=a. All resources are watched if not set.")
+	flag.BoolVar(&flagVar.clusterControllers, "cluster-controllers", true,
+		"Run the cluster-wide APIGateway and certificate controllers, the webhooks and the orphan cleanup. Defaults to false if the watch scope is restricted, as only one instance may run them.")
 
 	return flagVar
 }
@@ -161,6 +214,16 @@ func main() {
 		setupLog.Info("Could not set GOMEMLIMIT from cgroup", "error", err)
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:    flagVar.otlpEndpoint,
+		Insecure:    flagVar.otlpInsecure,
+		SampleRatio: flagVar.tracingSampleRatio,
+	}, ctrl.Log.WithName("tracing"))
+	if err != nil {
+		setupLog.Error(err, "Unable to set up tracing")
+		os.Exit(1)
+	}
+
 	config := ctrl.GetConfigOrDie()
 	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
 	if err != nil {
@@ -179,6 +242,21 @@ func main() {
 		os.Exit(0)
 	}
 
+	watchScope, err := controller.ParseWatchScope(flagVar.watchNamespaces, flagVar.watchLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "Invalid watch scope")
+		os.Exit(1)
+	}
+	if watchScope.IsRestricted() {
+		setupLog.Info("Restricting watches", "namespaces", watchScope.Namespaces, "labelSelector", flagVar.watchLabelSelector)
+		if !isFlagSet("cluster-controllers") {
+			flagVar.clusterControllers = false
+		}
+	}
+	if !flagVar.clusterControllers {
+		setupLog.Info("Cluster-wide controllers, webhooks and orphan cleanup are disabled")
+	}
+
 	options := ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -186,7 +264,7 @@ func main() {
 		},
 		HealthProbeBindAddress: flagVar.probeAddr,
 		LeaderElection:         flagVar.enableLeaderElection,
-		LeaderElectionID:       "69358922.kyma-project.io",
+		LeaderElectionID:       flagVar.leaderElectionID,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			TLSOpts: []func(*tls.Config){
 				func(cfg *tls.Config) {
@@ -202,6 +280,12 @@ func main() {
 					},
 				},
 			}
+			for obj, byObject := range watchScope.CacheByObject() {
+				opts.ByObject[obj] = byObject
+			}
+			for obj, byObject := range watchScope.SubresourceCacheByObject() {
+				opts.ByObject[obj] = byObject
+			}
 			return cache.New(config, opts)
 		},
 		Client: client.Options{
@@ -229,14 +313,17 @@ func main() {
 	}
 
 	reconcileConfig := gateway.ApiRuleReconcilerConfiguration{
-		OathkeeperSvcAddr:             "ory-oathkeeper-proxy.kyma-system.svc.cluster.local",
-		OathkeeperSvcPort:             4455,
-		CorsAllowOrigins:              "regex:.*",
-		CorsAllowMethods:              "GET,POST,PUT,DELETE,PATCH",
-		CorsAllowHeaders:              "Authorization,Content-Type,*",
-		ReconciliationPeriod:          uint(flagVar.reconciliationInterval.Seconds()),
-		ErrorReconciliationPeriod:     60,
-		MigrationReconciliationPeriod: uint(flagVar.migrationInterval.Seconds()),
+		OathkeeperSvcAddr:                "ory-oathkeeper-proxy.kyma-system.svc.cluster.local",
+		OathkeeperSvcPort:                4455,
+		CorsAllowOrigins:                 "regex:.*",
+		CorsAllowMethods:                 "GET,POST,PUT,DELETE,PATCH",
+		CorsAllowHeaders:                 "Authorization,Content-Type,*",
+		ReconciliationPeriod:             uint(flagVar.reconciliationInterval.Seconds()),
+		ErrorReconciliationPeriod:        60,
+		MigrationReconciliationPeriod:    uint(flagVar.migrationInterval.Seconds()),
+		ConsolidateAuthorizationPolicies: flagVar.consolidateAPs,
+		MaxConcurrentReconciles:          flagVar.apiRuleConcurrency,
+		WatchScope:                       watchScope,
 	}
 
 	rateLimiterCfg := controller.RateLimiterConfig{
@@ -247,14 +334,14 @@ func main() {
 	}
 
 	metrics := apiGatewayMetrics.NewApiGatewayMetrics()
-
-	if err := webhookv2alpha1.SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "Unable to create webhook", "mutating-webhook", "APIRule")
+	if err := apiGatewayMetrics.RegisterResourceStateCollector(mgr, ctrl.Log.WithName("metrics")); err != nil {
+		setupLog.Error(err, "Unable to register the resource state metrics")
 		os.Exit(1)
 	}
 
-	if err = webhookv1beta1.SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "validating-webhook", "APIRule")
+	admissionValidationConfig, err := webhookv2alpha1.ParseValidationConfig(flagVar.apiRuleAdmissionChecks)
+	if err != nil {
+		setupLog.Error(err, "Invalid APIRule admission checks configuration")
 		os.Exit(1)
 	}
 
@@ -263,29 +350,57 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = operator.NewAPIGatewayReconciler(mgr, oathkeeper.NewReconciler()).SetupWithManager(mgr, rateLimiterCfg); err != nil {
-		setupLog.Error(err, "Unable to create controller", "controller", "APIGateway")
+	rateLimitReconciler := ratelimit.NewRateLimitReconciler(mgr)
+	rateLimitReconciler.MaxConcurrentReconciles = flagVar.rateLimitConcurrency
+	if err = rateLimitReconciler.SetupWithManager(mgr, rateLimiterCfg); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RateLimit")
 		os.Exit(1)
 	}
 
-	if err = certificate.NewCertificateReconciler(mgr).SetupWithManager(mgr, rateLimiterCfg); err != nil {
-		setupLog.Error(err, "Unable to create controller", "controller", "certificate")
+	externalGatewayReconciler := external.NewExternalGatewayReconciler(mgr)
+	externalGatewayReconciler.MaxConcurrentReconciles = flagVar.externalGatewayConcurrency
+	if err = externalGatewayReconciler.SetupWithManager(mgr, rateLimiterCfg); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ExternalGateway")
 		os.Exit(1)
 	}
 
-	if err = certificate.ReadCertificateSecret(context.Background(), k8sClient, setupLog); err != nil {
-		setupLog.Error(err, "Unable to read certificate secret", "webhook", "certificate")
-		os.Exit(1)
-	}
+	// The APIGateway and certificate controllers, the webhooks and the orphan cleanup are cluster-wide singletons,
+	// they are only run by the instance with unrestricted watch scope unless enabled explicitly.
+	if flagVar.clusterControllers {
+		if err := webhookv2alpha1.SetupWebhookWithManager(mgr, admissionValidationConfig); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "mutating-webhook", "APIRule")
+			os.Exit(1)
+		}
 
-	if err = ratelimit.NewRateLimitReconciler(mgr).SetupWithManager(mgr, rateLimiterCfg); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "RateLimit")
-		os.Exit(1)
-	}
+		if err = webhookv1beta1.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "validating-webhook", "APIRule")
+			os.Exit(1)
+		}
 
-	if err = external.NewExternalGatewayReconciler(mgr).SetupWithManager(mgr, rateLimiterCfg); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ExternalGateway")
-		os.Exit(1)
+		if err = operator.NewAPIGatewayReconciler(mgr, oathkeeper.NewReconciler()).SetupWithManager(mgr, rateLimiterCfg); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "APIGateway")
+			os.Exit(1)
+		}
+
+		if err = certificate.NewCertificateReconciler(mgr).SetupWithManager(mgr, rateLimiterCfg); err != nil {
+			setupLog.Error(err, "Unable to create controller", "controller", "certificate")
+			os.Exit(1)
+		}
+
+		if err = certificate.ReadCertificateSecret(context.Background(), k8sClient, setupLog); err != nil {
+			setupLog.Error(err, "Unable to read certificate secret", "webhook", "certificate")
+			os.Exit(1)
+		}
+
+		janitorConfig := janitor.Config{
+			Interval:    flagVar.orphanCleanupInterval,
+			GracePeriod: flagVar.orphanCleanupGracePeriod,
+			ReportOnly:  flagVar.orphanCleanupReportOnly,
+		}
+		if err = janitor.NewJanitor(mgr, janitorConfig, metrics).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create janitor", "janitor", "orphaned subresources")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -301,8 +416,26 @@ func main() {
 	setupLog.Info("Starting manager")
 	setupLog.Info("Module version", "version", version.GetModuleVersion())
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "Unable to flush traces")
+	}
+	cancel()
+
+	if err != nil {
 		setupLog.Error(err, "Problem running manager")
 		os.Exit(1)
 	}
 }
+
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
@@ -6,6 +6,7 @@ export default [
       { text: 'Configure mTLS Authentication on k3d', link: './istio-gateways/configure-mtls-k3d.md' },
     ]},
     { text: 'Kyma Gateway', link: './istio-gateways/kyma-gateway.md' },
+    { text: 'Managed Gateways', link: './istio-gateways/managed-gateways.md' },
   ]},
   { text: 'Exposing and Securing Workloads', link: './expose-workloads/README.md', collapsed: true, items: [
     { text: 'JWT Validation', link: './expose-workloads/jwt/README.md', collapsed: true, items: [
@@ -31,7 +32,9 @@ export default [
     { text: 'APIGateway Custom Resource', link: './custom-resources/apigateway/04-00-apigateway-custom-resource.md' },
     { text: 'APIRule Custom Resource', link: './custom-resources/apirule/04-10-apirule-custom-resource.md'},
     { text: 'RateLimit Custom Resource', link: './custom-resources/ratelimit/04-10-ratelimit-custom-resource.md' },
-    { text: 'ExternalGateway Custom Resource', link: './custom-resources/externalgateway/externalgateway-custom-resource.md' }
+    { text: 'ExternalGateway Custom Resource', link: './custom-resources/externalgateway/externalgateway-custom-resource.md' },
+    { text: 'HostPolicy Custom Resource', link: './custom-resources/hostpolicy/04-10-hostpolicy-custom-resource.md' },
+    { text: 'APIRulePolicy Custom Resource', link: './custom-resources/apirulepolicy/04-10-apirulepolicy-custom-resource.md' }
   ]},
   { text: 'APIRule Migration', link: './apirule-migration/README.md', collapsed: true, items: [
     { text: 'Migrate Multiple APIRules Targeting the Same Workload', link: './apirule-migration/01-90-migrate-multiple-apirules-targeting-same-workload.md' },
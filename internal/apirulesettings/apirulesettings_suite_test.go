@@ -0,0 +1,22 @@
+package apirulesettings_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/api-gateway/tests"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAPIRuleSettings(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecs(t, "APIRule Settings Suite")
+}
+
+var _ = ReportAfterSuite("custom reporter", func(report types.Report) {
+	tests.GenerateGinkgoJunitReport("apirulesettings-suite", report)
+})
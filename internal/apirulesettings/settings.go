@@ -0,0 +1,162 @@
+package apirulesettings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	"github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
+)
+
+const maxTimeout = 3900
+
+// Settings are the settings of the APIRule reconciliation that are configured in the apiRules section of the
+// APIGateway CR. The zero value uses the defaults of the reconciliation.
+type Settings struct {
+	// JWTHandler overrides the JWT handler of the api-gateway-config ConfigMap if it is not empty.
+	JWTHandler string
+	// DefaultTimeout is the timeout in seconds of APIRules that don't define a timeout. The default of the
+	// reconciliation is used if it is 0.
+	DefaultTimeout uint32
+	// DefaultCorsPolicy is the CORS policy of APIRules that don't define a CORS policy.
+	DefaultCorsPolicy *gatewayv2alpha1.CorsPolicy
+	// AllowedJwtIssuers are the issuers that can be used in JWT authentications. All issuers are allowed if it is empty.
+	AllowedJwtIssuers []string
+	// Checks are the validation checks enforced for APIRules. All checks are enforced if it is nil.
+	Checks []v2alpha1.Check
+	// BackendNetworkPolicies enables the NetworkPolicies that restrict the ingress traffic of exposed workloads to the
+	// Istio ingress gateway.
+	BackendNetworkPolicies bool
+	// BackendNetworkPoliciesAllowMeshTraffic additionally allows the traffic of workloads with an Istio sidecar in the
+	// NetworkPolicies.
+	BackendNetworkPoliciesAllowMeshTraffic bool
+}
+
+// Read returns the settings of the oldest APIGateway CR, which is the one reconciled by the operator. The default
+// settings are returned if there is no APIGateway CR. Invalid settings are not applied and are returned as an error
+// together with the valid settings.
+func Read(ctx context.Context, k8sClient client.Reader) (Settings, error) {
+	var apiGateways operatorv1alpha1.APIGatewayList
+	if err := k8sClient.List(ctx, &apiGateways); err != nil {
+		return Settings{}, fmt.Errorf("listing APIGateway CRs: %w", err)
+	}
+
+	apiGateway := operatorv1alpha1.GetOldestAPIGatewayCR(&apiGateways)
+	if apiGateway == nil {
+		return Settings{}, nil
+	}
+	return FromAPIGateway(apiGateway)
+}
+
+// FromAPIGateway returns the settings of the APIGateway CR. Invalid settings are not applied and are returned as an
+// error together with the valid settings.
+func FromAPIGateway(apiGateway *operatorv1alpha1.APIGateway) (Settings, error) {
+	config := apiGateway.Spec.APIRules
+	if config == nil {
+		return Settings{}, nil
+	}
+
+	var settings Settings
+	var errs []error
+
+	switch config.JWTHandler {
+	case "", helpers.JWT_HANDLER_ORY, helpers.JWT_HANDLER_ISTIO:
+		settings.JWTHandler = config.JWTHandler
+	default:
+		errs = append(errs, fmt.Errorf("apiRules.jwtHandler: unsupported JWT handler %q, supported handlers are %s and %s",
+			config.JWTHandler, helpers.JWT_HANDLER_ORY, helpers.JWT_HANDLER_ISTIO))
+	}
+
+	if config.DefaultTimeout != nil {
+		if *config.DefaultTimeout < 1 || *config.DefaultTimeout > maxTimeout {
+			errs = append(errs, fmt.Errorf("apiRules.defaultTimeout: timeout must be between 1 and %d seconds", maxTimeout))
+		} else {
+			settings.DefaultTimeout = *config.DefaultTimeout
+		}
+	}
+
+	if config.DefaultCorsPolicy != nil {
+		if err := validateCorsPolicy(config.DefaultCorsPolicy); err != nil {
+			errs = append(errs, fmt.Errorf("apiRules.defaultCorsPolicy: %w", err))
+		} else {
+			settings.DefaultCorsPolicy = config.DefaultCorsPolicy.DeepCopy()
+		}
+	}
+
+	for i, issuer := range config.AllowedJWTIssuers {
+		if err := validateIssuer(issuer); err != nil {
+			errs = append(errs, fmt.Errorf("apiRules.allowedJwtIssuers[%d]: %w", i, err))
+		} else {
+			settings.AllowedJwtIssuers = append(settings.AllowedJwtIssuers, issuer)
+		}
+	}
+	if len(config.AllowedJWTIssuers) > 0 && len(settings.AllowedJwtIssuers) == 0 {
+		// Allowing all issuers because none of the configured issuers is valid would be less restrictive than intended.
+		settings.AllowedJwtIssuers = config.AllowedJWTIssuers
+	}
+
+	if config.BackendNetworkPolicies != nil {
+		settings.BackendNetworkPolicies = config.BackendNetworkPolicies.Enabled
+		settings.BackendNetworkPoliciesAllowMeshTraffic = config.BackendNetworkPolicies.AllowMeshTraffic
+	}
+
+	var disabled []v2alpha1.Check
+	for i, name := range config.DisabledValidationChecks {
+		check, err := v2alpha1.ParseCheck(name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("apiRules.disabledValidationChecks[%d]: %w", i, err))
+			continue
+		}
+		disabled = append(disabled, check)
+	}
+	if len(disabled) > 0 {
+		settings.Checks = []v2alpha1.Check{}
+		for _, check := range v2alpha1.AllChecks {
+			if !slices.Contains(disabled, check) {
+				settings.Checks = append(settings.Checks, check)
+			}
+		}
+	}
+
+	return settings, errors.Join(errs...)
+}
+
+func validateCorsPolicy(policy *gatewayv2alpha1.CorsPolicy) error {
+	for _, match := range policy.AllowOrigins {
+		for key, value := range match {
+			switch key {
+			case gatewayv2alpha1.Exact, gatewayv2alpha1.Prefix:
+			case gatewayv2alpha1.Regex:
+				if _, err := regexp.Compile(value); err != nil {
+					return fmt.Errorf("invalid regex %q in allowOrigins: %w", value, err)
+				}
+			default:
+				return fmt.Errorf("unsupported match type %q in allowOrigins, supported types are %s, %s and %s",
+					key, gatewayv2alpha1.Exact, gatewayv2alpha1.Prefix, gatewayv2alpha1.Regex)
+			}
+		}
+	}
+	return nil
+}
+
+func validateIssuer(issuer string) error {
+	if issuer == "" {
+		return errors.New("value is empty")
+	}
+	// The issuer doesn't need to be a URI, but if it contains a colon it has to be a valid URI.
+	if strings.Contains(issuer, ":") {
+		if invalid, err := validation.IsInvalidURI(issuer); invalid {
+			return err
+		}
+	}
+	return nil
+}
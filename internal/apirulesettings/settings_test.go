@@ -0,0 +1,122 @@
+package apirulesettings_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/apirulesettings"
+	"github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
+)
+
+func apiGatewayWith(config *operatorv1alpha1.APIRulesConfig) *operatorv1alpha1.APIGateway {
+	return &operatorv1alpha1.APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       operatorv1alpha1.APIGatewaySpec{APIRules: config},
+	}
+}
+
+var _ = Describe("FromAPIGateway", func() {
+	It("should return the default settings if the apiRules section is not set", func() {
+		settings, err := apirulesettings.FromAPIGateway(apiGatewayWith(nil))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(Equal(apirulesettings.Settings{}))
+	})
+
+	It("should return the configured settings", func() {
+		corsPolicy := &gatewayv2alpha1.CorsPolicy{
+			AllowOrigins: gatewayv2alpha1.StringMatch{{"regex": "https://.*\\.example\\.com"}, {"exact": "https://example.com"}},
+			AllowMethods: []string{"GET"},
+		}
+
+		settings, err := apirulesettings.FromAPIGateway(apiGatewayWith(&operatorv1alpha1.APIRulesConfig{
+			JWTHandler:               "istio",
+			DefaultTimeout:           ptr.To(uint32(300)),
+			DefaultCorsPolicy:        corsPolicy,
+			AllowedJWTIssuers:        []string{"https://issuer.example.com", "internal-issuer"},
+			DisabledValidationChecks: []string{"sidecarInjection", "hostPolicies"},
+			BackendNetworkPolicies:   &operatorv1alpha1.BackendNetworkPoliciesConfig{Enabled: true, AllowMeshTraffic: true},
+		}))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.JWTHandler).To(Equal("istio"))
+		Expect(settings.DefaultTimeout).To(Equal(uint32(300)))
+		Expect(settings.DefaultCorsPolicy).To(Equal(corsPolicy))
+		Expect(settings.AllowedJwtIssuers).To(Equal([]string{"https://issuer.example.com", "internal-issuer"}))
+		Expect(settings.Checks).To(HaveLen(len(v2alpha1.AllChecks) - 2))
+		Expect(settings.Checks).ToNot(ContainElements(v2alpha1.CheckSidecarInjection, v2alpha1.CheckHostPolicies))
+		Expect(settings.BackendNetworkPolicies).To(BeTrue())
+		Expect(settings.BackendNetworkPoliciesAllowMeshTraffic).To(BeTrue())
+	})
+
+	It("should not apply invalid settings and return an error for each of them", func() {
+		settings, err := apirulesettings.FromAPIGateway(apiGatewayWith(&operatorv1alpha1.APIRulesConfig{
+			JWTHandler:               "unknown",
+			DefaultTimeout:           ptr.To(uint32(4000)),
+			DefaultCorsPolicy:        &gatewayv2alpha1.CorsPolicy{AllowOrigins: gatewayv2alpha1.StringMatch{{"regex": "("}}},
+			AllowedJWTIssuers:        []string{"https://issuer.example.com", ""},
+			DisabledValidationChecks: []string{"jwt", "unknown"},
+		}))
+
+		Expect(err).To(MatchError(ContainSubstring("apiRules.jwtHandler")))
+		Expect(err).To(MatchError(ContainSubstring("apiRules.defaultTimeout")))
+		Expect(err).To(MatchError(ContainSubstring("apiRules.defaultCorsPolicy")))
+		Expect(err).To(MatchError(ContainSubstring("apiRules.allowedJwtIssuers[1]")))
+		Expect(err).To(MatchError(ContainSubstring("apiRules.disabledValidationChecks[1]")))
+		Expect(settings.JWTHandler).To(BeEmpty())
+		Expect(settings.DefaultTimeout).To(BeZero())
+		Expect(settings.DefaultCorsPolicy).To(BeNil())
+		Expect(settings.AllowedJwtIssuers).To(Equal([]string{"https://issuer.example.com"}))
+		Expect(settings.Checks).ToNot(ContainElement(v2alpha1.CheckJwt))
+	})
+
+	It("should not allow all issuers if none of the allowed issuers is valid", func() {
+		settings, err := apirulesettings.FromAPIGateway(apiGatewayWith(&operatorv1alpha1.APIRulesConfig{
+			AllowedJWTIssuers: []string{"http://[invalid"},
+		}))
+
+		Expect(err).To(HaveOccurred())
+		Expect(settings.AllowedJwtIssuers).ToNot(BeEmpty())
+	})
+})
+
+var _ = Describe("Read", func() {
+	newClient := func(objs ...*operatorv1alpha1.APIGateway) *fake.ClientBuilder {
+		scheme := runtime.NewScheme()
+		Expect(operatorv1alpha1.AddToScheme(scheme)).To(Succeed())
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, obj := range objs {
+			builder = builder.WithObjects(obj)
+		}
+		return builder
+	}
+
+	It("should return the default settings if there is no APIGateway CR", func() {
+		settings, err := apirulesettings.Read(context.Background(), newClient().Build())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings).To(Equal(apirulesettings.Settings{}))
+	})
+
+	It("should return the settings of the oldest APIGateway CR", func() {
+		oldest := apiGatewayWith(&operatorv1alpha1.APIRulesConfig{DefaultTimeout: ptr.To(uint32(60))})
+		oldest.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		newer := apiGatewayWith(&operatorv1alpha1.APIRulesConfig{DefaultTimeout: ptr.To(uint32(120))})
+		newer.Name = "newer"
+		newer.CreationTimestamp = metav1.Now()
+
+		settings, err := apirulesettings.Read(context.Background(), newClient(oldest, newer).Build())
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(settings.DefaultTimeout).To(Equal(uint32(60)))
+	})
+})
@@ -13,11 +13,37 @@ var (
 	DependenciesMissing              = ReasonMessage{"DependenciesMissing", "Module dependencies missing", metav1.ConditionFalse}
 	KymaGatewayReconcileSucceeded    = ReasonMessage{"KymaGatewayReconcileSucceeded", "Kyma Gateway reconciliation succeeded", metav1.ConditionFalse}
 	KymaGatewayReconcileFailed       = ReasonMessage{"KymaGatewayReconcileFailed", "Kyma Gateway reconciliation failed", metav1.ConditionFalse}
+	KymaGatewayMisconfigured         = ReasonMessage{"KymaGatewayMisconfigured", "Kyma Gateway configuration is invalid", metav1.ConditionFalse}
 	KymaGatewayDeletionBlocked       = ReasonMessage{"KymaGatewayDeletionBlocked", "Kyma Gateway deletion blocked because of the existing custom resources", metav1.ConditionFalse}
+	KymaGatewayCertificateFailed     = ReasonMessage{"KymaGatewayCertificateFailed", "Kyma Gateway certificate could not be issued", metav1.ConditionFalse}
+	KymaGatewayDNSEntryFailed        = ReasonMessage{"KymaGatewayDNSEntryFailed", "Kyma Gateway DNS record could not be created", metav1.ConditionFalse}
+	GatewaysReconcileSucceeded       = ReasonMessage{"GatewaysReconcileSucceeded", "Gateways reconciliation succeeded", metav1.ConditionFalse}
+	GatewaysReconcileFailed          = ReasonMessage{"GatewaysReconcileFailed", "Gateways reconciliation failed", metav1.ConditionFalse}
+	GatewaysMisconfigured            = ReasonMessage{"GatewaysMisconfigured", "Gateways configuration is invalid", metav1.ConditionFalse}
+	GatewaysDeletionBlocked          = ReasonMessage{"GatewaysDeletionBlocked", "Gateways deletion blocked because of the existing custom resources", metav1.ConditionFalse}
 	OathkeeperReconcileSucceeded     = ReasonMessage{"OathkeeperReconcileSucceeded", "Ory Oathkeeper reconciliation succeeded", metav1.ConditionFalse}
 	OathkeeperReconcileFailed        = ReasonMessage{"OathkeeperReconcileFailed", "Ory Oathkeeper reconciliation failed", metav1.ConditionFalse}
 	OathkeeperReconcileDisabled      = ReasonMessage{"OathkeeperReconcileDisabled", "Ory Oathkeeper reconciliation disabled", metav1.ConditionFalse}
 	DeletionBlockedExistingResources = ReasonMessage{"DeletionBlockedExistingResources", "API Gateway deletion blocked because of the existing custom resources", metav1.ConditionFalse}
+	DeletionForceCleanupInProgress   = ReasonMessage{"DeletionForceCleanupInProgress", "API Gateway deletion is deleting the existing custom resources", metav1.ConditionFalse}
+)
+
+// CertificateReady is the type of the condition that reports the readiness of the Kyma Gateway certificate.
+const CertificateReady = "CertificateReady"
+
+var (
+	CertificateIssued  = ReasonMessage{"CertificateIssued", "Kyma Gateway certificate is ready", metav1.ConditionTrue}
+	CertificatePending = ReasonMessage{"CertificatePending", "Kyma Gateway certificate is being issued", metav1.ConditionFalse}
+	CertificateFailed  = ReasonMessage{"CertificateFailed", "Kyma Gateway certificate could not be issued", metav1.ConditionFalse}
+)
+
+// DNSEntryReady is the type of the condition that reports the readiness of the Kyma Gateway DNS record.
+const DNSEntryReady = "DNSEntryReady"
+
+var (
+	DNSEntryCreated = ReasonMessage{"DNSEntryCreated", "Kyma Gateway DNS record is ready", metav1.ConditionTrue}
+	DNSEntryPending = ReasonMessage{"DNSEntryPending", "Kyma Gateway DNS record is being created", metav1.ConditionFalse}
+	DNSEntryFailed  = ReasonMessage{"DNSEntryFailed", "Kyma Gateway DNS record could not be created", metav1.ConditionFalse}
 )
 
 // ReasonMessage is a struct that defines different states of Ready condition
@@ -36,6 +62,13 @@ func (rm ReasonMessage) Condition() *metav1.Condition {
 	}
 }
 
+// ConditionWithType returns metav1.Condition of the given type from existing ReasonMessage
+func (rm ReasonMessage) ConditionWithType(conditionType string) *metav1.Condition {
+	condition := rm.Condition()
+	condition.Type = conditionType
+	return condition
+}
+
 // AdditionalMessage adds additional string message to already defined message field in ReasonMessage
 // and returns a new ReasonMessage based on parent
 func (rm ReasonMessage) AdditionalMessage(message string) ReasonMessage {
@@ -22,4 +22,16 @@ var _ = Describe("ReasonMessage", func() {
 
 	})
 
+	Context("ConditionWithType", func() {
+		It("should return a condition with the given type", func() {
+			// when
+			result := conditions.CertificatePending.ConditionWithType(conditions.CertificateReady)
+
+			// then
+			Expect(result.Type).To(Equal("CertificateReady"))
+			Expect(result.Reason).To(Equal("CertificatePending"))
+			Expect(conditions.CertificatePending.Condition().Type).To(Equal("Ready"))
+		})
+	})
+
 })
@@ -28,27 +28,97 @@ const (
 
 // original code reference: https://github.com/kubernetes/client-go/blob/master/util/cert/cert.go
 func GenerateSelfSignedCertificate(host string, alternateIPs []net.IP, alternateDNS []string, maxAge time.Duration) ([]byte, []byte, error) {
+	certBytes, keyBytes, _, err := GenerateSelfSignedCertificateWithCA(host, alternateIPs, alternateDNS, maxAge)
+	return certBytes, keyBytes, err
+}
+
+// GenerateSelfSignedCertificateWithCA works like GenerateSelfSignedCertificate, but additionally returns the PEM encoded
+// CA certificate, so that clients can be configured to trust the certificate.
+func GenerateSelfSignedCertificateWithCA(host string, alternateIPs []net.IP, alternateDNS []string, maxAge time.Duration) ([]byte, []byte, []byte, error) {
 	validFrom := time.Now().Add(-time.Hour) // valid an hour earlier to avoid flakes due to clock skew
 
 	// Create CA certificate
 	caKey, caCertificate, caDERBytes, err := createCACertificate(host, validFrom, maxAge)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Create certificate
 	certKey, certDERBytes, err := createCertificate(host, validFrom, maxAge, alternateIPs, alternateDNS, caKey, caCertificate)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Certificate followed by the CA certificate
 	certBytes, err := encodePEMBlock("CERTIFICATE", certDERBytes, caDERBytes)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Key
+	keyBytes, err := encodePEMBlock(keyutil.RSAPrivateKeyBlockType, x509.MarshalPKCS1PrivateKey(certKey))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	caBytes, err := encodePEMBlock("CERTIFICATE", caDERBytes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return certBytes, keyBytes, caBytes, nil
+}
+
+// GenerateCA returns a PEM encoded self-signed CA certificate and its key. Certificates signed by the CA are generated
+// with GenerateCertificateSignedByCA.
+func GenerateCA(host string, maxAge time.Duration) ([]byte, []byte, error) {
+	validFrom := time.Now().Add(-time.Hour) // valid an hour earlier to avoid flakes due to clock skew
+
+	caKey, _, caDERBytes, err := createCACertificate(host, validFrom, maxAge)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caBytes, err := encodePEMBlock("CERTIFICATE", caDERBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyBytes, err := encodePEMBlock(keyutil.RSAPrivateKeyBlockType, x509.MarshalPKCS1PrivateKey(caKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return caBytes, keyBytes, nil
+}
+
+// GenerateCertificateSignedByCA returns a PEM encoded certificate signed by the PEM encoded CA certificate and key, and
+// the key of the certificate. The certificate is followed by the CA certificate.
+func GenerateCertificateSignedByCA(host string, alternateIPs []net.IP, alternateDNS []string, maxAge time.Duration, caBytes, caKeyBytes []byte) ([]byte, []byte, error) {
+	caCertificates, err := cert.ParseCertsPEM(caBytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+	parsedKey, err := keyutil.ParsePrivateKeyPEM(caKeyBytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA key")
+	}
+	caKey, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("CA key is not an RSA key")
+	}
+
+	validFrom := time.Now().Add(-time.Hour) // valid an hour earlier to avoid flakes due to clock skew
+	certKey, certDERBytes, err := createCertificate(host, validFrom, maxAge, alternateIPs, alternateDNS, caKey, caCertificates[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certBytes, err := encodePEMBlock("CERTIFICATE", certDERBytes, caCertificates[0].Raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	keyBytes, err := encodePEMBlock(keyutil.RSAPrivateKeyBlockType, x509.MarshalPKCS1PrivateKey(certKey))
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// eventRecordingClient records an Event on the owner for each object that is created, updated, patched or deleted
+// with the client. Writes of the owner itself and updates that didn't modify the object are not recorded.
+type eventRecordingClient struct {
+	client.Client
+	recorder events.EventRecorder
+	owner    client.Object
+}
+
+// NewEventRecordingClient returns a client that records the changes of subresources as Events on the owner. It's
+// used for reconciliations that write their subresources directly with the client.
+func NewEventRecordingClient(k8sClient client.Client, recorder events.EventRecorder, owner client.Object) client.Client {
+	if recorder == nil {
+		return k8sClient
+	}
+	return &eventRecordingClient{Client: k8sClient, recorder: recorder, owner: owner}
+}
+
+func (c *eventRecordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	c.record(EventActionCreate, obj, err)
+	return err
+}
+
+func (c *eventRecordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	resourceVersion := obj.GetResourceVersion()
+	err := c.Client.Update(ctx, obj, opts...)
+	if err == nil && obj.GetResourceVersion() == resourceVersion {
+		return nil
+	}
+	c.record(EventActionUpdate, obj, err)
+	return err
+}
+
+func (c *eventRecordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	resourceVersion := obj.GetResourceVersion()
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	if err == nil && obj.GetResourceVersion() == resourceVersion {
+		return nil
+	}
+	c.record(EventActionUpdate, obj, err)
+	return err
+}
+
+func (c *eventRecordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	if apierrors.IsNotFound(err) {
+		return err
+	}
+	c.record(EventActionDelete, obj, err)
+	return err
+}
+
+func (c *eventRecordingClient) record(action string, obj client.Object, err error) {
+	if obj.GetUID() != "" && obj.GetUID() == c.owner.GetUID() {
+		return
+	}
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		if gvk, gvkErr := apiutil.GVKForObject(obj, c.Scheme()); gvkErr == nil {
+			kind = gvk.Kind
+		}
+	}
+	RecordSubresourceChange(c.recorder, c.owner, action, kind, obj.GetNamespace(), obj.GetName(), err)
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+// Reasons of the Events recorded by the controllers. Users and tools filter Events by these reasons, so they must
+// not be changed.
+const (
+	EventReasonSubresourceCreated = "SubresourceCreated"
+	EventReasonSubresourceUpdated = "SubresourceUpdated"
+	EventReasonSubresourceDeleted = "SubresourceDeleted"
+	EventReasonSubresourceFailed  = "SubresourceFailed"
+	EventReasonValidationFailed   = "ValidationFailed"
+	EventReasonDependencyMissing  = "DependencyMissing"
+	EventReasonStateChanged       = "StateChanged"
+)
+
+// Actions of the Events recorded by the controllers.
+const (
+	EventActionCreate       = "Create"
+	EventActionUpdate       = "Update"
+	EventActionDelete       = "Delete"
+	EventActionValidate     = "Validate"
+	EventActionReconcile    = "Reconcile"
+	EventActionUpdateStatus = "UpdateStatus"
+)
+
+// maxEventNoteLength is the maximum length of the note of an Event that is accepted by the API server.
+const maxEventNoteLength = 1024
+
+type previousStateKey struct{}
+
+// WithPreviousState stores the state of the reconciled object at the start of the reconciliation. Controllers that
+// set an intermediate state during the reconciliation use it to record only the transition from the previous to the
+// final state.
+func WithPreviousState(ctx context.Context, state string) context.Context {
+	return context.WithValue(ctx, previousStateKey{}, state)
+}
+
+// PreviousState returns the state stored with WithPreviousState.
+func PreviousState(ctx context.Context) string {
+	state, _ := ctx.Value(previousStateKey{}).(string)
+	return state
+}
+
+// RecordStateTransition records an Event if the state of the object changed. Transitions to the Error and Warning
+// states are recorded as Warning Events, all other transitions as Normal Events.
+func RecordStateTransition(recorder events.EventRecorder, obj runtime.Object, oldState, newState, description string) {
+	if recorder == nil || oldState == newState || newState == "" {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if newState == "Error" || newState == "Warning" {
+		eventType = corev1.EventTypeWarning
+	}
+
+	note := fmt.Sprintf("State changed from %s to %s", oldState, newState)
+	if oldState == "" {
+		note = fmt.Sprintf("State changed to %s", newState)
+	}
+	if description != "" {
+		note = fmt.Sprintf("%s: %s", note, description)
+	}
+	recordEvent(recorder, obj, eventType, EventReasonStateChanged, EventActionUpdateStatus, note)
+}
+
+// RecordValidationFailures records a Warning Event that lists the validation failures of the object.
+func RecordValidationFailures(recorder events.EventRecorder, obj runtime.Object, failures []validation.Failure) {
+	if recorder == nil || len(failures) == 0 {
+		return
+	}
+
+	messages := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		messages = append(messages, fmt.Sprintf("%s: %s", failure.AttributePath, failure.Message))
+	}
+	note := fmt.Sprintf("Validation failed with %d failure(s): %s", len(failures), strings.Join(messages, "; "))
+	recordEvent(recorder, obj, corev1.EventTypeWarning, EventReasonValidationFailed, EventActionValidate, note)
+}
+
+// RecordValidationError records a Warning Event for a validation that failed with a single error.
+func RecordValidationError(recorder events.EventRecorder, obj runtime.Object, err error) {
+	if recorder == nil || err == nil {
+		return
+	}
+	recordEvent(recorder, obj, corev1.EventTypeWarning, EventReasonValidationFailed, EventActionValidate,
+		fmt.Sprintf("Validation failed: %s", err))
+}
+
+// RecordDependencyMissing records a Warning Event for a CRD that is required for the reconciliation of the object,
+// but is not present in the cluster.
+func RecordDependencyMissing(recorder events.EventRecorder, obj runtime.Object, name string) {
+	if recorder == nil {
+		return
+	}
+	recordEvent(recorder, obj, corev1.EventTypeWarning, EventReasonDependencyMissing, EventActionReconcile,
+		fmt.Sprintf("CRD %s is not present. Make sure to install required dependencies for the component", name))
+}
+
+// RecordSubresourceChange records an Event for a subresource of the object that was created, updated or deleted.
+// If err is not nil, a Warning Event for the failed change is recorded instead.
+func RecordSubresourceChange(recorder events.EventRecorder, obj runtime.Object, action, kind, namespace, name string, err error) {
+	if recorder == nil {
+		return
+	}
+
+	if err != nil {
+		recordEvent(recorder, obj, corev1.EventTypeWarning, EventReasonSubresourceFailed, action,
+			fmt.Sprintf("Failed to %s %s %s/%s: %s", strings.ToLower(action), kind, namespace, name, err))
+		return
+	}
+
+	var reason, verb string
+	switch action {
+	case EventActionCreate:
+		reason, verb = EventReasonSubresourceCreated, "Created"
+	case EventActionUpdate:
+		reason, verb = EventReasonSubresourceUpdated, "Updated"
+	case EventActionDelete:
+		reason, verb = EventReasonSubresourceDeleted, "Deleted"
+	default:
+		return
+	}
+	recordEvent(recorder, obj, corev1.EventTypeNormal, reason, action, fmt.Sprintf("%s %s %s/%s", verb, kind, namespace, name))
+}
+
+func recordEvent(recorder events.EventRecorder, obj runtime.Object, eventType, reason, action, note string) {
+	if len(note) > maxEventNoteLength {
+		note = note[:maxEventNoteLength-3] + "..."
+	}
+	recorder.Eventf(obj, nil, eventType, reason, action, "%s", note)
+}
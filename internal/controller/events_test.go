@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func recordedEvents(recorder *events.FakeRecorder) []string {
+	var recorded []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			recorded = append(recorded, e)
+		default:
+			return recorded
+		}
+	}
+}
+
+var _ = Describe("events", func() {
+	cr := &operatorv1alpha1.APIGateway{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "owner-uid"}}
+
+	Context("RecordStateTransition", func() {
+		It("Should record a Normal Event for a transition to Ready", func() {
+			recorder := events.NewFakeRecorder(10)
+
+			RecordStateTransition(recorder, cr, "Processing", "Ready", "Successfully reconciled")
+
+			Expect(recordedEvents(recorder)).To(ConsistOf("Normal StateChanged State changed from Processing to Ready: Successfully reconciled"))
+		})
+
+		It("Should record a Warning Event for a transition to Error", func() {
+			recorder := events.NewFakeRecorder(10)
+
+			RecordStateTransition(recorder, cr, "", "Error", "")
+
+			Expect(recordedEvents(recorder)).To(ConsistOf("Warning StateChanged State changed to Error"))
+		})
+
+		It("Should not record an Event if the state didn't change", func() {
+			recorder := events.NewFakeRecorder(10)
+
+			RecordStateTransition(recorder, cr, "Ready", "Ready", "Successfully reconciled")
+
+			Expect(recordedEvents(recorder)).To(BeEmpty())
+		})
+
+		It("Should truncate long descriptions", func() {
+			recorder := events.NewFakeRecorder(10)
+
+			RecordStateTransition(recorder, cr, "Ready", "Warning", strings.Repeat("a", 2000))
+
+			recorded := recordedEvents(recorder)
+			Expect(recorded).To(HaveLen(1))
+			Expect(len(recorded[0])).To(BeNumerically("<=", len("Warning StateChanged ")+maxEventNoteLength))
+			Expect(recorded[0]).To(HaveSuffix("..."))
+		})
+	})
+
+	Context("RecordValidationFailures", func() {
+		It("Should record a Warning Event with all failures", func() {
+			recorder := events.NewFakeRecorder(10)
+
+			RecordValidationFailures(recorder, cr, []validation.Failure{
+				{AttributePath: "spec.hosts[0]", Message: "Host is not allowed"},
+				{AttributePath: "spec.rules[0].path", Message: "Path is invalid"},
+			})
+
+			Expect(recordedEvents(recorder)).To(ConsistOf("Warning ValidationFailed Validation failed with 2 failure(s): " +
+				"spec.hosts[0]: Host is not allowed; spec.rules[0].path: Path is invalid"))
+		})
+	})
+
+	Context("RecordSubresourceChange", func() {
+		It("Should record a Warning Event for a failed change", func() {
+			recorder := events.NewFakeRecorder(10)
+
+			RecordSubresourceChange(recorder, cr, EventActionUpdate, "Gateway", "kyma-system", "kyma-gateway", errors.New("conflict"))
+
+			Expect(recordedEvents(recorder)).To(ConsistOf("Warning SubresourceFailed Failed to update Gateway kyma-system/kyma-gateway: conflict"))
+		})
+	})
+
+	Context("NewEventRecordingClient", func() {
+		It("Should record Events for changes of subresources", func() {
+			// given
+			recorder := events.NewFakeRecorder(10)
+			k8sClient := NewEventRecordingClient(createFakeClient(), recorder, cr)
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "default"}}
+
+			// when
+			Expect(k8sClient.Create(context.Background(), cm)).To(Succeed())
+			cm.Data = map[string]string{"key": "value"}
+			Expect(k8sClient.Update(context.Background(), cm)).To(Succeed())
+			Expect(k8sClient.Delete(context.Background(), cm)).To(Succeed())
+
+			// then
+			Expect(recordedEvents(recorder)).To(Equal([]string{
+				"Normal SubresourceCreated Created ConfigMap default/test-cm",
+				"Normal SubresourceUpdated Updated ConfigMap default/test-cm",
+				"Normal SubresourceDeleted Deleted ConfigMap default/test-cm",
+			}))
+		})
+
+		It("Should not record Events for deletions of objects that don't exist and for writes of the owner", func() {
+			// given
+			recorder := events.NewFakeRecorder(10)
+			owner := cr.DeepCopy()
+			k8sClient := NewEventRecordingClient(createFakeClient(owner), recorder, owner)
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "not-existing", Namespace: "default"}}
+
+			// when
+			Expect(client.IgnoreNotFound(k8sClient.Delete(context.Background(), cm))).To(Succeed())
+			owner.Finalizers = []string{"test"}
+			Expect(k8sClient.Update(context.Background(), owner)).To(Succeed())
+
+			// then
+			Expect(recordedEvents(recorder)).To(BeEmpty())
+		})
+	})
+})
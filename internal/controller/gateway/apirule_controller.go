@@ -28,27 +28,35 @@ import (
 
 	"github.com/kyma-project/api-gateway/internal/gatewaytranslator"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/migration"
+	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/networkpolicy"
 	"github.com/kyma-project/api-gateway/internal/subresources/accessrule"
 
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	runtimecontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
 	"github.com/kyma-project/api-gateway/internal/access"
+	"github.com/kyma-project/api-gateway/internal/apirulesettings"
 	"github.com/kyma-project/api-gateway/internal/controller"
 	"github.com/kyma-project/api-gateway/internal/dependencies"
 	"github.com/kyma-project/api-gateway/internal/processing/default_domain"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/istio"
 	v2alpha1Processing "github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
 	"github.com/kyma-project/api-gateway/internal/processing/status"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 	"github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -59,6 +67,7 @@ import (
 
 	"github.com/go-logr/logr"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -80,6 +89,9 @@ const (
 // +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules/finalizers,verbs=update
 // +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=externalgateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=hostpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirulepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.kyma-project.io,resources=apigateways,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch
 // +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=rules,verbs=get;list;watch;create;update;patch;delete
@@ -89,11 +101,14 @@ const (
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 
 func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	l := r.Log.WithValues("namespace", req.Namespace, "APIRule", req.Name)
 	l.Info("Starting reconciliation")
 	ctx = logr.NewContext(ctx, r.Log)
+	ctx, span := tracing.Start(ctx, "APIRuleReconciler.Reconcile", tracing.APIRuleAttributes(req.Name, req.Namespace)...)
+	defer span.End()
 
 	isCMReconcile := req.String() == types.NamespacedName{
 		Namespace: helpers.CM_NS, Name: helpers.CM_NAME}.String()
@@ -102,6 +117,22 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if finishReconcile {
 		return doneReconcileNoRequeue()
 	}
+
+	// Requests are also enqueued for changes of subresources in namespaces outside the watch scope, and APIRules are
+	// read from the API server instead of the scoped cache, so APIRules outside the scope must be skipped. The
+	// api-gateway-config ConfigMap is handled above regardless of the scope, because it configures all APIRules.
+	if !r.WatchScope.ContainsNamespace(req.Namespace) {
+		l.Info("Skipping APIRule outside the watch scope")
+		return doneReconcileNoRequeue()
+	}
+
+	// Invalid settings of the APIGateway CR are reported in its conditions and the valid settings are still applied.
+	settings, err := apirulesettings.Read(ctx, r.Client)
+	if err != nil {
+		l.Error(err, "Failed to read the APIRule settings of the APIGateway CR, using the valid settings")
+	}
+	config := r.effectiveConfig(settings)
+
 	apiRuleV2alpha1 := &gatewayv2alpha1.APIRule{}
 
 	if err := r.Get(ctx, req.NamespacedName, apiRuleV2alpha1); err != nil {
@@ -112,11 +143,18 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return doneReconcileErrorRequeue(err, errorReconciliationPeriod)
 	}
 
+	if !r.WatchScope.Contains(apiRuleV2alpha1) {
+		l.Info("Skipping APIRule outside the watch scope")
+		return doneReconcileNoRequeue()
+	}
+
+	ctx = controller.WithPreviousState(ctx, string(apiRuleV2alpha1.Status.State))
+
 	// assign LastProcessedTime early to indicate that resource got reconciled
 	apiRuleV2alpha1.Status.LastProcessedTime = metav1.Now()
 
 	apiRule := gatewayv1beta1.APIRule{}
-	err := apiRule.ConvertFrom(apiRuleV2alpha1)
+	err = apiRule.ConvertFrom(apiRuleV2alpha1)
 	if err != nil {
 		l.Error(err, "Error while converting APIRule v2alpha1 to v1beta1")
 		return doneReconcileErrorRequeue(err, errorReconciliationPeriod)
@@ -139,7 +177,7 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	if isAPIRuleV2(apiRuleV2alpha1) {
-		return r.reconcileV2Alpha1APIRule(ctx, l, apiRuleV2alpha1, apiRule, accessAllowed)
+		return r.reconcileV2Alpha1APIRule(ctx, l, apiRuleV2alpha1, apiRule, accessAllowed, config, settings)
 	}
 
 	if !accessAllowed {
@@ -157,9 +195,12 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return r.updateResourceRequeue(ctx, l, n)
 	}
 
-	l.Info("Reconciling v1beta1 APIRule", "jwtHandler", r.Config.JWTHandler)
-	cmd := r.getV1Beta1Reconciliation(&apiRule, defaultDomainName, &l)
+	l.Info("Reconciling v1beta1 APIRule", "jwtHandler", config.JWTHandler)
+	cmd := r.getV1Beta1Reconciliation(&apiRule, defaultDomainName, config, &l)
 	if name, err := dependencies.APIRuleV1beta1().AreAvailable(ctx, r.Client); err != nil {
+		if apierrs.IsNotFound(err) {
+			controller.RecordDependencyMissing(r.Recorder, apiRuleV2alpha1, name)
+		}
 		s, err := handleDependenciesError(name, err).V1beta1Status()
 		if err != nil {
 			return doneReconcileErrorRequeue(err, r.OnErrorReconcilePeriod)
@@ -172,10 +213,13 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	l.Info("Validating APIRule config")
-	failures := validation.ValidateConfig(r.Config)
+	failures := validation.ValidateConfig(config)
 	if len(failures) > 0 {
 		l.Error(fmt.Errorf("validation has failures"),
 			"Configuration validation failed", "failures", failures)
+		for _, o := range r.reconciliationObservers(apiRuleV2alpha1) {
+			o.ValidationFailed(failures)
+		}
 		s := cmd.GetStatusBase(string(gatewayv1beta1.StatusSkipped)).
 			GenerateStatusFromFailures(failures)
 		if err := s.UpdateStatus(&apiRule.Status); err != nil {
@@ -186,7 +230,7 @@ func (r *APIRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	l.Info("Reconciling APIRule sub-resources")
-	s := processing.Reconcile(ctx, r.Client, &l, cmd)
+	s := processing.Reconcile(ctx, r.Client, r.APIReader, &l, cmd, r.reconciliationObservers(apiRuleV2alpha1)...)
 	if err := s.UpdateStatus(&apiRule.Status); err != nil {
 		l.Error(err, "Error updating APIRule status")
 		// Quick retry if the object has been modified
@@ -219,7 +263,7 @@ func isAPIRuleV2(apiRule *gatewayv2alpha1.APIRule) bool {
 	return true
 }
 
-func (r *APIRuleReconciler) reconcileV2Alpha1APIRule(ctx context.Context, l logr.Logger, apiRule *gatewayv2alpha1.APIRule, apiRuleV1beta1 gatewayv1beta1.APIRule, migrationAllowed bool) (ctrl.Result, error) {
+func (r *APIRuleReconciler) reconcileV2Alpha1APIRule(ctx context.Context, l logr.Logger, apiRule *gatewayv2alpha1.APIRule, apiRuleV1beta1 gatewayv1beta1.APIRule, migrationAllowed bool, config *helpers.Config, settings apirulesettings.Settings) (ctrl.Result, error) {
 	l.Info("Reconciling v2alpha1 APIRule")
 
 	toUpdate := apiRule.DeepCopy()
@@ -249,9 +293,12 @@ func (r *APIRuleReconciler) reconcileV2Alpha1APIRule(ctx context.Context, l logr
 		return r.updateStatus(ctx, l, toUpdate, true)
 	}
 
-	cmd := r.getV2Alpha1Reconciliation(&apiRuleV1beta1, toUpdate, gateway, migrate, &l)
+	cmd := r.getV2Alpha1Reconciliation(&apiRuleV1beta1, toUpdate, gateway, migrate, settings, &l)
 
 	if name, err := dependencies.APIRuleV2().AreAvailable(ctx, r.Client); err != nil {
+		if apierrs.IsNotFound(err) {
+			controller.RecordDependencyMissing(r.Recorder, toUpdate, name)
+		}
 		s, err := handleDependenciesError(name, err).V2alpha1Status()
 		if err != nil {
 			return doneReconcileErrorRequeue(err, r.OnErrorReconcilePeriod)
@@ -264,10 +311,13 @@ func (r *APIRuleReconciler) reconcileV2Alpha1APIRule(ctx context.Context, l logr
 	}
 
 	l.Info("Validating APIRule config")
-	failures := validation.ValidateConfig(r.Config)
+	failures := validation.ValidateConfig(config)
 	if len(failures) > 0 {
 		l.Error(fmt.Errorf("validation has failures"),
 			"Configuration validation failed", "failures", failures)
+		for _, o := range r.reconciliationObservers(toUpdate) {
+			o.ValidationFailed(failures)
+		}
 		s := cmd.GetStatusBase(string(gatewayv2alpha1.Error)).
 			GenerateStatusFromFailures(failures)
 		if err := s.UpdateStatus(&toUpdate.Status); err != nil {
@@ -278,7 +328,7 @@ func (r *APIRuleReconciler) reconcileV2Alpha1APIRule(ctx context.Context, l logr
 	}
 
 	l.Info("Reconciling APIRule sub-resources")
-	s := processing.Reconcile(ctx, r.Client, &l, cmd)
+	s := processing.Reconcile(ctx, r.Client, r.APIReader, &l, cmd, r.reconciliationObservers(toUpdate)...)
 
 	if migrate && !s.HasError() {
 		migration.ApplyMigrationAnnotation(l, toUpdate)
@@ -331,7 +381,10 @@ func handleDependenciesError(name string, err error) controller.Status {
 	}
 }
 
-func discoverGateway(client client.Client, ctx context.Context, l logr.Logger, rule *gatewayv2alpha1.APIRule) (*networkingv1beta1.Gateway, error) {
+func discoverGateway(client client.Client, ctx context.Context, l logr.Logger, rule *gatewayv2alpha1.APIRule) (gateway *networkingv1beta1.Gateway, err error) {
+	ctx, span := tracing.Start(ctx, "gateway.discoverGateway", tracing.APIRuleAttributes(rule.Name, rule.Namespace)...)
+	defer func() { tracing.End(span, err) }()
+
 	// Check if either Gateway or ExternalGateway is specified
 	if (rule.Spec.Gateway == nil && rule.Spec.ExternalGateway == nil) || (rule.Spec.Gateway != nil && rule.Spec.ExternalGateway != nil) {
 		v2Alpha1Status := status.ReconciliationV2alpha1Status{
@@ -478,10 +531,20 @@ func discoverExternalGateway(client client.Client, ctx context.Context, l logr.L
 	return &gateway, nil
 }
 
-func (r *APIRuleReconciler) getV1Beta1Reconciliation(apiRule *gatewayv1beta1.APIRule, defaultDomainName string, namespacedLogger *logr.Logger) processing.ReconciliationCommand {
+// effectiveConfig returns the configuration of the api-gateway-config ConfigMap with the settings of the APIGateway CR
+// applied. The settings of the APIGateway CR take precedence over the ConfigMap, which is only read for compatibility.
+func (r *APIRuleReconciler) effectiveConfig(settings apirulesettings.Settings) *helpers.Config {
+	config := *r.Config
+	if settings.JWTHandler != "" {
+		config.JWTHandler = settings.JWTHandler
+	}
+	return &config
+}
+
+func (r *APIRuleReconciler) getV1Beta1Reconciliation(apiRule *gatewayv1beta1.APIRule, defaultDomainName string, jwtConfig *helpers.Config, namespacedLogger *logr.Logger) processing.ReconciliationCommand {
 	config := r.ReconciliationConfig
 	config.DefaultDomainName = defaultDomainName
-	switch r.Config.JWTHandler {
+	switch jwtConfig.JWTHandler {
 	case helpers.JWT_HANDLER_ISTIO:
 		return istio.NewIstioReconciliation(apiRule, config, namespacedLogger, r.Client)
 	default:
@@ -489,9 +552,18 @@ func (r *APIRuleReconciler) getV1Beta1Reconciliation(apiRule *gatewayv1beta1.API
 	}
 }
 
-func (r *APIRuleReconciler) getV2Alpha1Reconciliation(apiRulev1beta1 *gatewayv1beta1.APIRule, apiRulev2alpha1 *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, needsMigration bool, namespacedLogger *logr.Logger) processing.ReconciliationCommand {
+func (r *APIRuleReconciler) getV2Alpha1Reconciliation(apiRulev1beta1 *gatewayv1beta1.APIRule, apiRulev2alpha1 *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, needsMigration bool, settings apirulesettings.Settings, namespacedLogger *logr.Logger) processing.ReconciliationCommand {
 	config := r.ReconciliationConfig
-	v2alpha1Validator := v2alpha1.NewAPIRuleValidator(apiRulev2alpha1)
+	config.DefaultTimeout = settings.DefaultTimeout
+	config.DefaultCorsPolicy = settings.DefaultCorsPolicy
+	config.BackendNetworkPolicies = settings.BackendNetworkPolicies
+	config.BackendNetworkPoliciesAllowMeshTraffic = settings.BackendNetworkPoliciesAllowMeshTraffic
+	v2alpha1Validator := &v2alpha1.APIRuleValidator{
+		ApiRule:           apiRulev2alpha1,
+		HostIndexReader:   r.Cache,
+		Checks:            settings.Checks,
+		AllowedJwtIssuers: settings.AllowedJwtIssuers,
+	}
 	return v2alpha1Processing.NewReconciliation(apiRulev2alpha1, apiRulev1beta1, gateway, v2alpha1Validator, config, namespacedLogger, needsMigration, r.Client)
 }
 
@@ -517,6 +589,19 @@ func (p annotationChangedTypedPredicate[object]) Update(e event.UpdateEvent) boo
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *APIRuleReconciler) SetupWithManager(mgr ctrl.Manager, c controller.RateLimiterConfig) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv2alpha1.APIRule{}, v2alpha1.HostsIndexField, v2alpha1.IndexHosts); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv2alpha1.APIRule{}, ServicesIndexField, IndexServices); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv2alpha1.APIRule{}, GatewayIndexField, IndexGateway); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gatewayv2alpha1.APIRule{}, ExternalGatewayIndexField, IndexExternalGateway); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		// We need to filter for generation changes, because we had an issue that on Azure clusters the APIRules were constantly reconciled.
 		For(&gatewayv2alpha1.APIRule{}, builder.WithPredicates(
@@ -524,15 +609,31 @@ func (r *APIRuleReconciler) SetupWithManager(mgr ctrl.Manager, c controller.Rate
 				predicate.GenerationChangedPredicate{},
 				annotationChangedPredicate{annotation: "gateway.kyma-project.io/original-version"},
 				annotationChangedPredicate{annotation: "gateway.kyma-project.io/v1beta1-spec"},
+				annotationChangedPredicate{annotation: authorizationpolicy.ConsolidationAnnotationName},
+				annotationChangedPredicate{annotation: networkpolicy.AnnotationName},
 			))).
 		Watches(&corev1.ConfigMap{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(&isApiGatewayConfigMapPredicate{Log: r.Log})).
+		Watches(&gatewayv2alpha1.APIRule{}, NewSameHostAPIRuleInformer(r), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&hostpolicyv1alpha1.HostPolicy{}, NewHostPolicyInformer(r)).
+		Watches(&apirulepolicyv1alpha1.APIRulePolicy{}, NewAPIRulePolicyInformer(r)).
+		// The APIRule settings of the APIGateway CR apply to all APIRules.
+		Watches(&operatorv1alpha1.APIGateway{}, NewAPIGatewayInformer(r), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&networkingv1beta1.VirtualService{}, NewSubresourceDriftInformer(r), builder.WithPredicates(isSubresourcePredicate)).
+		Watches(&securityv1beta1.AuthorizationPolicy{}, NewSubresourceDriftInformer(r), builder.WithPredicates(isSubresourcePredicate)).
+		Watches(&securityv1beta1.RequestAuthentication{}, NewSubresourceDriftInformer(r), builder.WithPredicates(isSubresourcePredicate)).
+		Watches(&networkingv1.NetworkPolicy{}, NewSubresourceDriftInformer(r), builder.WithPredicates(isSubresourcePredicate)).
+		// Changes of the servers of a Gateway and the deletion of an ExternalGateway affect the status of the APIRules
+		// referencing them.
+		Watches(&networkingv1beta1.Gateway{}, NewGatewayInformer(r), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&externalv1alpha1.ExternalGateway{}, NewExternalGatewayInformer(r), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&corev1.Service{}, NewServiceInformer(r),
 			builder.WithPredicates(
 				// Filter out CREATE event types.
 				// We will probably have to reiterate this in the future.
 				predicateutil.ForEventTypes(predicateutil.UpdateEvent, predicateutil.DeleteEvent, predicateutil.GenericEvent))).
 		WithOptions(runtimecontroller.Options{
-			RateLimiter: controller.NewRateLimiter(c),
+			RateLimiter:             controller.NewRateLimiter(c),
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
 		Complete(r)
 }
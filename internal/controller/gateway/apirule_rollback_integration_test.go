@@ -0,0 +1,161 @@
+package gateway_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apinetworkingv1beta1 "istio.io/api/networking/v1beta1"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+// failingApplyClient is the client of the APIRule reconciler in the test suite. It fails the server-side apply of
+// objects of the kinds and namespaces added with failApply.
+type failingApplyClient struct {
+	client.Client
+
+	mu       sync.Mutex
+	failures map[string]bool
+}
+
+var applyFailures = &failingApplyClient{}
+
+func (c *failingApplyClient) failApply(kind, namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures == nil {
+		c.failures = map[string]bool{}
+	}
+	c.failures[kind+"/"+namespace] = true
+}
+
+func (c *failingApplyClient) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = nil
+}
+
+func (c *failingApplyClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	if u, ok := obj.(interface {
+		GetKind() string
+		GetNamespace() string
+	}); ok {
+		c.mu.Lock()
+		fail := c.failures[u.GetKind()+"/"+u.GetNamespace()]
+		c.mu.Unlock()
+		if fail {
+			return fmt.Errorf("injected failure for %s in namespace %s", u.GetKind(), u.GetNamespace())
+		}
+	}
+	return c.Client.Apply(ctx, obj, opts...)
+}
+
+var _ = Describe("APIRule reconciliation with failing subresource changes", func() {
+	const (
+		apiRuleName = "rollback"
+		serviceName = "rollback-service"
+		host        = "rollback.local.kyma.dev"
+	)
+
+	BeforeEach(func() {
+		gateway := &networkingv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "kyma-gateway", Namespace: "kyma-system"},
+			Spec: apinetworkingv1beta1.Gateway{
+				Servers: []*apinetworkingv1beta1.Server{{
+					Port:  &apinetworkingv1beta1.Port{Number: 443, Protocol: "HTTPS", Name: "https"},
+					Hosts: []string{"*.local.kyma.dev"},
+				}},
+			},
+		}
+		if err := c.Create(ctx, gateway); err != nil && !apierrs.IsAlreadyExists(err) {
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: testNamespace},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": serviceName},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 8080}},
+			},
+		}
+		Expect(c.Create(ctx, service)).To(Succeed())
+
+		DeferCleanup(func() {
+			applyFailures.reset()
+			Expect(client.IgnoreNotFound(c.Delete(ctx, &gatewayv2alpha1.APIRule{ObjectMeta: metav1.ObjectMeta{Name: apiRuleName, Namespace: testNamespace}}))).To(Succeed())
+			Expect(c.Delete(ctx, service)).To(Succeed())
+		})
+	})
+
+	apiRuleState := func() gatewayv2alpha1.State {
+		var apiRule gatewayv2alpha1.APIRule
+		if err := c.Get(ctx, client.ObjectKey{Name: apiRuleName, Namespace: testNamespace}, &apiRule); err != nil {
+			return ""
+		}
+		return apiRule.Status.State
+	}
+
+	routedPath := func() string {
+		var vs networkingv1beta1.VirtualService
+		name := processing.SubresourceName(apiRuleName, testNamespace, "VirtualService")
+		if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: testNamespace}, &vs); err != nil || len(vs.Spec.Http) == 0 {
+			return ""
+		}
+		return vs.Spec.Http[0].Match[0].Uri.String()
+	}
+
+	It("should keep the previous VirtualService when the AuthorizationPolicy can't be applied", func() {
+		By("Creating the APIRule")
+		apiRule := &gatewayv2alpha1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        apiRuleName,
+				Namespace:   testNamespace,
+				Annotations: map[string]string{"gateway.kyma-project.io/original-version": "v2alpha1"},
+			},
+			Spec: gatewayv2alpha1.APIRuleSpec{
+				Hosts:   []*gatewayv2alpha1.Host{ptr.To(gatewayv2alpha1.Host(host))},
+				Gateway: ptr.To(testGatewayURL),
+				Service: &gatewayv2alpha1.Service{Name: ptr.To(serviceName), Port: ptr.To(uint32(8080))},
+				Rules: []gatewayv2alpha1.Rule{{
+					Path:    "/old",
+					Methods: []gatewayv2alpha1.HttpMethod{"GET"},
+					NoAuth:  ptr.To(true),
+				}},
+			},
+		}
+		Expect(c.Create(ctx, apiRule)).To(Succeed())
+		Eventually(apiRuleState, eventuallyTimeout).Should(Equal(gatewayv2alpha1.Ready))
+		Eventually(routedPath, eventuallyTimeout).ShouldNot(BeEmpty())
+		previousPath := routedPath()
+
+		By("Changing the path while AuthorizationPolicies can't be applied")
+		applyFailures.failApply("AuthorizationPolicy", testNamespace)
+		Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := c.Get(ctx, client.ObjectKeyFromObject(apiRule), apiRule); err != nil {
+				return err
+			}
+			apiRule.Spec.Rules[0].Path = "/new"
+			return c.Update(ctx, apiRule)
+		})).To(Succeed())
+
+		Eventually(apiRuleState, eventuallyTimeout).Should(Equal(gatewayv2alpha1.Error))
+		Eventually(routedPath, eventuallyTimeout).Should(Equal(previousPath))
+
+		By("Applying the change once AuthorizationPolicies can be applied again")
+		applyFailures.reset()
+		Eventually(apiRuleState, eventuallyTimeout).Should(Equal(gatewayv2alpha1.Ready))
+		Eventually(routedPath, eventuallyTimeout).ShouldNot(Equal(previousPath))
+	})
+})
@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+const EventReasonSubresourceDrift = "SubresourceDrift"
+
+// isSubresourcePredicate filters for objects created by the module for an APIRule.
+var isSubresourcePredicate = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if obj.GetLabels()[processing.ModuleLabelKey] != processing.ApiGatewayLabelValue {
+		return false
+	}
+	_, ok := processing.GetOwnerFromLabels(obj.GetLabels())
+	return ok
+})
+
+// NewSubresourceDriftInformer enqueues the owning APIRule when one of its subresources is changed by another field
+// manager or deleted, so the change is reverted by the reconciliation instead of waiting for the next periodic
+// reconciliation. Changes by other field managers are recorded as an Event on the APIRule. Subresources of APIRules in
+// namespaces outside the watch scope are ignored in case the cache isn't restricted to the watch scope.
+func NewSubresourceDriftInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			owner, ok := processing.GetOwnerFromLabels(e.ObjectOld.GetLabels())
+			if !ok || !r.WatchScope.ContainsNamespace(owner.Namespace) {
+				return
+			}
+
+			fieldManager, drifted := subresourceDrift(e.ObjectOld, e.ObjectNew)
+			if !drifted {
+				return
+			}
+
+			var kind string
+			if gvk, err := apiutil.GVKForObject(e.ObjectNew, r.Scheme); err == nil {
+				kind = gvk.Kind
+			}
+			r.Log.Info("Reverting change of subresource", "kind", kind, "namespace", e.ObjectNew.GetNamespace(),
+				"name", e.ObjectNew.GetName(), "fieldManager", fieldManager, "apiRule", owner.String())
+			if r.Recorder != nil {
+				r.Recorder.Eventf(apiRuleReference(owner), nil, corev1.EventTypeWarning, EventReasonSubresourceDrift, "Revert",
+					"%s %s/%s was changed by %s, the change is reverted", kind, e.ObjectNew.GetNamespace(), e.ObjectNew.GetName(), fieldManager)
+			}
+			q.Add(reconcile.Request{NamespacedName: owner})
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			// It's not known who deleted the subresource, so no Event is recorded. If the APIRule still exists and
+			// requires the subresource, the reconciliation creates it again.
+			if owner, ok := processing.GetOwnerFromLabels(e.Object.GetLabels()); ok && r.WatchScope.ContainsNamespace(owner.Namespace) {
+				q.Add(reconcile.Request{NamespacedName: owner})
+			}
+		},
+	}
+}
+
+// subresourceDrift returns whether the spec or the labels of a subresource were changed by a field manager other than
+// the one of the APIRule controller and the name of this field manager.
+func subresourceDrift(oldObj, newObj client.Object) (string, bool) {
+	if oldObj.GetGeneration() == newObj.GetGeneration() && maps.Equal(oldObj.GetLabels(), newObj.GetLabels()) {
+		return "", false
+	}
+
+	fieldManager := lastFieldManager(newObj)
+	return fieldManager, fieldManager != "" && fieldManager != processing.FieldManager
+}
+
+// lastFieldManager returns the field manager that changed the object last. The time of managed fields only has a
+// precision of seconds, so a field manager other than the one of the APIRule controller is preferred if several
+// field managers changed the object in the same second.
+func lastFieldManager(obj client.Object) string {
+	var fieldManager string
+	var last int64
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		t := entry.Time.Unix()
+		if t > last || t == last && fieldManager == processing.FieldManager {
+			fieldManager, last = entry.Manager, t
+		}
+	}
+	return fieldManager
+}
+
+func apiRuleReference(nn types.NamespacedName) *gatewayv2alpha1.APIRule {
+	apiRule := &gatewayv2alpha1.APIRule{}
+	apiRule.SetGroupVersionKind(gatewayv2alpha1.GroupVersion.WithKind("APIRule"))
+	apiRule.SetName(nn.Name)
+	apiRule.SetNamespace(nn.Namespace)
+	return apiRule
+}
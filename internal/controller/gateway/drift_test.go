@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+func driftTestVirtualService(generation int64, managers ...metav1.ManagedFieldsEntry) *networkingv1beta1.VirtualService {
+	return &networkingv1beta1.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-vs",
+			Namespace:  "default",
+			Generation: generation,
+			Labels: map[string]string{
+				processing.ModuleLabelKey:      processing.ApiGatewayLabelValue,
+				processing.OwnerLabelName:      "test-apirule",
+				processing.OwnerLabelNamespace: "default",
+			},
+			ManagedFields: managers,
+		},
+	}
+}
+
+func managedFieldsEntry(manager string, t time.Time) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: t}}
+}
+
+var _ = Describe("Subresource drift", func() {
+	now := time.Now()
+
+	DescribeTable("subresourceDrift",
+		func(oldObj, newObj *networkingv1beta1.VirtualService, expectedDrift bool, expectedManager string) {
+			// when
+			manager, drift := subresourceDrift(oldObj, newObj)
+
+			// then
+			Expect(drift).To(Equal(expectedDrift))
+			Expect(manager).To(Equal(expectedManager))
+		},
+		Entry("should detect a spec change by another field manager",
+			driftTestVirtualService(1, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour))),
+			driftTestVirtualService(2,
+				managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour)),
+				managedFieldsEntry("kubectl-edit", now)),
+			true, "kubectl-edit"),
+		Entry("should not detect a spec change by the APIRule controller",
+			driftTestVirtualService(1, managedFieldsEntry("kubectl-edit", now.Add(-time.Hour))),
+			driftTestVirtualService(2,
+				managedFieldsEntry("kubectl-edit", now.Add(-time.Hour)),
+				managedFieldsEntry(processing.FieldManager, now)),
+			false, processing.FieldManager),
+		Entry("should detect a change if another field manager and the APIRule controller changed in the same second",
+			driftTestVirtualService(1, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour))),
+			driftTestVirtualService(2,
+				managedFieldsEntry(processing.FieldManager, now),
+				managedFieldsEntry("kubectl-edit", now)),
+			true, "kubectl-edit"),
+		Entry("should not detect a change if only status or metadata changed",
+			driftTestVirtualService(1, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour))),
+			driftTestVirtualService(1,
+				managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour)),
+				managedFieldsEntry("pilot-discovery", now)),
+			false, ""),
+	)
+
+	It("should enqueue the owning APIRule and record an Event when a subresource drifted", func() {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv2alpha1.AddToScheme(scheme)).To(Succeed())
+		recorder := events.NewFakeRecorder(1)
+		r := &APIRuleReconciler{Log: logr.Discard(), Scheme: scheme, Recorder: recorder}
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(queue.ShutDown)
+
+		oldObj := driftTestVirtualService(1, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour)))
+		newObj := driftTestVirtualService(2, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour)), managedFieldsEntry("kubectl-edit", now))
+
+		// when
+		NewSubresourceDriftInformer(r).Update(context.Background(), event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}, queue)
+
+		// then
+		Expect(queue.Len()).To(Equal(1))
+		request, _ := queue.Get()
+		Expect(request.NamespacedName).To(Equal(types.NamespacedName{Namespace: "default", Name: "test-apirule"}))
+
+		Expect(recorder.Events).To(Receive(And(
+			ContainSubstring(EventReasonSubresourceDrift),
+			ContainSubstring("VirtualService default/test-vs was changed by kubectl-edit"),
+		)))
+	})
+
+	It("should ignore subresources of APIRules in namespaces outside the watch scope", func() {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv2alpha1.AddToScheme(scheme)).To(Succeed())
+		recorder := events.NewFakeRecorder(1)
+		r := &APIRuleReconciler{Log: logr.Discard(), Scheme: scheme, Recorder: recorder, WatchScope: controller.WatchScope{Namespaces: []string{"tenant-a"}}}
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(queue.ShutDown)
+
+		oldObj := driftTestVirtualService(1, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour)))
+		newObj := driftTestVirtualService(2, managedFieldsEntry(processing.FieldManager, now.Add(-time.Hour)), managedFieldsEntry("kubectl-edit", now))
+
+		// when
+		NewSubresourceDriftInformer(r).Update(context.Background(), event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}, queue)
+		NewSubresourceDriftInformer(r).Delete(context.Background(), event.DeleteEvent{Object: newObj}, queue)
+
+		// then
+		Expect(queue.Len()).To(BeZero())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+})
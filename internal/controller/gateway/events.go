@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+var eventActions = map[string]string{
+	"create": controller.EventActionCreate,
+	"update": controller.EventActionUpdate,
+	"delete": controller.EventActionDelete,
+}
+
+// apiRuleEventObserver records Events on the APIRule for the validation failures and the changes of the
+// subresources during the reconciliation.
+type apiRuleEventObserver struct {
+	recorder events.EventRecorder
+	scheme   *runtime.Scheme
+	apiRule  client.Object
+}
+
+func (r *APIRuleReconciler) newEventObserver(apiRule client.Object) processing.ReconciliationObserver {
+	return apiRuleEventObserver{recorder: r.Recorder, scheme: r.Scheme, apiRule: apiRule}
+}
+
+// reconciliationObservers returns the observers that record Events and metrics for the reconciliation of the APIRule.
+func (r *APIRuleReconciler) reconciliationObservers(apiRule client.Object) []processing.ReconciliationObserver {
+	observers := []processing.ReconciliationObserver{r.newEventObserver(apiRule)}
+	if r.Metrics != nil {
+		observers = append(observers, apiRuleMetricsObserver{metrics: r.Metrics, scheme: r.Scheme})
+	}
+	return observers
+}
+
+func (o apiRuleEventObserver) ValidationFailed(failures []validation.Failure) {
+	controller.RecordValidationFailures(o.recorder, o.apiRule, failures)
+}
+
+func (o apiRuleEventObserver) PhaseCompleted(string, time.Duration) {}
+
+func (o apiRuleEventObserver) ChangeApplied(change *processing.ObjectChange, err error) {
+	controller.RecordSubresourceChange(o.recorder, o.apiRule, eventActions[change.Action.String()], objectKind(change.Obj, o.scheme),
+		change.Obj.GetNamespace(), change.Obj.GetName(), err)
+}
+
+// objectKind returns the kind of the object. Typed objects usually have no TypeMeta, so the kind is looked up in
+// the scheme.
+func objectKind(obj client.Object, scheme *runtime.Scheme) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" && scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+			kind = gvk.Kind
+		}
+	}
+	return kind
+}
+
+// apiRuleState returns the state and description of the APIRule in the states of version v2alpha1, so transitions
+// are reported in the same way for all versions.
+func apiRuleState(apiRule client.Object) (gatewayv2alpha1.State, string) {
+	switch a := apiRule.(type) {
+	case *gatewayv2alpha1.APIRule:
+		return a.Status.State, a.Status.Description
+	case *gatewayv1beta1.APIRule:
+		converted := gatewayv2alpha1.APIRule{}
+		if err := a.ConvertTo(&converted); err != nil {
+			return "", ""
+		}
+		return converted.Status.State, converted.Status.Description
+	}
+	return "", ""
+}
+
+// recordStateTransition records an Event if the state of the APIRule differs from the state at the start of the
+// reconciliation.
+func (r *APIRuleReconciler) recordStateTransition(ctx context.Context, apiRule client.Object) {
+	state, description := apiRuleState(apiRule)
+	controller.RecordStateTransition(r.Recorder, apiRule, controller.PreviousState(ctx), string(state), description)
+}
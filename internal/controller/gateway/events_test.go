@@ -0,0 +1,60 @@
+package gateway
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+var _ = Describe("APIRule Events", func() {
+	It("should record an Event for an applied subresource change", func() {
+		// given
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		recorder := events.NewFakeRecorder(1)
+		r := &APIRuleReconciler{Scheme: scheme, Recorder: recorder}
+		apiRule := &gatewayv2alpha1.APIRule{ObjectMeta: metav1.ObjectMeta{Name: "test-apirule", Namespace: "default"}}
+		// Objects without TypeMeta must be resolved with the scheme.
+		vs := &networkingv1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: "test-vs", Namespace: "default"}}
+
+		// when
+		r.newEventObserver(apiRule).ChangeApplied(processing.NewObjectCreateAction(vs), nil)
+
+		// then
+		Expect(recorder.Events).To(Receive(Equal("Normal SubresourceCreated Created VirtualService default/test-vs")))
+	})
+
+	DescribeTable("should compare the state with the state at the start of the reconciliation",
+		func(previousState, state gatewayv2alpha1.State, expectedEvent string) {
+			// given
+			recorder := events.NewFakeRecorder(1)
+			r := &APIRuleReconciler{Recorder: recorder}
+			apiRule := &gatewayv2alpha1.APIRule{Status: gatewayv2alpha1.APIRuleStatus{State: state, Description: "Validation error"}}
+			ctx := controller.WithPreviousState(context.Background(), string(previousState))
+
+			// when
+			r.recordStateTransition(ctx, apiRule)
+
+			// then
+			if expectedEvent == "" {
+				Expect(recorder.Events).ToNot(Receive())
+				return
+			}
+			Expect(recorder.Events).To(Receive(Equal(expectedEvent)))
+		},
+		Entry("transition to Error", gatewayv2alpha1.Ready, gatewayv2alpha1.Error,
+			"Warning StateChanged State changed from Ready to Error: Validation error"),
+		Entry("first reconciliation", gatewayv2alpha1.State(""), gatewayv2alpha1.Ready,
+			"Normal StateChanged State changed to Ready: Validation error"),
+		Entry("state unchanged", gatewayv2alpha1.Ready, gatewayv2alpha1.Ready, ""),
+	)
+})
@@ -23,12 +23,12 @@ import (
 	"time"
 
 	certv1alpha1 "github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
-	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -41,6 +41,7 @@ import (
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
 	"github.com/kyma-project/api-gateway/internal/controller"
 	"github.com/kyma-project/api-gateway/internal/dependencies"
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
 	"github.com/kyma-project/api-gateway/internal/reconciliations/externalgateway"
 )
 
@@ -57,6 +58,9 @@ type ExternalGatewayReconciler struct {
 	Scheme                 *runtime.Scheme
 	RequeueInterval        time.Duration
 	PendingRequeueInterval time.Duration
+	Recorder               events.EventRecorder
+	// MaxConcurrentReconciles is the maximum number of ExternalGateways reconciled at the same time. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
 // NewExternalGatewayReconciler creates a new ExternalGatewayReconciler
@@ -68,6 +72,7 @@ func NewExternalGatewayReconciler(mgr manager.Manager) *ExternalGatewayReconcile
 		Scheme:                 mgr.GetScheme(),
 		RequeueInterval:        defaultReconciliationInterval,
 		PendingRequeueInterval: pendingRequeueInterval,
+		Recorder:               mgr.GetEventRecorder("externalgateway-controller"),
 	}
 }
 
@@ -78,6 +83,7 @@ func NewExternalGatewayReconciler(mgr manager.Manager) *ExternalGatewayReconcile
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch,resourceNames=shoot-info,namespace=kube-system
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 // +kubebuilder:rbac:groups=dns.gardener.cloud,resources=dnsentries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cert.gardener.cloud,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=envoyfilters,verbs=get;list;watch;create;update;patch;delete
@@ -100,6 +106,10 @@ func (r *ExternalGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return r.handleDeletion(ctx, log, externalGateway)
 	}
 
+	// The state is set to Processing during the reconciliation, so only the transition from the previous to the
+	// final state is recorded as an Event.
+	previousState := string(externalGateway.Status.State)
+
 	if !controllerutil.ContainsFinalizer(externalGateway, externalGatewayFinalizer) {
 		controllerutil.AddFinalizer(externalGateway, externalGatewayFinalizer)
 		if err := r.Update(ctx, externalGateway); err != nil {
@@ -135,6 +145,8 @@ func (r *ExternalGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		if statusErr := r.updateStatus(ctx, externalGateway, externalv1alpha1.Error, err.Error(), conditions); statusErr != nil {
 			log.Error(statusErr, "Failed to update error status")
+		} else {
+			controller.RecordStateTransition(r.Recorder, externalGateway, previousState, string(externalv1alpha1.Error), err.Error())
 		}
 		return ctrl.Result{}, err
 	}
@@ -143,6 +155,8 @@ func (r *ExternalGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		conditions = append(conditions, externalv1alpha1.WaitingCondition(externalGateway.Generation))
 		if err := r.updateStatus(ctx, externalGateway, externalv1alpha1.Processing, "Waiting for sub-resources to become ready", conditions); err != nil {
 			log.Error(err, "Failed to update Processing status while waiting for sub-resources")
+		} else {
+			controller.RecordStateTransition(r.Recorder, externalGateway, previousState, string(externalv1alpha1.Processing), "Waiting for sub-resources to become ready")
 		}
 		log.Info("ExternalGateway reconcile finished",
 			"state", string(externalv1alpha1.Processing),
@@ -159,6 +173,7 @@ func (r *ExternalGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		log.Error(err, "Failed to update status to Ready")
 		return ctrl.Result{}, err
 	}
+	controller.RecordStateTransition(r.Recorder, externalGateway, previousState, string(externalv1alpha1.Ready), "All resources reconciled successfully")
 
 	log.Info("ExternalGateway reconcile finished",
 		"state", string(externalv1alpha1.Ready),
@@ -167,9 +182,10 @@ func (r *ExternalGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 }
 
 // reconcileResources orchestrates all sub-resource reconciliations and returns per-component conditions.
-// requeue is true when Gardener sub-resources are applied but not yet Ready (normal async behaviour).
+// requeue is true when DNS or Gardener sub-resources are applied but not yet Ready (normal async behaviour).
 func (r *ExternalGatewayReconciler) reconcileResources(ctx context.Context, log logr.Logger, external *externalv1alpha1.ExternalGateway) (conditions []metav1.Condition, requeue bool, err error) {
 	log.Info("Reconciling ExternalGateway resources", "region", external.Spec.Region)
+	k8sClient := controller.NewEventRecordingClient(r.Client, r.Recorder, external)
 
 	if err := externalgateway.CheckExternalDomainUnique(ctx, r.Client, external); err != nil {
 		return nil, false, err
@@ -180,42 +196,55 @@ func (r *ExternalGatewayReconciler) reconcileResources(ctx context.Context, log
 		return nil, false, fmt.Errorf("failed to build internal domain: %w", err)
 	}
 
-	_, gardenerErr := dependencies.Gardener().AreAvailable(ctx, r.Client)
-	isGardenerAvailable := gardenerErr == nil
+	dnsProvider, err := dns.DetectProvider(ctx, r.Client)
+	if err != nil {
+		return nil, false, err
+	}
 
-	if isGardenerAvailable {
-		dnsCond, dnsPending, dnsErr := r.reconcileDNSEntry(ctx, external, internalDomain)
+	var dnsErr error
+	if dnsProvider != nil {
+		var dnsCond metav1.Condition
+		var dnsPending bool
+		dnsCond, dnsPending, dnsErr = r.reconcileDNSEntry(ctx, k8sClient, dnsProvider, external, internalDomain)
 		conditions = append(conditions, dnsCond)
 		requeue = dnsPending
+	} else {
+		log.Info("Neither Gardener nor external-dns available, skipping DNS record reconciliation")
+		conditions = append(conditions, metav1.Condition{
+			Type:               externalv1alpha1.ConditionTypeDNSEntryReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: external.Generation,
+			Reason:             externalv1alpha1.ReasonGardenerCRDUnavailable,
+			Message:            "Neither Gardener nor external-dns CRDs are available; DNS record management skipped",
+		})
+	}
+
+	_, gardenerErr := dependencies.Gardener().AreAvailable(ctx, r.Client)
+	isGardenerAvailable := gardenerErr == nil
 
-		certCond, certPending, certErr := r.reconcileCertificate(ctx, external, internalDomain)
+	var certErr error
+	if isGardenerAvailable {
+		var certCond metav1.Condition
+		var certPending bool
+		certCond, certPending, certErr = r.reconcileCertificate(ctx, k8sClient, external, internalDomain)
 		conditions = append(conditions, certCond)
 		requeue = requeue || certPending
-
-		if err := errors.Join(dnsErr, certErr); err != nil {
-			return conditions, false, err
-		}
 	} else {
-		log.Info("Gardener not available, skipping DNSEntry and Certificate reconciliation")
-		conditions = append(conditions,
-			metav1.Condition{
-				Type:               externalv1alpha1.ConditionTypeDNSEntryReady,
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: external.Generation,
-				Reason:             externalv1alpha1.ReasonGardenerCRDUnavailable,
-				Message:            "Gardener CRDs are not available; DNSEntry management skipped",
-			},
-			metav1.Condition{
-				Type:               externalv1alpha1.ConditionTypeCertificateReady,
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: external.Generation,
-				Reason:             externalv1alpha1.ReasonGardenerCRDUnavailable,
-				Message:            "Gardener CRDs are not available; Certificate management skipped",
-			},
-		)
-	}
-
-	if err := externalgateway.ReconcileCASecret(ctx, r.Client, external); err != nil {
+		log.Info("Gardener not available, skipping Certificate reconciliation")
+		conditions = append(conditions, metav1.Condition{
+			Type:               externalv1alpha1.ConditionTypeCertificateReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: external.Generation,
+			Reason:             externalv1alpha1.ReasonGardenerCRDUnavailable,
+			Message:            "Gardener CRDs are not available; Certificate management skipped",
+		})
+	}
+
+	if err := errors.Join(dnsErr, certErr); err != nil {
+		return conditions, false, err
+	}
+
+	if err := externalgateway.ReconcileCASecret(ctx, k8sClient, external); err != nil {
 		conditions = append(conditions, gatewayConfiguredFailure(external.Generation, err))
 		return conditions, false, fmt.Errorf("failed to reconcile CA Secret: %w", err)
 	}
@@ -223,23 +252,23 @@ func (r *ExternalGatewayReconciler) reconcileResources(ctx context.Context, log
 	// EnvoyFilters (XFCC sanitization + client-cert validation) must be reconciled BEFORE the
 	// Istio Gateway. If a filter fails to apply, the Gateway must not exist — otherwise Istio
 	// would route mTLS traffic to the workload without the UGW region/cert enforcement chain.
-	certSubjects, err := externalgateway.ResolveRegionCertSubjects(ctx, r.Client, external)
+	certSubjects, err := externalgateway.ResolveRegionCertSubjects(ctx, k8sClient, external)
 	if err != nil {
 		conditions = append(conditions, gatewayConfiguredFailure(external.Generation, err))
 		return conditions, false, fmt.Errorf("failed to resolve certificate subjects: %w", err)
 	}
 
-	if err := externalgateway.ReconcileXFCCSanitizationFilter(ctx, r.Client, external); err != nil {
+	if err := externalgateway.ReconcileXFCCSanitizationFilter(ctx, k8sClient, external); err != nil {
 		conditions = append(conditions, gatewayConfiguredFailure(external.Generation, err))
 		return conditions, false, fmt.Errorf("failed to reconcile XFCC sanitization filter: %w", err)
 	}
 
-	if err := externalgateway.ReconcileCertValidationFilter(ctx, r.Client, external, certSubjects); err != nil {
+	if err := externalgateway.ReconcileCertValidationFilter(ctx, k8sClient, external, certSubjects); err != nil {
 		conditions = append(conditions, gatewayConfiguredFailure(external.Generation, err))
 		return conditions, false, fmt.Errorf("failed to reconcile certificate validation filter: %w", err)
 	}
 
-	if err := externalgateway.ReconcileGateway(ctx, r.Client, r.Scheme, external, internalDomain); err != nil {
+	if err := externalgateway.ReconcileGateway(ctx, k8sClient, r.Scheme, external, internalDomain); err != nil {
 		conditions = append(conditions, gatewayConfiguredFailure(external.Generation, err))
 		return conditions, false, fmt.Errorf("failed to reconcile Gateway: %w", err)
 	}
@@ -262,37 +291,38 @@ func (r *ExternalGatewayReconciler) handleDeletion(ctx context.Context, log logr
 	}
 
 	log.Info("Handling deletion, cleaning up resources")
+	k8sClient := controller.NewEventRecordingClient(r.Client, r.Recorder, external)
 
 	_, gardenerErr := dependencies.Gardener().AreAvailable(ctx, r.Client)
 	isGardenerAvailable := gardenerErr == nil
 
-	if err := externalgateway.DeleteGateway(ctx, r.Client, external.Namespace, external.GatewayName()); err != nil {
+	if err := externalgateway.DeleteGateway(ctx, k8sClient, external.Namespace, external.GatewayName()); err != nil {
 		log.Error(err, "Failed to delete Gateway")
 		return ctrl.Result{}, err
 	}
 
-	if err := externalgateway.DeleteXFCCSanitizationFilter(ctx, r.Client, external.XFCCFilterName()); err != nil {
+	if err := externalgateway.DeleteXFCCSanitizationFilter(ctx, k8sClient, external.XFCCFilterName()); err != nil {
 		log.Error(err, "Failed to delete XFCC sanitization EnvoyFilter")
 		return ctrl.Result{}, err
 	}
 
-	if err := externalgateway.DeleteCertValidationFilter(ctx, r.Client, external.CertValidationFilterName()); err != nil {
+	if err := externalgateway.DeleteCertValidationFilter(ctx, k8sClient, external.CertValidationFilterName()); err != nil {
 		log.Error(err, "Failed to delete certificate validation EnvoyFilter")
 		return ctrl.Result{}, err
 	}
 
-	if err := externalgateway.DeleteCASecret(ctx, r.Client, external.CASecretName()); err != nil {
+	if err := externalgateway.DeleteCASecret(ctx, k8sClient, external.CASecretName()); err != nil {
 		log.Error(err, "Failed to delete CA Secret")
 		return ctrl.Result{}, err
 	}
 
-	if isGardenerAvailable {
-		if err := externalgateway.DeleteDNSEntry(ctx, r.Client, external.DNSEntryName()); err != nil {
-			log.Error(err, "Failed to delete DNSEntry")
-			return ctrl.Result{}, err
-		}
+	if err := externalgateway.DeleteDNSEntry(ctx, k8sClient, external.DNSEntryName()); err != nil {
+		log.Error(err, "Failed to delete DNS record")
+		return ctrl.Result{}, err
+	}
 
-		if err := externalgateway.DeleteCertificate(ctx, r.Client, external.CertificateName(), external.TLSSecretName()); err != nil {
+	if isGardenerAvailable {
+		if err := externalgateway.DeleteCertificate(ctx, k8sClient, external.CertificateName(), external.TLSSecretName()); err != nil {
 			log.Error(err, "Failed to delete Certificate")
 			return ctrl.Result{}, err
 		}
@@ -348,10 +378,10 @@ func gatewayConfiguredFailure(generation int64, err error) metav1.Condition {
 	}
 }
 
-// dnsEntryCondition maps a Gardener DNSEntry state string to a metav1.Condition.
-func dnsEntryCondition(generation int64, state, message string) metav1.Condition {
-	switch state {
-	case dnsv1alpha1.STATE_READY:
+// dnsEntryCondition maps the status of a DNS record to a metav1.Condition.
+func dnsEntryCondition(generation int64, status dns.Status) metav1.Condition {
+	switch status.State {
+	case dns.Ready:
 		return metav1.Condition{
 			Type:               externalv1alpha1.ConditionTypeDNSEntryReady,
 			Status:             metav1.ConditionTrue,
@@ -359,19 +389,19 @@ func dnsEntryCondition(generation int64, state, message string) metav1.Condition
 			Reason:             externalv1alpha1.ReasonReady,
 			Message:            "DNSEntry is ready",
 		}
-	case dnsv1alpha1.STATE_ERROR, dnsv1alpha1.STATE_INVALID, dnsv1alpha1.STATE_STALE:
+	case dns.Failed:
 		return metav1.Condition{
 			Type:               externalv1alpha1.ConditionTypeDNSEntryReady,
 			Status:             metav1.ConditionFalse,
 			ObservedGeneration: generation,
 			Reason:             externalv1alpha1.ReasonDNSEntryError,
-			Message:            message,
+			Message:            status.Message,
 		}
 	default:
-		// Pending, empty (just created), or any other transient state
+		// Pending, just created, or not yet processed by the DNS provider
 		msg := "DNSEntry is being provisioned"
-		if message != "" {
-			msg = message
+		if status.Message != "" {
+			msg = status.Message
 		}
 		return metav1.Condition{
 			Type:               externalv1alpha1.ConditionTypeDNSEntryReady,
@@ -418,12 +448,12 @@ func certificateCondition(generation int64, state, message string) metav1.Condit
 	}
 }
 
-// reconcileDNSEntry reconciles the DNSEntry sub-resource and returns its condition, whether it is
-// still pending, and any error that should block the overall reconciliation result.
-// A hard k8s API error is returned directly; a Gardener terminal state is returned as an error
+// reconcileDNSEntry reconciles the DNS record sub-resource of the DNS provider and returns its condition,
+// whether it is still pending, and any error that should block the overall reconciliation result.
+// A hard k8s API error is returned directly; a terminal state of the DNS provider is returned as an error
 // with the condition already set so the caller always has both pieces of information.
-func (r *ExternalGatewayReconciler) reconcileDNSEntry(ctx context.Context, external *externalv1alpha1.ExternalGateway, internalDomain string) (metav1.Condition, bool, error) {
-	if err := externalgateway.ReconcileDNSEntry(ctx, r.Client, external, internalDomain); err != nil {
+func (r *ExternalGatewayReconciler) reconcileDNSEntry(ctx context.Context, k8sClient client.Client, provider dns.Provider, external *externalv1alpha1.ExternalGateway, internalDomain string) (metav1.Condition, bool, error) {
+	if err := externalgateway.ReconcileDNSEntry(ctx, k8sClient, provider, external, internalDomain); err != nil {
 		return metav1.Condition{
 			Type:               externalv1alpha1.ConditionTypeDNSEntryReady,
 			Status:             metav1.ConditionFalse,
@@ -433,17 +463,17 @@ func (r *ExternalGatewayReconciler) reconcileDNSEntry(ctx context.Context, exter
 		}, false, fmt.Errorf("failed to reconcile DNSEntry: %w", err)
 	}
 
-	state, msg, err := externalgateway.GetDNSEntryStatus(ctx, r.Client, external.DNSEntryName())
+	status, err := externalgateway.GetDNSEntryStatus(ctx, r.Client, provider, external.DNSEntryName())
 	if err != nil {
 		return metav1.Condition{}, false, fmt.Errorf("failed to get DNSEntry status: %w", err)
 	}
 
-	cond := dnsEntryCondition(external.Generation, state, msg)
-	switch state {
-	case dnsv1alpha1.STATE_READY:
+	cond := dnsEntryCondition(external.Generation, status)
+	switch status.State {
+	case dns.Ready:
 		return cond, false, nil
-	case dnsv1alpha1.STATE_ERROR, dnsv1alpha1.STATE_INVALID, dnsv1alpha1.STATE_STALE:
-		return cond, false, fmt.Errorf("DNSEntry is in a terminal error state %q: %s", state, msg)
+	case dns.Failed:
+		return cond, false, fmt.Errorf("DNS record of provider %s is in a terminal error state: %s", provider.Name(), status.Message)
 	default:
 		return cond, true, nil
 	}
@@ -451,8 +481,8 @@ func (r *ExternalGatewayReconciler) reconcileDNSEntry(ctx context.Context, exter
 
 // reconcileCertificate reconciles the Certificate sub-resource and returns its condition, whether it
 // is still pending, and any error that should block the overall reconciliation result.
-func (r *ExternalGatewayReconciler) reconcileCertificate(ctx context.Context, external *externalv1alpha1.ExternalGateway, internalDomain string) (metav1.Condition, bool, error) {
-	if err := externalgateway.ReconcileCertificate(ctx, r.Client, external, internalDomain); err != nil {
+func (r *ExternalGatewayReconciler) reconcileCertificate(ctx context.Context, k8sClient client.Client, external *externalv1alpha1.ExternalGateway, internalDomain string) (metav1.Condition, bool, error) {
+	if err := externalgateway.ReconcileCertificate(ctx, k8sClient, external, internalDomain); err != nil {
 		return metav1.Condition{
 			Type:               externalv1alpha1.ConditionTypeCertificateReady,
 			Status:             metav1.ConditionFalse,
@@ -483,7 +513,8 @@ func (r *ExternalGatewayReconciler) SetupWithManager(mgr ctrl.Manager, rateLimit
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&externalv1alpha1.ExternalGateway{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WithOptions(runtimecontroller.Options{
-			RateLimiter: controller.NewRateLimiter(rateLimiterConfig),
+			RateLimiter:             controller.NewRateLimiter(rateLimiterConfig),
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
 		Complete(r)
 }
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+const (
+	// GatewayIndexField is the name of the field index on the Istio Gateway referenced by APIRules. The keys of the
+	// index are built with GatewayIndexKey.
+	GatewayIndexField = "spec.gateway"
+	// ExternalGatewayIndexField is the name of the field index on the ExternalGateway referenced by APIRules. The keys
+	// of the index are built with GatewayIndexKey.
+	ExternalGatewayIndexField = "spec.externalGateway"
+)
+
+// GatewayIndexKey returns the key of a Gateway or ExternalGateway in the GatewayIndexField and
+// ExternalGatewayIndexField indexes.
+func GatewayIndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// IndexGateway returns the key of the Istio Gateway referenced by an APIRule.
+func IndexGateway(obj client.Object) []string {
+	apiRule, ok := obj.(*gatewayv2alpha1.APIRule)
+	if !ok || apiRule.Spec.Gateway == nil {
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(*apiRule.Spec.Gateway, "/")
+	if !found {
+		return nil
+	}
+	return []string{GatewayIndexKey(namespace, name)}
+}
+
+// IndexExternalGateway returns the key of the ExternalGateway referenced by an APIRule. An ExternalGateway referenced
+// without namespace is in the namespace of the APIRule, as in discoverExternalGateway.
+func IndexExternalGateway(obj client.Object) []string {
+	apiRule, ok := obj.(*gatewayv2alpha1.APIRule)
+	if !ok || apiRule.Spec.ExternalGateway == nil {
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(*apiRule.Spec.ExternalGateway, "/")
+	if !found {
+		namespace, name = apiRule.Namespace, *apiRule.Spec.ExternalGateway
+	}
+	return []string{GatewayIndexKey(namespace, name)}
+}
@@ -3,137 +3,157 @@ package gateway
 import (
 	"context"
 	"slices"
+	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
-	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing/default_domain"
+	"github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
 )
 
+// NewServiceInformer enqueues the APIRules exposing a changed Service.
 func NewServiceInformer(r *APIRuleReconciler) handler.EventHandler {
-	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-		var apiRules gatewayv2alpha1.APIRuleList
-		// This call queries apiserver on every service change.
-		// This needs to be improved
-		if err := r.List(ctx, &apiRules); err != nil {
-			return nil
-		}
+	return handler.EnqueueRequestsFromMapFunc(r.apiRulesExposingService)
+}
 
-		if len(apiRules.Items) == 0 {
-			return nil
-		}
+// apiRulesExposingService returns the requests for the APIRules exposing the Service. The APIRules are read from the
+// cache by the ServicesIndexField index, so a change of a Service doesn't list all APIRules.
+func (r *APIRuleReconciler) apiRulesExposingService(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.apiRulesByIndex(ctx, ServicesIndexField, ServiceIndexKey(obj.GetNamespace(), obj.GetName()))
+}
 
-		var apiRulesV2alpha1 []gatewayv2alpha1.APIRule
-		var apiRulesV1beta1 []gatewayv1beta1.APIRule
+// NewGatewayInformer enqueues the APIRules referencing a changed Istio Gateway. For a Gateway generated for an
+// ExternalGateway, the APIRules referencing the ExternalGateway are enqueued as well.
+func NewGatewayInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(r.apiRulesReferencingGateway)
+}
 
-		for _, apiRuleV2 := range apiRules.Items {
-			if apiRuleV2.Annotations == nil {
-				continue
-			}
-			originalVersion, ok := apiRuleV2.Annotations[gatewayv2.OriginalVersionAnnotation]
-			if !ok || slices.Contains([]string{"v2", "v2alpha1"}, originalVersion) {
-				apiRulesV2alpha1 = append(apiRulesV2alpha1, apiRuleV2)
-			}
-			if originalVersion == "v1beta1" {
-				converted := gatewayv1beta1.APIRule{}
-				if err := converted.ConvertFrom(&apiRuleV2); err != nil {
-					r.Log.Error(err, "Failed to convert APIRule v2alpha1 to v1beta1", "name", apiRuleV2.Name, "namespace", apiRuleV2.Namespace)
-					continue
-				}
-				apiRulesV1beta1 = append(apiRulesV1beta1, converted)
-			}
+func (r *APIRuleReconciler) apiRulesReferencingGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.apiRulesByIndex(ctx, GatewayIndexField, GatewayIndexKey(obj.GetNamespace(), obj.GetName()))
+
+	if owner := metav1.GetControllerOf(obj); owner != nil && owner.Kind == "ExternalGateway" {
+		if gv, err := schema.ParseGroupVersion(owner.APIVersion); err == nil && gv.Group == externalv1alpha1.GroupVersion.Group {
+			requests = append(requests, r.apiRulesByIndex(ctx, ExternalGatewayIndexField, GatewayIndexKey(obj.GetNamespace(), owner.Name))...)
 		}
+	}
+	return requests
+}
 
-		requests := matchAPIRulesV1WithChangedService(apiRulesV1beta1, obj)
-		requests = append(requests, matchAPIRulesV2WithChangedService(apiRulesV2alpha1, obj)...)
-		return requests
-	})
+// NewExternalGatewayInformer enqueues the APIRules referencing a changed ExternalGateway.
+func NewExternalGatewayInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(r.apiRulesReferencingExternalGateway)
 }
 
-func matchAPIRulesV1WithChangedService(apiRulesV1beta1 []gatewayv1beta1.APIRule, obj client.Object) []reconcile.Request {
-	var requests []reconcile.Request
-	for _, apiRule := range apiRulesV1beta1 {
-		// match if service is exposed by an APIRule
-		// and add APIRule to the reconciliation queue
-		matches := func(target *gatewayv1beta1.Service) bool {
-			if target == nil {
-				return false
-			}
+func (r *APIRuleReconciler) apiRulesReferencingExternalGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.apiRulesByIndex(ctx, ExternalGatewayIndexField, GatewayIndexKey(obj.GetNamespace(), obj.GetName()))
+}
 
-			matchesNs := apiRule.Namespace == obj.GetNamespace()
-			if target.Namespace != nil {
-				matchesNs = *target.Namespace == obj.GetNamespace()
-			}
+// apiRulesByIndex returns the requests for the APIRules with the key in the field index.
+func (r *APIRuleReconciler) apiRulesByIndex(ctx context.Context, field, key string) []reconcile.Request {
+	return r.enqueueAPIRules(ctx, client.MatchingFields{field: key})
+}
 
-			var matchesName bool
-			if target.Name != nil {
-				matchesName = *target.Name == obj.GetName()
-			}
+// enqueueAPIRules returns the requests for the APIRules in the watch scope matching the list options. The APIRules are
+// read from the cache, because the client of the reconciler reads APIRules directly from the API server.
+func (r *APIRuleReconciler) enqueueAPIRules(ctx context.Context, opts ...client.ListOption) []reconcile.Request {
+	var apiRules gatewayv2alpha1.APIRuleList
+	if err := r.Cache.List(ctx, &apiRules, opts...); err != nil {
+		r.Log.Error(err, "Failed to list APIRules")
+		return nil
+	}
 
-			return matchesNs && matchesName
-		}
-		if matches(apiRule.Spec.Service) {
-			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
-				Namespace: apiRule.Namespace,
-				Name:      apiRule.Name,
-			}})
+	requests := make([]reconcile.Request, 0, len(apiRules.Items))
+	for _, apiRule := range apiRules.Items {
+		if !r.WatchScope.Contains(&apiRule) {
 			continue
 		}
-		for _, rule := range apiRule.Spec.Rules {
-			if matches(rule.Service) {
-				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
-					Namespace: apiRule.Namespace,
-					Name:      apiRule.Name,
-				}})
-				continue
-			}
-		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: apiRule.Namespace, Name: apiRule.Name}})
 	}
 	return requests
 }
 
-func matchAPIRulesV2WithChangedService(apiRules []gatewayv2alpha1.APIRule, obj client.Object) []reconcile.Request {
-	var requests []reconcile.Request
-	for _, apiRule := range apiRules {
-		// match if service is exposed by an APIRule
-		// and add APIRule to the reconciliation queue
-		matches := func(target *gatewayv2alpha1.Service) bool {
-			if target == nil {
-				return false
-			}
+// NewSameHostAPIRuleInformer enqueues the APIRules exposing one of the hosts of a changed APIRule, so path conflicts
+// between APIRules are re-evaluated when one of them changes or is deleted.
+func NewSameHostAPIRuleInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		apiRule, ok := obj.(*gatewayv2alpha1.APIRule)
+		if !ok {
+			return nil
+		}
 
-			matchesNs := apiRule.Namespace == obj.GetNamespace()
-			if target.Namespace != nil {
-				matchesNs = *target.Namespace == obj.GetNamespace()
+		var requests []reconcile.Request
+		for _, key := range hostIndexKeys(ctx, r.Client, apiRule) {
+			for _, request := range r.apiRulesByIndex(ctx, v2alpha1.HostsIndexField, key) {
+				if request.Namespace == apiRule.Namespace && request.Name == apiRule.Name || slices.Contains(requests, request) {
+					continue
+				}
+				requests = append(requests, request)
 			}
+		}
+		return requests
+	})
+}
 
-			var matchesName bool
-			if target.Name != nil {
-				matchesName = *target.Name == obj.GetName()
-			}
+// hostIndexKeys returns the keys of the hosts index that can refer to the hosts of the APIRule, that is the hosts as
+// defined in the spec, their short host names and for short host names the hosts with the domain of the Gateway.
+func hostIndexKeys(ctx context.Context, k8sClient client.Client, apiRule *gatewayv2alpha1.APIRule) []string {
+	var keys []string
+	add := func(key string) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
 
-			return matchesNs && matchesName
+	for _, host := range v2alpha1.IndexHosts(apiRule) {
+		add(host)
+		if shortHost, _, found := strings.Cut(host, "."); found {
+			add(shortHost)
+			continue
 		}
-		if matches(apiRule.Spec.Service) {
-			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
-				Namespace: apiRule.Namespace,
-				Name:      apiRule.Name,
-			}})
+
+		if apiRule.Spec.Gateway == nil {
 			continue
 		}
-		for _, rule := range apiRule.Spec.Rules {
-			if matches(rule.Service) {
-				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
-					Namespace: apiRule.Namespace,
-					Name:      apiRule.Name,
-				}})
-				continue
-			}
+		gatewayNamespace, gatewayName, found := strings.Cut(*apiRule.Spec.Gateway, "/")
+		if !found {
+			continue
+		}
+		if domain, err := default_domain.GetDomainFromGateway(ctx, k8sClient, gatewayName, gatewayNamespace); err == nil {
+			add(default_domain.GetHostWithDomain(host, domain))
 		}
 	}
-	return requests
+	return keys
+}
+
+// NewHostPolicyInformer enqueues all APIRules when a HostPolicy changes, because the host patterns of the policy can
+// match any host.
+func NewHostPolicyInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		return r.enqueueAPIRules(ctx)
+	})
+}
+
+// NewAPIGatewayInformer enqueues all APIRules when an APIGateway CR changes, because the APIRule settings of the CR
+// apply to all APIRules.
+func NewAPIGatewayInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		return r.enqueueAPIRules(ctx)
+	})
+}
+
+// NewAPIRulePolicyInformer enqueues all APIRules in the namespace of a changed APIRulePolicy.
+func NewAPIRulePolicyInformer(r *APIRuleReconciler) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if !r.WatchScope.ContainsNamespace(obj.GetNamespace()) {
+			return nil
+		}
+		return r.enqueueAPIRules(ctx, client.InNamespace(obj.GetNamespace()))
+	})
 }
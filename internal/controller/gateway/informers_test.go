@@ -0,0 +1,323 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller"
+)
+
+func v2APIRule(namespace, name, serviceName string, ruleServiceNamespace *string) *gatewayv2alpha1.APIRule {
+	return &gatewayv2alpha1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{gatewayv2.OriginalVersionAnnotation: "v2"},
+		},
+		Spec: gatewayv2alpha1.APIRuleSpec{
+			Service: &gatewayv2alpha1.Service{Name: ptr.To(serviceName), Port: ptr.To(uint32(8080))},
+			Rules: []gatewayv2alpha1.Rule{
+				{Path: "/rule-service", Service: &gatewayv2alpha1.Service{Name: ptr.To("rule-" + serviceName), Namespace: ruleServiceNamespace, Port: ptr.To(uint32(8080))}},
+			},
+		},
+	}
+}
+
+func v1beta1APIRule(t testing.TB, namespace, name, serviceName string) *gatewayv2alpha1.APIRule {
+	apiRule := &gatewayv1beta1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1beta1.APIRuleSpec{
+			Host:    ptr.To("example.com"),
+			Service: &gatewayv1beta1.Service{Name: ptr.To(serviceName), Port: ptr.To(uint32(8080))},
+			Rules: []gatewayv1beta1.Rule{
+				{Path: "/.*", Methods: []gatewayv1beta1.HttpMethod{"GET"}, AccessStrategies: []*gatewayv1beta1.Authenticator{{Handler: &gatewayv1beta1.Handler{Name: "noop"}}}},
+			},
+		},
+	}
+	converted := &gatewayv2alpha1.APIRule{}
+	if err := apiRule.ConvertTo(converted); err != nil {
+		t.Fatalf("convert APIRule: %v", err)
+	}
+	if converted.Annotations[gatewayv2.OriginalVersionAnnotation] != "v1beta1" {
+		t.Fatalf("expected original version annotation v1beta1, got %v", converted.Annotations)
+	}
+	return converted
+}
+
+func newInformerReconciler(apiRules ...*gatewayv2alpha1.APIRule) *APIRuleReconciler {
+	scheme := runtime.NewScheme()
+	Expect(gatewayv2alpha1.AddToScheme(scheme)).To(Succeed())
+	objects := make([]client.Object, 0, len(apiRules))
+	for _, apiRule := range apiRules {
+		objects = append(objects, apiRule)
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithIndex(&gatewayv2alpha1.APIRule{}, ServicesIndexField, IndexServices).
+		WithIndex(&gatewayv2alpha1.APIRule{}, GatewayIndexField, IndexGateway).
+		WithIndex(&gatewayv2alpha1.APIRule{}, ExternalGatewayIndexField, IndexExternalGateway).
+		Build()
+	return &APIRuleReconciler{Client: k8sClient, Cache: k8sClient, Log: logr.Discard()}
+}
+
+func mapService(r *APIRuleReconciler, namespace, name string) []reconcile.Request {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	return r.apiRulesExposingService(context.Background(), service)
+}
+
+func request(namespace, name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+func gatewayAPIRule(namespace, name string, gateway, externalGateway *string) *gatewayv2alpha1.APIRule {
+	return &gatewayv2alpha1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       gatewayv2alpha1.APIRuleSpec{Gateway: gateway, ExternalGateway: externalGateway},
+	}
+}
+
+var _ = Describe("Service informer", func() {
+	DescribeTable("IndexServices",
+		func(apiRule func() *gatewayv2alpha1.APIRule, expected []string) {
+			Expect(IndexServices(apiRule())).To(Equal(expected))
+		},
+		Entry("should index the Services on spec and rule of a v2 APIRule",
+			func() *gatewayv2alpha1.APIRule { return v2APIRule("default", "test", "backend", ptr.To("other")) },
+			[]string{"default/backend", "other/rule-backend"}),
+		Entry("should index the rule Service in the namespace of a v2 APIRule",
+			func() *gatewayv2alpha1.APIRule { return v2APIRule("default", "test", "backend", nil) },
+			[]string{"default/backend", "default/rule-backend"}),
+		Entry("should index the Service of a v1beta1 APIRule",
+			func() *gatewayv2alpha1.APIRule { return v1beta1APIRule(GinkgoTB(), "default", "test", "backend") },
+			[]string{"default/backend"}),
+		Entry("should not index an APIRule of unknown version",
+			func() *gatewayv2alpha1.APIRule {
+				return &gatewayv2alpha1.APIRule{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{gatewayv2.OriginalVersionAnnotation: "v3"}},
+					Spec:       gatewayv2alpha1.APIRuleSpec{Service: &gatewayv2alpha1.Service{Name: ptr.To("backend")}},
+				}
+			},
+			nil),
+	)
+
+	It("should enqueue the APIRules exposing the Service", func() {
+		// given
+		r := newInformerReconciler(
+			v2APIRule("default", "v2-spec", "backend", nil),
+			v2APIRule("default", "v2-rule", "other", ptr.To("default")),
+			v1beta1APIRule(GinkgoTB(), "default", "v1beta1", "backend"),
+			v2APIRule("other", "other-namespace", "backend", nil),
+		)
+
+		// when / then
+		Expect(mapService(r, "default", "backend")).To(ConsistOf(request("default", "v1beta1"), request("default", "v2-spec")))
+		Expect(mapService(r, "default", "rule-other")).To(ConsistOf(request("default", "v2-rule")))
+		Expect(mapService(r, "default", "not-exposed")).To(BeEmpty())
+	})
+
+	It("should only enqueue the APIRules in the watch scope", func() {
+		// given
+		inScope := v2APIRule("tenant-a", "in-scope", "backend", ptr.To("shared"))
+		inScope.Labels = map[string]string{"tenant-group": "a"}
+		otherGroup := v2APIRule("tenant-a", "other-group", "backend", ptr.To("shared"))
+		otherGroup.Labels = map[string]string{"tenant-group": "b"}
+		otherNamespace := v2APIRule("tenant-c", "other-namespace", "backend", ptr.To("shared"))
+		otherNamespace.Labels = map[string]string{"tenant-group": "a"}
+		r := newInformerReconciler(inScope, otherGroup, otherNamespace)
+		scope, err := controller.ParseWatchScope("tenant-a,tenant-b", "tenant-group=a")
+		Expect(err).ToNot(HaveOccurred())
+		r.WatchScope = scope
+
+		// when / then
+		Expect(mapService(r, "shared", "rule-backend")).To(ConsistOf(request("tenant-a", "in-scope")))
+	})
+})
+
+var _ = Describe("Gateway informer", func() {
+	DescribeTable("IndexGateway and IndexExternalGateway",
+		func(apiRule *gatewayv2alpha1.APIRule, expectedGateway, expectedExternalGateway []string) {
+			Expect(IndexGateway(apiRule)).To(Equal(expectedGateway))
+			Expect(IndexExternalGateway(apiRule)).To(Equal(expectedExternalGateway))
+		},
+		Entry("should index the Gateway",
+			gatewayAPIRule("default", "test", ptr.To("kyma-system/kyma-gateway"), nil),
+			[]string{"kyma-system/kyma-gateway"}, nil),
+		Entry("should index the ExternalGateway with namespace",
+			gatewayAPIRule("default", "test", nil, ptr.To("other/external")),
+			nil, []string{"other/external"}),
+		Entry("should index the ExternalGateway without namespace in the namespace of the APIRule",
+			gatewayAPIRule("default", "test", nil, ptr.To("external")),
+			nil, []string{"default/external"}),
+		Entry("should not index an APIRule without gateway",
+			gatewayAPIRule("default", "test", nil, nil),
+			nil, nil),
+	)
+
+	Context("enqueue APIRules", func() {
+		var r *APIRuleReconciler
+
+		BeforeEach(func() {
+			r = newInformerReconciler(
+				gatewayAPIRule("default", "kyma-gateway", ptr.To("kyma-system/kyma-gateway"), nil),
+				gatewayAPIRule("default", "other-gateway", ptr.To("kyma-system/other-gateway"), nil),
+				gatewayAPIRule("default", "external", nil, ptr.To("external")),
+				gatewayAPIRule("other", "external-other-namespace", nil, ptr.To("external")),
+			)
+		})
+
+		It("should enqueue the APIRules referencing the Istio Gateway", func() {
+			// given
+			gateway := &networkingv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "kyma-gateway", Namespace: "kyma-system"}}
+
+			// when
+			requests := r.apiRulesReferencingGateway(context.Background(), gateway)
+
+			// then
+			Expect(requests).To(ConsistOf(request("default", "kyma-gateway")))
+		})
+
+		It("should enqueue the APIRules referencing the ExternalGateway of a generated Gateway", func() {
+			// given
+			externalGateway := &externalv1alpha1.ExternalGateway{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default", UID: "uid"}}
+			gateway := &networkingv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{
+				Name:      externalGateway.GatewayName(),
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(externalGateway, externalv1alpha1.GroupVersion.WithKind("ExternalGateway")),
+				},
+			}}
+
+			// when
+			requests := r.apiRulesReferencingGateway(context.Background(), gateway)
+
+			// then
+			Expect(requests).To(ConsistOf(request("default", "external")))
+		})
+
+		It("should enqueue the APIRules referencing the ExternalGateway", func() {
+			// given
+			externalGateway := &externalv1alpha1.ExternalGateway{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "other"}}
+
+			// when
+			requests := r.apiRulesReferencingExternalGateway(context.Background(), externalGateway)
+
+			// then
+			Expect(requests).To(ConsistOf(request("other", "external-other-namespace")))
+		})
+	})
+})
+
+// indexerReader reads APIRules from a client-go indexer like the cache of the manager, because the fake client
+// evaluates field selectors by filtering all objects.
+type indexerReader struct {
+	indexer toolscache.Indexer
+}
+
+func newIndexerReader(b *testing.B, apiRules []*gatewayv2alpha1.APIRule) indexerReader {
+	indexer := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{
+		"field:" + ServicesIndexField: func(obj any) ([]string, error) {
+			return IndexServices(obj.(client.Object)), nil
+		},
+	})
+	for _, apiRule := range apiRules {
+		if err := indexer.Add(apiRule); err != nil {
+			b.Fatalf("add APIRule to indexer: %v", err)
+		}
+	}
+	return indexerReader{indexer: indexer}
+}
+
+func (r indexerReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return errors.New("not supported")
+}
+
+func (r indexerReader) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	objs := r.indexer.List()
+	if listOpts.FieldSelector != nil {
+		requirement := listOpts.FieldSelector.Requirements()[0]
+		var err error
+		if objs, err = r.indexer.ByIndex("field:"+requirement.Field, requirement.Value); err != nil {
+			return err
+		}
+	}
+
+	apiRuleList := list.(*gatewayv2alpha1.APIRuleList)
+	for _, obj := range objs {
+		apiRuleList.Items = append(apiRuleList.Items, *obj.(*gatewayv2alpha1.APIRule).DeepCopy())
+	}
+	return nil
+}
+
+// listAndMatchServices is the previous implementation of the Service informer, which lists all APIRules and converts
+// the APIRules of version v1beta1 on every change of a Service. It's kept as the baseline of the benchmark.
+func listAndMatchServices(ctx context.Context, reader client.Reader, obj client.Object) []reconcile.Request {
+	var apiRules gatewayv2alpha1.APIRuleList
+	if err := reader.List(ctx, &apiRules); err != nil {
+		return nil
+	}
+
+	key := ServiceIndexKey(obj.GetNamespace(), obj.GetName())
+	var requests []reconcile.Request
+	for i := range apiRules.Items {
+		if slices.Contains(IndexServices(&apiRules.Items[i]), key) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: apiRules.Items[i].Namespace, Name: apiRules.Items[i].Name}})
+		}
+	}
+	return requests
+}
+
+func BenchmarkServiceInformer(b *testing.B) {
+	const apiRuleCount = 2000
+	apiRules := make([]*gatewayv2alpha1.APIRule, 0, apiRuleCount)
+	for i := range apiRuleCount {
+		namespace := fmt.Sprintf("namespace-%d", i%50)
+		if i%4 == 0 {
+			apiRules = append(apiRules, v1beta1APIRule(b, namespace, fmt.Sprintf("apirule-%d", i), fmt.Sprintf("service-%d", i)))
+			continue
+		}
+		apiRules = append(apiRules, v2APIRule(namespace, fmt.Sprintf("apirule-%d", i), fmt.Sprintf("service-%d", i), nil))
+	}
+	reader := newIndexerReader(b, apiRules)
+	r := &APIRuleReconciler{Cache: reader, Log: logr.Discard()}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "service-1001", Namespace: "namespace-1"}}
+	ctx := context.Background()
+
+	b.Run("list all APIRules", func(b *testing.B) {
+		for b.Loop() {
+			if len(listAndMatchServices(ctx, reader, service)) != 1 {
+				b.Fatal("expected one request")
+			}
+		}
+	})
+
+	b.Run("services index", func(b *testing.B) {
+		for b.Loop() {
+			if len(r.apiRulesExposingService(ctx, service)) != 1 {
+				b.Fatal("expected one request")
+			}
+		}
+	})
+}
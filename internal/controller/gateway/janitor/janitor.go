@@ -0,0 +1,227 @@
+// Package janitor removes subresources of APIRules whose owning APIRule no longer exists.
+//
+// Subresources are usually removed by the APIRule controller when the APIRule is deleted. If the finalizer of the
+// APIRule is removed manually or the controller fails in between, the subresources are left behind and still
+// expose the workloads. The janitor periodically lists all subresources created by the module and deletes the ones
+// whose owning APIRule can't be found.
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/metrics"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	rulev1alpha1 "github.com/kyma-project/api-gateway/internal/types/ory/oathkeeper-maester/api/v1alpha1"
+)
+
+const (
+	DefaultInterval    = 1 * time.Hour
+	DefaultGracePeriod = 10 * time.Minute
+
+	EventReasonOrphanDetected = "OrphanedSubresourceDetected"
+	EventReasonOrphanDeleted  = "OrphanedSubresourceDeleted"
+)
+
+// SubresourceKinds are the kinds of subresources that are created for APIRules.
+var SubresourceKinds = []schema.GroupVersionKind{
+	networkingv1beta1.SchemeGroupVersion.WithKind("VirtualService"),
+	securityv1beta1.SchemeGroupVersion.WithKind("AuthorizationPolicy"),
+	securityv1beta1.SchemeGroupVersion.WithKind("RequestAuthentication"),
+	networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"),
+	rulev1alpha1.GroupVersion.WithKind("Rule"),
+}
+
+type Config struct {
+	// Interval is the time between two runs of the janitor. The janitor is disabled if the interval is zero.
+	Interval time.Duration
+	// GracePeriod is the minimum age of a subresource before it's considered orphaned. This prevents that
+	// subresources are deleted while the owning APIRule is created or not yet visible to the janitor.
+	GracePeriod time.Duration
+	// ReportOnly disables the deletion of orphaned subresources. Orphans are only reported in logs, Events and metrics.
+	ReportOnly bool
+}
+
+// Janitor finds and deletes subresources whose owning APIRule doesn't exist.
+type Janitor struct {
+	// Client is used to list and delete subresources. Subresources are read as unstructured objects, so they
+	// are always read from the API server.
+	Client client.Client
+	// APIReader is used to check whether the owning APIRule exists, so that a stale cache never leads
+	// to the deletion of subresources of an existing APIRule.
+	APIReader client.Reader
+	Recorder  events.EventRecorder
+	Metrics   *metrics.ApiGatewayMetrics
+	Log       logr.Logger
+	Config    Config
+	// Now returns the current time and can be replaced in tests.
+	Now func() time.Time
+}
+
+// Orphan is a subresource whose owning APIRule doesn't exist.
+type Orphan struct {
+	Object *unstructured.Unstructured
+	Owner  types.NamespacedName
+}
+
+func NewJanitor(mgr manager.Manager, config Config, metrics *metrics.ApiGatewayMetrics) *Janitor {
+	return &Janitor{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Recorder:  mgr.GetEventRecorder("api-gateway-janitor"),
+		Metrics:   metrics,
+		Log:       mgr.GetLogger().WithName("janitor"),
+		Config:    config,
+		Now:       time.Now,
+	}
+}
+
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// SetupWithManager adds the janitor to the manager if it's enabled.
+func (j *Janitor) SetupWithManager(mgr manager.Manager) error {
+	if j.Config.Interval <= 0 {
+		j.Log.Info("Janitor for orphaned subresources is disabled")
+		return nil
+	}
+	return mgr.Add(j)
+}
+
+// NeedLeaderElection makes sure that only the leader deletes orphaned subresources.
+func (j *Janitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the janitor periodically until the context is cancelled.
+func (j *Janitor) Start(ctx context.Context) error {
+	j.Log.Info("Starting janitor for orphaned subresources", "interval", j.Config.Interval, "reportOnly", j.Config.ReportOnly)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if _, err := j.Run(ctx); err != nil {
+			j.Log.Error(err, "Failed to clean up orphaned subresources")
+		}
+	}, j.Config.Interval)
+	return nil
+}
+
+// Run finds the orphaned subresources of all kinds and deletes them unless the janitor runs in report-only mode.
+// It returns the orphans that were found.
+func (j *Janitor) Run(ctx context.Context) ([]Orphan, error) {
+	owners := make(map[types.NamespacedName]bool)
+	var found []Orphan
+
+	for _, gvk := range SubresourceKinds {
+		orphans, err := j.findOrphans(ctx, gvk, owners)
+		if err != nil {
+			return found, fmt.Errorf("finding orphaned %s: %w", gvk.Kind, err)
+		}
+		j.Metrics.SetOrphanedSubresources(gvk.Kind, len(orphans))
+		found = append(found, orphans...)
+
+		for _, orphan := range orphans {
+			if err := j.handleOrphan(ctx, orphan); err != nil {
+				return found, err
+			}
+		}
+	}
+
+	return found, nil
+}
+
+func (j *Janitor) findOrphans(ctx context.Context, gvk schema.GroupVersionKind, owners map[types.NamespacedName]bool) ([]Orphan, error) {
+	list := unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	err := j.Client.List(ctx, &list, client.MatchingLabels{processing.ModuleLabelKey: processing.ApiGatewayLabelValue})
+	if apimeta.IsNoMatchError(err) {
+		// The CRD of the kind is not installed in the cluster, e.g. Ory Oathkeeper Rules.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []Orphan
+	for i := range list.Items {
+		obj := &list.Items[i]
+		owner, ok := processing.GetOwnerFromLabels(obj.GetLabels())
+		if !ok || !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if j.Now().Sub(obj.GetCreationTimestamp().Time) < j.Config.GracePeriod {
+			continue
+		}
+
+		exists, checked := owners[owner]
+		if !checked {
+			exists, err = j.apiRuleExists(ctx, owner)
+			if err != nil {
+				return nil, err
+			}
+			owners[owner] = exists
+		}
+
+		if !exists {
+			orphans = append(orphans, Orphan{Object: obj, Owner: owner})
+		}
+	}
+
+	return orphans, nil
+}
+
+func (j *Janitor) apiRuleExists(ctx context.Context, owner types.NamespacedName) (bool, error) {
+	apiRule := metav1.PartialObjectMetadata{}
+	apiRule.SetGroupVersionKind(gatewayv2alpha1.GroupVersion.WithKind("APIRule"))
+	err := j.APIReader.Get(ctx, owner, &apiRule)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (j *Janitor) handleOrphan(ctx context.Context, orphan Orphan) error {
+	obj := orphan.Object
+	log := j.Log.WithValues("kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName(), "apiRule", orphan.Owner.String())
+
+	if j.Config.ReportOnly {
+		log.Info("Found orphaned subresource")
+		j.Recorder.Eventf(obj, nil, corev1.EventTypeWarning, EventReasonOrphanDetected, "Report",
+			"Owning APIRule %s does not exist", orphan.Owner)
+		return nil
+	}
+
+	// The preconditions make sure that a subresource that was replaced in the meantime is not deleted.
+	uid, resourceVersion := obj.GetUID(), obj.GetResourceVersion()
+	err := j.Client.Delete(ctx, obj, client.Preconditions{UID: &uid, ResourceVersion: &resourceVersion})
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deleting orphaned %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	log.Info("Deleted orphaned subresource")
+	j.Metrics.IncreaseOrphanedSubresourcesDeletedCounter(obj.GetKind())
+	j.Recorder.Eventf(obj, nil, corev1.EventTypeNormal, EventReasonOrphanDeleted, "Delete",
+		"Deleted because owning APIRule %s does not exist", orphan.Owner)
+	return nil
+}
@@ -0,0 +1,237 @@
+package janitor_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller/gateway/janitor"
+	"github.com/kyma-project/api-gateway/internal/metrics"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	rulev1alpha1 "github.com/kyma-project/api-gateway/internal/types/ory/oathkeeper-maester/api/v1alpha1"
+)
+
+var apiGatewayMetrics = metrics.NewApiGatewayMetrics()
+
+var _ = Describe("Janitor", func() {
+	var (
+		now      = time.Now()
+		old      = metav1.NewTime(now.Add(-time.Hour))
+		recorder *events.FakeRecorder
+	)
+
+	newScheme := func() *runtime.Scheme {
+		scheme := runtime.NewScheme()
+		utilruntime.Must(gatewayv2alpha1.AddToScheme(scheme))
+		utilruntime.Must(networkingv1beta1.AddToScheme(scheme))
+		utilruntime.Must(securityv1beta1.AddToScheme(scheme))
+		utilruntime.Must(rulev1alpha1.AddToScheme(scheme))
+		return scheme
+	}
+
+	newJanitor := func(c client.Client, reportOnly bool) *janitor.Janitor {
+		recorder = events.NewFakeRecorder(10)
+		return &janitor.Janitor{
+			Client:    c,
+			APIReader: c,
+			Recorder:  recorder,
+			Metrics:   apiGatewayMetrics,
+			Log:       logr.Discard(),
+			Config: janitor.Config{
+				Interval:    time.Minute,
+				GracePeriod: janitor.DefaultGracePeriod,
+				ReportOnly:  reportOnly,
+			},
+			Now: func() time.Time { return now },
+		}
+	}
+
+	moduleLabels := func(owner map[string]string) map[string]string {
+		labels := map[string]string{processing.ModuleLabelKey: processing.ApiGatewayLabelValue}
+		for k, v := range owner {
+			labels[k] = v
+		}
+		return labels
+	}
+
+	apiRule := &gatewayv2alpha1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+	}
+
+	virtualService := func(name string, labels map[string]string, created metav1.Time) *networkingv1beta1.VirtualService {
+		return &networkingv1beta1.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels, CreationTimestamp: created},
+		}
+	}
+
+	exists := func(c client.Client, obj client.Object) bool {
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).ShouldNot(HaveOccurred())
+		return true
+	}
+
+	It("should delete subresources with owner labels of a not existing APIRule", func() {
+		// given
+		orphan := virtualService("orphan", moduleLabels(map[string]string{
+			processing.OwnerLabelName:      "deleted",
+			processing.OwnerLabelNamespace: "default",
+		}), old)
+		owned := virtualService("owned", moduleLabels(map[string]string{
+			processing.OwnerLabelName:      "existing",
+			processing.OwnerLabelNamespace: "default",
+		}), old)
+		c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(apiRule, orphan, owned).Build()
+
+		// when
+		orphans, err := newJanitor(c, false).Run(context.Background())
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orphans).To(HaveLen(1))
+		Expect(orphans[0].Owner.String()).To(Equal("default/deleted"))
+		Expect(exists(c, orphan)).To(BeFalse())
+		Expect(exists(c, owned)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring(janitor.EventReasonOrphanDeleted)))
+		Expect(gaugeValue("VirtualService")).To(Equal(float64(1)))
+	})
+
+	It("should delete subresources with the legacy owner label of a not existing APIRule", func() {
+		// given
+		orphan := &securityv1beta1.AuthorizationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "orphan",
+				Namespace:         "default",
+				Labels:            moduleLabels(map[string]string{processing.LegacyOwnerLabel: "name.with.dots.default"}),
+				CreationTimestamp: old,
+			},
+		}
+		owned := &securityv1beta1.AuthorizationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "owned",
+				Namespace:         "default",
+				Labels:            moduleLabels(map[string]string{processing.LegacyOwnerLabel: "existing.default"}),
+				CreationTimestamp: old,
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(apiRule, orphan, owned).Build()
+
+		// when
+		orphans, err := newJanitor(c, false).Run(context.Background())
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orphans).To(HaveLen(1))
+		Expect(orphans[0].Owner.String()).To(Equal("default/name.with.dots"))
+		Expect(exists(c, orphan)).To(BeFalse())
+		Expect(exists(c, owned)).To(BeTrue())
+	})
+
+	It("should not delete subresources younger than the grace period", func() {
+		// given
+		orphan := virtualService("orphan", moduleLabels(map[string]string{
+			processing.OwnerLabelName:      "deleted",
+			processing.OwnerLabelNamespace: "default",
+		}), metav1.NewTime(now.Add(-time.Minute)))
+		c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(orphan).Build()
+
+		// when
+		orphans, err := newJanitor(c, false).Run(context.Background())
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orphans).To(BeEmpty())
+		Expect(exists(c, orphan)).To(BeTrue())
+	})
+
+	It("should not delete subresources without module or owner labels", func() {
+		// given
+		withoutModuleLabel := virtualService("without-module-label", map[string]string{
+			processing.OwnerLabelName:      "deleted",
+			processing.OwnerLabelNamespace: "default",
+		}, old)
+		withoutOwner := virtualService("without-owner", moduleLabels(nil), old)
+		c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(withoutModuleLabel, withoutOwner).Build()
+
+		// when
+		orphans, err := newJanitor(c, false).Run(context.Background())
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orphans).To(BeEmpty())
+		Expect(exists(c, withoutModuleLabel)).To(BeTrue())
+		Expect(exists(c, withoutOwner)).To(BeTrue())
+	})
+
+	It("should only emit an event for orphaned subresources in report-only mode", func() {
+		// given
+		orphan := &rulev1alpha1.Rule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "orphan",
+				Namespace: "default",
+				Labels: moduleLabels(map[string]string{
+					processing.OwnerLabelName:      "deleted",
+					processing.OwnerLabelNamespace: "default",
+				}),
+				CreationTimestamp: old,
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(orphan).Build()
+
+		// when
+		orphans, err := newJanitor(c, true).Run(context.Background())
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(orphans).To(HaveLen(1))
+		Expect(exists(c, orphan)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(And(
+			ContainSubstring(janitor.EventReasonOrphanDetected),
+			ContainSubstring("default/deleted"),
+		)))
+		Expect(gaugeValue("Rule")).To(Equal(float64(1)))
+	})
+})
+
+func gaugeValue(kind string) float64 {
+	families, err := ctrlmetrics.Registry.Gather()
+	Expect(err).ShouldNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() != "api_gateway_orphaned_subresources" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if hasLabel(metric, "kind", kind) {
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func hasLabel(metric *dto.Metric, name, value string) bool {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name && label.GetValue() == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package janitor_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+
+	"github.com/kyma-project/api-gateway/tests"
+)
+
+func TestJanitor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Janitor Suite")
+}
+
+var _ = ReportAfterSuite("custom reporter", func(report types.Report) {
+	tests.GenerateGinkgoJunitReport("api-gateway-janitor-suite", report)
+})
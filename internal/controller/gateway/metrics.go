@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kyma-project/api-gateway/internal/metrics"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+const phaseStatusUpdate = "status_update"
+
+// apiRuleMetricsObserver records the metrics for the validation failures, the changes of the subresources and the
+// durations of the phases of the reconciliation.
+type apiRuleMetricsObserver struct {
+	metrics *metrics.ApiGatewayMetrics
+	scheme  *runtime.Scheme
+}
+
+func (o apiRuleMetricsObserver) ValidationFailed(failures []validation.Failure) {
+	for _, failure := range failures {
+		o.metrics.IncreaseAPIRuleValidationFailuresCounter(failure.AttributePath)
+	}
+}
+
+func (o apiRuleMetricsObserver) ChangeApplied(change *processing.ObjectChange, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	o.metrics.IncreaseAPIRuleSubresourceChangesCounter(objectKind(change.Obj, o.scheme), change.Action.String(), result)
+}
+
+func (o apiRuleMetricsObserver) PhaseCompleted(phase string, duration time.Duration) {
+	o.metrics.ObserveAPIRuleReconcilePhaseDuration(phase, duration)
+}
@@ -28,6 +28,7 @@ import (
 	"istio.io/api/networking/v1alpha3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	runtimecontroller "sigs.k8s.io/controller-runtime/pkg/controller"
@@ -46,6 +47,9 @@ type RateLimitReconciler struct {
 	client.Client
 	Scheme          *runtime.Scheme
 	ReconcilePeriod time.Duration
+	Recorder        events.EventRecorder
+	// MaxConcurrentReconciles is the maximum number of RateLimits reconciled at the same time. Defaults to 1.
+	MaxConcurrentReconciles int
 }
 
 // +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=ratelimits,verbs=get;list;watch;create;update;patch;delete
@@ -65,10 +69,14 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if err := r.Get(ctx, req.NamespacedName, &rl); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	previousState := rl.Status.State
 
 	if d, err := dependencies.RateLimit().AreAvailable(ctx, r.Client); err != nil {
+		if apierrors.IsNotFound(err) {
+			controller.RecordDependencyMissing(r.Recorder, &rl, d)
+		}
 		rl.Status.Error(fmt.Errorf("dependency missing '%s': %w", d, err))
-		if err := r.Status().Update(ctx, &rl); err != nil {
+		if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, err
@@ -82,7 +90,7 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	if len(existingAPIGateways.Items) < 1 {
 		rl.Status.Warning(fmt.Errorf("failed to reconcile RateLimit CR because of missing APIGateway CR in the cluster"))
-		if err := r.Status().Update(ctx, &rl); err != nil {
+		if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 			return ctrl.Result{}, err
 		}
 		err := fmt.Errorf("no APIGateway CR in the cluster")
@@ -92,7 +100,7 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	latestCr := operatorv1alpha1.GetOldestAPIGatewayCR(existingAPIGateways)
 	if latestCr == nil {
 		rl.Status.Warning(fmt.Errorf("failed to reconcile RateLimit CR because of missing APIGateway CR in the cluster"))
-		if err := r.Status().Update(ctx, &rl); err != nil {
+		if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 			return ctrl.Result{}, err
 		}
 		err := fmt.Errorf("no APIGateway CR in the cluster")
@@ -101,7 +109,7 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	if latestCr.Status.State != operatorv1alpha1.Ready {
 		rl.Status.Warning(fmt.Errorf("failed to create RateLimit CR because APIGateway CR is in %s state", latestCr.Status.State))
-		if err := r.Status().Update(ctx, &rl); err != nil {
+		if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 			return ctrl.Result{}, err
 		}
 		err := fmt.Errorf("APIGateway CR %s/%s is in %s state", latestCr.Namespace, latestCr.Name, latestCr.Status.State)
@@ -111,8 +119,9 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	l.Info("Validating RateLimit resource")
 	err := ratelimit.Validate(ctx, r.Client, rl)
 	if err != nil {
+		controller.RecordValidationError(r.Recorder, &rl, err)
 		rl.Status.Error(fmt.Errorf("failed to validate RateLimit: %w", err))
-		if err := r.Status().Update(ctx, &rl); err != nil {
+		if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, err
@@ -124,7 +133,7 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	ef := builder.Build()
 
 	l.Info("Updating EnvoyFilter resource to desired state", "EnvoyFilter.Name", ef.Name)
-	if err := r.createOrUpdate(ctx, ef, func() error {
+	op, err := r.createOrUpdate(ctx, ef, func() error {
 		if err := controllerutil.SetControllerReference(&rl, ef, r.Scheme); err != nil {
 			return err
 		}
@@ -156,44 +165,67 @@ func (r *RateLimitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 		limit.SetConfigPatches(ef)
 		return nil
-	}); err != nil {
+	})
+	if err != nil {
 		l.Error(err, "Failed to create EnvoyFilter", "EnvoyFilter.Name", ef.Name)
+		controller.RecordSubresourceChange(r.Recorder, &rl, controller.EventActionCreate, "EnvoyFilter", ef.Namespace, ef.Name, err)
 		rl.Status.Error(fmt.Errorf("failed to create EnvoyFilter: %w", err))
-		if err := r.Status().Update(ctx, &rl); err != nil {
+		if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, err
 	}
 
+	switch op {
+	case controllerutil.OperationResultCreated:
+		controller.RecordSubresourceChange(r.Recorder, &rl, controller.EventActionCreate, "EnvoyFilter", ef.Namespace, ef.Name, nil)
+	case controllerutil.OperationResultUpdated:
+		controller.RecordSubresourceChange(r.Recorder, &rl, controller.EventActionUpdate, "EnvoyFilter", ef.Namespace, ef.Name, nil)
+	}
+
 	l.Info("Reconciliation finished")
 	rl.Status.Ready()
-	if err := r.Status().Update(ctx, &rl); err != nil {
+	if err := r.updateStatus(ctx, &rl, previousState); err != nil {
 		return ctrl.Result{}, err
 	}
 	return ctrl.Result{RequeueAfter: r.ReconcilePeriod}, nil
 }
 
-func (r *RateLimitReconciler) createOrUpdate(ctx context.Context, obj client.Object, mutate func() error) error {
+// updateStatus updates the status of the RateLimit and records an Event if its state changed.
+func (r *RateLimitReconciler) updateStatus(ctx context.Context, rl *ratelimitv1alpha1.RateLimit, previousState string) error {
+	if err := r.Status().Update(ctx, rl); err != nil {
+		return err
+	}
+	controller.RecordStateTransition(r.Recorder, rl, previousState, rl.Status.State, rl.Status.Description)
+	return nil
+}
+
+func (r *RateLimitReconciler) createOrUpdate(ctx context.Context, obj client.Object, mutate func() error) (controllerutil.OperationResult, error) {
 	key := client.ObjectKeyFromObject(obj)
 	if err := r.Get(ctx, key, obj); err != nil {
 		if !apierrors.IsNotFound(err) {
-			return err
+			return controllerutil.OperationResultNone, err
 		}
 		if err := mutate(); err != nil {
-			return err
+			return controllerutil.OperationResultNone, err
 		}
 		if err := r.Create(ctx, obj); err != nil {
-			return err
+			return controllerutil.OperationResultNone, err
 		}
-		return nil
+		return controllerutil.OperationResultCreated, nil
 	}
 	if err := mutate(); err != nil {
-		return err
+		return controllerutil.OperationResultNone, err
 	}
+	// The resource version only changes if the update modified the object.
+	resourceVersion := obj.GetResourceVersion()
 	if err := r.Update(ctx, obj); err != nil {
-		return err
+		return controllerutil.OperationResultNone, err
 	}
-	return nil
+	if obj.GetResourceVersion() == resourceVersion {
+		return controllerutil.OperationResultNone, nil
+	}
+	return controllerutil.OperationResultUpdated, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -201,7 +233,8 @@ func (r *RateLimitReconciler) SetupWithManager(mgr ctrl.Manager, c controller.Ra
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ratelimitv1alpha1.RateLimit{}).
 		WithOptions(runtimecontroller.Options{
-			RateLimiter: controller.NewRateLimiter(c),
+			RateLimiter:             controller.NewRateLimiter(c),
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
 		Complete(r)
 }
@@ -211,5 +244,6 @@ func NewRateLimitReconciler(mgr manager.Manager) *RateLimitReconciler {
 		Client:          mgr.GetClient(),
 		Scheme:          mgr.GetScheme(),
 		ReconcilePeriod: defaultReconciliationPeriod,
+		Recorder:        mgr.GetEventRecorder("ratelimit-controller"),
 	}
 }
@@ -45,10 +45,16 @@ func doneReconcileMigrationRequeue(reconcilerPeriod time.Duration) (ctrl.Result,
 func (r *APIRuleReconciler) updateStatus(ctx context.Context, l logr.Logger,
 	apiRule client.Object, reconcileError bool) (ctrl.Result, error) {
 	l.Info("Updating APIRule status")
-	if err := r.Status().Update(ctx, apiRule); err != nil {
+	start := time.Now()
+	err := r.Status().Update(ctx, apiRule)
+	if r.Metrics != nil {
+		r.Metrics.ObserveAPIRuleReconcilePhaseDuration(phaseStatusUpdate, time.Since(start))
+	}
+	if err != nil {
 		l.Error(err, "Error updating APIRule status")
 		return doneReconcileErrorRequeue(err, r.OnErrorReconcilePeriod)
 	}
+	r.recordStateTransition(ctx, apiRule)
 	if _, ok := apiRule.GetAnnotations()[migration.AnnotationName]; ok {
 		l.Info("Finished reconciliation", "next", r.MigrationReconcilePeriod)
 		return doneReconcileMigrationRequeue(r.MigrationReconcilePeriod)
@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+// ServicesIndexField is the name of the field index on the backend Services of APIRules. The keys of the index are
+// built with ServiceIndexKey.
+const ServicesIndexField = "spec.services"
+
+// ServiceIndexKey returns the key of a Service in the ServicesIndexField index.
+func ServiceIndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// IndexServices returns the keys of the Services exposed by an APIRule, either on the spec or on one of the rules.
+// APIRules of both versions are stored as v2alpha1 in the cache, so APIRules created with version v1beta1 are
+// converted to v1beta1 once when they are indexed, instead of on every change of a Service.
+func IndexServices(obj client.Object) []string {
+	apiRule, ok := obj.(*gatewayv2alpha1.APIRule)
+	if !ok {
+		return nil
+	}
+
+	originalVersion, ok := apiRule.Annotations[gatewayv2.OriginalVersionAnnotation]
+	switch {
+	case !ok || slices.Contains([]string{"v2", "v2alpha1"}, originalVersion):
+		return indexServicesV2alpha1(apiRule)
+	case originalVersion == "v1beta1":
+		converted := gatewayv1beta1.APIRule{}
+		if err := converted.ConvertFrom(apiRule); err != nil {
+			return nil
+		}
+		return indexServicesV1beta1(&converted)
+	}
+	return nil
+}
+
+func indexServicesV2alpha1(apiRule *gatewayv2alpha1.APIRule) []string {
+	var keys []string
+	add := func(service *gatewayv2alpha1.Service) {
+		if service == nil || service.Name == nil {
+			return
+		}
+		namespace := apiRule.Namespace
+		if service.Namespace != nil {
+			namespace = *service.Namespace
+		}
+		if key := ServiceIndexKey(namespace, *service.Name); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	add(apiRule.Spec.Service)
+	for _, rule := range apiRule.Spec.Rules {
+		add(rule.Service)
+	}
+	return keys
+}
+
+func indexServicesV1beta1(apiRule *gatewayv1beta1.APIRule) []string {
+	var keys []string
+	add := func(service *gatewayv1beta1.Service) {
+		if service == nil || service.Name == nil {
+			return
+		}
+		namespace := apiRule.Namespace
+		if service.Namespace != nil {
+			namespace = *service.Namespace
+		}
+		if key := ServiceIndexKey(namespace, *service.Name); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	add(apiRule.Spec.Service)
+	for _, rule := range apiRule.Spec.Rules {
+		add(rule.Service)
+	}
+	return keys
+}
@@ -17,7 +17,9 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
@@ -81,6 +83,8 @@ var _ = BeforeSuite(func(specCtx SpecContext) {
 	Expect(gatewayv2alpha1.AddToScheme(s)).Should(Succeed())
 	Expect(gatewayv2.AddToScheme(s)).Should(Succeed())
 	Expect(externalv1alpha1.AddToScheme(s)).Should(Succeed())
+	Expect(hostpolicyv1alpha1.AddToScheme(s)).Should(Succeed())
+	Expect(apirulepolicyv1alpha1.AddToScheme(s)).Should(Succeed())
 	Expect(rulev1alpha1.AddToScheme(s)).Should(Succeed())
 	Expect(networkingv1beta1.AddToScheme(s)).Should(Succeed())
 	Expect(securityv1beta1.AddToScheme(s)).Should(Succeed())
@@ -227,6 +231,9 @@ var _ = BeforeSuite(func(specCtx SpecContext) {
 	apiGatewayMetrics := metrics.NewApiGatewayMetrics()
 
 	apiReconciler := gateway.NewApiRuleReconciler(mgr, reconcilerConfig, apiGatewayMetrics)
+	// Changes of subresources can be made to fail to test the rollback of the reconciliation.
+	applyFailures.Client = apiReconciler.Client
+	apiReconciler.Client = applyFailures
 	rateLimiterCfg := controller.RateLimiterConfig{
 		Burst:            200,
 		Frequency:        30,
@@ -235,7 +242,7 @@ var _ = BeforeSuite(func(specCtx SpecContext) {
 	}
 
 	Expect(apiReconciler.SetupWithManager(mgr, rateLimiterCfg)).Should(Succeed())
-	Expect(webhookv2alpha1.SetupWebhookWithManager(mgr)).Should(Succeed())
+	Expect(webhookv2alpha1.SetupWebhookWithManager(mgr, webhookv2alpha1.DefaultValidationConfig())).Should(Succeed())
 
 	go func() {
 		defer GinkgoRecover()
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyma-project/api-gateway/internal/controller"
 	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/metrics"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"istio.io/api/networking/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -25,6 +27,17 @@ type APIRuleReconciler struct {
 	OnErrorReconcilePeriod   time.Duration
 	MigrationReconcilePeriod time.Duration
 	Metrics                  *metrics.ApiGatewayMetrics
+	// Cache is used to read APIRules by field indexes, because reading APIRules with the Client is not cached.
+	Cache client.Reader
+	// APIReader is used to read the subresources before they are changed, so that a rollback never restores an
+	// outdated version from the cache.
+	APIReader client.Reader
+	Recorder  events.EventRecorder
+	// MaxConcurrentReconciles is the maximum number of APIRules reconciled at the same time. Defaults to 1.
+	MaxConcurrentReconciles int
+	// WatchScope restricts the reconciled APIRules. The cache only contains APIRules in the scope, but requests are
+	// also enqueued for subresources in all namespaces and APIRules are read from the API server.
+	WatchScope controller.WatchScope
 }
 
 type ApiRuleReconcilerConfiguration struct {
@@ -34,6 +47,9 @@ type ApiRuleReconcilerConfiguration struct {
 	ReconciliationPeriod                                 uint
 	ErrorReconciliationPeriod                            uint
 	MigrationReconciliationPeriod                        uint
+	ConsolidateAuthorizationPolicies                     bool
+	MaxConcurrentReconciles                              int
+	WatchScope                                           controller.WatchScope
 }
 
 func NewApiRuleReconciler(mgr manager.Manager, config ApiRuleReconcilerConfiguration, apiGatewayMetrics *metrics.ApiGatewayMetrics) *APIRuleReconciler {
@@ -48,6 +64,7 @@ func NewApiRuleReconciler(mgr manager.Manager, config ApiRuleReconcilerConfigura
 				AllowMethods: getList(config.CorsAllowMethods),
 				AllowOrigins: getStringMatch(config.CorsAllowOrigins),
 			},
+			ConsolidateAuthorizationPolicies: config.ConsolidateAuthorizationPolicies,
 		},
 		Scheme:                   mgr.GetScheme(),
 		Config:                   &helpers.Config{},
@@ -55,6 +72,11 @@ func NewApiRuleReconciler(mgr manager.Manager, config ApiRuleReconcilerConfigura
 		OnErrorReconcilePeriod:   time.Duration(config.ErrorReconciliationPeriod) * time.Second,
 		MigrationReconcilePeriod: time.Duration(config.MigrationReconciliationPeriod) * time.Second,
 		Metrics:                  apiGatewayMetrics,
+		Cache:                    mgr.GetCache(),
+		APIReader:                mgr.GetAPIReader(),
+		Recorder:                 mgr.GetEventRecorder("apirule-controller"),
+		MaxConcurrentReconciles:  config.MaxConcurrentReconciles,
+		WatchScope:               config.WatchScope,
 	}
 }
 
@@ -34,6 +34,7 @@ import (
 	"github.com/kyma-project/api-gateway/internal/conditions"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	oryv1alpha1 "github.com/kyma-project/api-gateway/internal/types/ory/oathkeeper-maester/api/v1alpha1"
 
@@ -42,6 +43,7 @@ import (
 	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
 	"github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/apirulesettings"
 	"github.com/kyma-project/api-gateway/internal/controller"
 	"github.com/kyma-project/api-gateway/internal/dependencies"
 	"github.com/kyma-project/api-gateway/internal/reconciliations/gateway"
@@ -60,6 +62,7 @@ const (
 	//defaultApiGatewayReconciliationInterval = time.Hour * 10
 	// Temporarily reduced the interval to 1 hour to make sure that NLB migration does
 	defaultApiGatewayReconciliationInterval = time.Hour
+	pendingResourceReconciliationInterval   = time.Minute
 )
 
 func NewAPIGatewayReconciler(mgr manager.Manager, oathkeeperReconciler ReadyVerifyingReconciler) *APIGatewayReconciler {
@@ -68,6 +71,7 @@ func NewAPIGatewayReconciler(mgr manager.Manager, oathkeeperReconciler ReadyVeri
 		Scheme:               mgr.GetScheme(),
 		log:                  mgr.GetLogger().WithName("apigateway-controller"),
 		oathkeeperReconciler: oathkeeperReconciler,
+		Recorder:             mgr.GetEventRecorder("apigateway-controller"),
 	}
 }
 
@@ -76,19 +80,22 @@ func NewAPIGatewayReconciler(mgr manager.Manager, oathkeeperReconciler ReadyVeri
 // +kubebuilder:rbac:groups=operator.kyma-project.io,resources=apigateways/finalizers,verbs=update
 // +kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets;deployments;services;serviceaccounts,verbs=get;list;watch;update;patch;create;delete
+// +kubebuilder:rbac:groups="",resources=nodes;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets;configmaps;deployments;services;serviceaccounts,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="oathkeeper.ory.sh",resources=rules,verbs=deletecollection;create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles;rolebindings;clusterroles;clusterrolebindings,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="autoscaling",resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="cert.gardener.cloud",resources=certificates,verbs=get;list;watch;update;patch;create;delete
+// +kubebuilder:rbac:groups="cert-manager.io",resources=certificates,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="dns.gardener.cloud",resources=dnsentries,verbs=get;list;watch;update;patch;create;delete
+// +kubebuilder:rbac:groups="externaldns.k8s.io",resources=dnsendpoints,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;deletecollection;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalercheckpoints,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules;ratelimits;externalgateways,verbs=get;list;watch;delete
 
 func (r *APIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.log.Info("Received reconciliation request", "name", req.Name)
@@ -102,6 +109,9 @@ func (r *APIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		r.log.Info("Could not get APIGateway CR")
 		return ctrl.Result{}, err
 	}
+	// The state is set to Processing during the reconciliation, so only the transition from the previous to the
+	// final state is recorded as an Event.
+	ctx = controller.WithPreviousState(ctx, string(apiGatewayCR.Status.State))
 
 	existingAPIGateways := &operatorv1alpha1.APIGatewayList{}
 	if err := r.List(ctx, existingAPIGateways); err != nil {
@@ -125,8 +135,9 @@ func (r *APIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	networkPoliciesEnabled := apiGatewayCR.Spec.NetworkPoliciesEnabled != nil && *apiGatewayCR.Spec.NetworkPoliciesEnabled
 	r.log.Info("Handling NetworkPolicies if needed", "networkPoliciesEnabled", networkPoliciesEnabled)
+	k8sClient := controller.NewEventRecordingClient(r.Client, r.Recorder, &apiGatewayCR)
 	opPolicy := networkpolicy.OperatorPolicy{
-		Client:  r.Client,
+		Client:  k8sClient,
 		Enabled: networkPoliciesEnabled,
 		Owner:   &apiGatewayCR,
 	}
@@ -142,24 +153,43 @@ func (r *APIGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if !apiGatewayCR.IsInDeletion() {
 		if name, dependenciesErr := dependencies.ApiGateway().AreAvailable(ctx, r.Client); dependenciesErr != nil {
+			if apierrors.IsNotFound(dependenciesErr) {
+				controller.RecordDependencyMissing(r.Recorder, &apiGatewayCR, name)
+			}
 			return r.requeueReconciliation(ctx, apiGatewayCR, handleDependenciesError(name, dependenciesErr))
 		}
 	}
 
 	if finalizerStatus := r.reconcileFinalizer(ctx, &apiGatewayCR); !finalizerStatus.IsReady() {
+		if finalizerStatus.State() == controller.Deleting {
+			return r.requeueDeletion(ctx, apiGatewayCR, finalizerStatus)
+		}
 		return r.requeueReconciliation(ctx, apiGatewayCR, finalizerStatus)
 	}
 
-	if kymaGatewayStatus := gateway.ReconcileKymaGateway(ctx, r.Client, &apiGatewayCR, APIGatewayResourceListDefaultPath); !kymaGatewayStatus.IsReady() {
+	if kymaGatewayStatus := gateway.ReconcileKymaGateway(ctx, k8sClient, &apiGatewayCR, APIGatewayResourceListDefaultPath); !kymaGatewayStatus.IsReady() {
 		return r.requeueReconciliation(ctx, apiGatewayCR, kymaGatewayStatus)
 	}
 
-	if oryOathkeeperStatus := r.oathkeeperReconciler.ReconcileAndVerifyReadiness(ctx, r.Client, &apiGatewayCR); !oryOathkeeperStatus.IsReady() {
+	if gatewaysStatus := gateway.ReconcileManagedGateways(ctx, k8sClient, &apiGatewayCR, APIGatewayResourceListDefaultPath); !gatewaysStatus.IsReady() {
+		return r.requeueReconciliation(ctx, apiGatewayCR, gatewaysStatus)
+	}
+
+	if !apiGatewayCR.IsInDeletion() {
+		if certificateStatus := r.reconcileCertificateCondition(ctx, &apiGatewayCR); !certificateStatus.IsReady() {
+			return r.requeueReconciliation(ctx, apiGatewayCR, certificateStatus)
+		}
+		if dnsEntryStatus := r.reconcileDNSEntryCondition(ctx, &apiGatewayCR); !dnsEntryStatus.IsReady() {
+			return r.requeueReconciliation(ctx, apiGatewayCR, dnsEntryStatus)
+		}
+	}
+
+	if oryOathkeeperStatus := r.oathkeeperReconciler.ReconcileAndVerifyReadiness(ctx, k8sClient, &apiGatewayCR); !oryOathkeeperStatus.IsReady() {
 		return r.requeueReconciliation(ctx, apiGatewayCR, oryOathkeeperStatus)
 	}
 
 	r.log.Info("Reconciling VPA if CRD is available")
-	vpaReconciler := vpa.NewReconciler(r.Client)
+	vpaReconciler := vpa.NewReconciler(k8sClient)
 	if err := vpaReconciler.Reconcile(ctx, apiGatewayCR.IsInDeletion()); err != nil {
 		return r.requeueReconciliation(ctx, apiGatewayCR, controller.ErrorStatus(err, "Error during VPA reconciliation", conditions.ReconcileFailed.Condition()))
 	}
@@ -185,7 +215,7 @@ func handleDependenciesError(name string, err error) controller.Status {
 // SetupWithManager sets up the controller with the Manager.
 func (r *APIGatewayReconciler) SetupWithManager(mgr ctrl.Manager, c controller.RateLimiterConfig) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha1.APIGateway{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&operatorv1alpha1.APIGateway{}, builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, forceCleanupAnnotationChangedPredicate))).
 		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 			if obj.GetNamespace() != "istio-system" {
 				return nil
@@ -236,23 +266,99 @@ func (r *APIGatewayReconciler) requeueReconciliation(ctx context.Context, cr ope
 	statusUpdateErr := controller.UpdateApiGatewayStatus(ctx, r.Client, &cr, status)
 	if statusUpdateErr != nil {
 		r.log.Error(statusUpdateErr, "Update status failed")
+	} else {
+		r.recordStateTransition(ctx, &cr)
 	}
 
 	return ctrl.Result{}, status.NestedError()
 }
 
+// requeueDeletion reports the progress of the deletion of the APIGateway CR and checks it again after a short interval.
+func (r *APIGatewayReconciler) requeueDeletion(ctx context.Context, cr operatorv1alpha1.APIGateway, status controller.Status) (ctrl.Result, error) {
+	if err := controller.UpdateApiGatewayStatus(ctx, r.Client, &cr, status); err != nil {
+		r.log.Error(err, "Update status failed")
+		return ctrl.Result{}, err
+	}
+	r.recordStateTransition(ctx, &cr)
+
+	return ctrl.Result{RequeueAfter: forceCleanupReconciliationInterval}, nil
+}
+
 func (r *APIGatewayReconciler) finishReconcile(ctx context.Context, cr operatorv1alpha1.APIGateway) (ctrl.Result, error) {
-	if err := controller.UpdateApiGatewayStatus(ctx, r.Client, &cr, controller.ReadyStatus(conditions.ReconcileSucceeded.Condition())); err != nil {
+	status := controller.ReadyStatus(conditions.ReconcileSucceeded.Condition())
+	// Invalid APIRule settings don't block the reconciliation of the module, since the APIRule controller applies the
+	// valid settings, but they are reported until they are fixed.
+	if _, err := apirulesettings.FromAPIGateway(&cr); err != nil {
+		r.log.Info("APIGateway CR has invalid APIRule settings", "error", err.Error())
+		status = controller.WarningStatus(err, fmt.Sprintf("Invalid APIRule settings: %s", err),
+			conditions.CustomResourceMisconfigured.AdditionalMessage(": "+err.Error()).Condition())
+	}
+
+	if err := controller.UpdateApiGatewayStatus(ctx, r.Client, &cr, status); err != nil {
 		r.log.Error(err, "Update status failed")
 		return ctrl.Result{}, err
 	}
+	r.recordStateTransition(ctx, &cr)
 
 	r.log.Info("Successfully reconciled")
+	requeueAfter := defaultApiGatewayReconciliationInterval
+	// The readiness of a certificate or DNS record that is still being created is checked more often to report it timely.
+	if meta.IsStatusConditionFalse(cr.Status.Conditions, conditions.CertificateReady) ||
+		meta.IsStatusConditionFalse(cr.Status.Conditions, conditions.DNSEntryReady) {
+		requeueAfter = pendingResourceReconciliationInterval
+	}
 	return ctrl.Result{
-		RequeueAfter: defaultApiGatewayReconciliationInterval,
+		RequeueAfter: requeueAfter,
 	}, nil
 }
 
+// reconcileCertificateCondition reports the readiness of the Kyma Gateway certificate in the CertificateReady condition.
+// It returns a Warning status if the certificate could not be issued.
+func (r *APIGatewayReconciler) reconcileCertificateCondition(ctx context.Context, apiGatewayCR *operatorv1alpha1.APIGateway) controller.Status {
+	condition, err := gateway.KymaGatewayCertificateCondition(ctx, r.Client, *apiGatewayCR)
+	if err != nil {
+		return controller.ErrorStatus(err, "Error during Kyma Gateway certificate readiness check", conditions.KymaGatewayReconcileFailed.Condition())
+	}
+	return r.reconcileKymaGatewayResourceCondition(ctx, apiGatewayCR, conditions.CertificateReady, condition,
+		conditions.CertificateFailed, conditions.KymaGatewayCertificateFailed)
+}
+
+// reconcileDNSEntryCondition reports the readiness of the Kyma Gateway DNS record in the DNSEntryReady condition.
+// It returns a Warning status if the DNS record could not be created.
+func (r *APIGatewayReconciler) reconcileDNSEntryCondition(ctx context.Context, apiGatewayCR *operatorv1alpha1.APIGateway) controller.Status {
+	condition, err := gateway.KymaGatewayDNSEntryCondition(ctx, r.Client, *apiGatewayCR)
+	if err != nil {
+		return controller.ErrorStatus(err, "Error during Kyma Gateway DNS record readiness check", conditions.KymaGatewayReconcileFailed.Condition())
+	}
+	return r.reconcileKymaGatewayResourceCondition(ctx, apiGatewayCR, conditions.DNSEntryReady, condition,
+		conditions.DNSEntryFailed, conditions.KymaGatewayDNSEntryFailed)
+}
+
+// reconcileKymaGatewayResourceCondition sets the condition of the given type that reports the readiness of a resource
+// created for the Kyma Gateway, or removes it if condition is nil. It returns a Warning status with the failedStatus
+// reason if the condition has the failed reason.
+func (r *APIGatewayReconciler) reconcileKymaGatewayResourceCondition(ctx context.Context, apiGatewayCR *operatorv1alpha1.APIGateway,
+	conditionType string, condition *metav1.Condition, failed, failedStatus conditions.ReasonMessage) controller.Status {
+	if condition == nil {
+		if err := controller.RemoveApiGatewayCondition(ctx, r.Client, apiGatewayCR, conditionType); err != nil {
+			return controller.ErrorStatus(err, fmt.Sprintf("Failed to update %s condition", conditionType), conditions.ReconcileFailed.Condition())
+		}
+		return controller.ReadyStatus(conditions.ReconcileSucceeded.Condition())
+	}
+
+	if err := controller.SetApiGatewayCondition(ctx, r.Client, apiGatewayCR, *condition); err != nil {
+		return controller.ErrorStatus(err, fmt.Sprintf("Failed to update %s condition", conditionType), conditions.ReconcileFailed.Condition())
+	}
+
+	if condition.Reason == failed.Condition().Reason {
+		err := fmt.Errorf("%s: %s", strings.ToLower(failedStatus.Condition().Message), condition.Message)
+		// The details are reported in the condition of the resource.
+		return controller.WarningStatus(err, condition.Message, failedStatus.Condition())
+	}
+
+	return controller.ReadyStatus(conditions.ReconcileSucceeded.Condition())
+}
+
 func (r *APIGatewayReconciler) terminateReconciliation(ctx context.Context, apiGatewayCR operatorv1alpha1.APIGateway, status controller.Status) (ctrl.Result, error) {
 	statusUpdateErr := controller.UpdateApiGatewayStatus(ctx, r.Client, &apiGatewayCR, status)
 
@@ -261,11 +367,18 @@ func (r *APIGatewayReconciler) terminateReconciliation(ctx context.Context, apiG
 		// In case the update of the status fails we must requeue the request, because otherwise the Error state is never visible in the CR.
 		return ctrl.Result{}, statusUpdateErr
 	}
+	r.recordStateTransition(ctx, &apiGatewayCR)
 
 	r.log.Error(status.NestedError(), "Reconcile failed, but won't requeue")
 	return ctrl.Result{}, nil
 }
 
+// recordStateTransition records an Event if the state of the APIGateway CR differs from the state at the start of
+// the reconciliation.
+func (r *APIGatewayReconciler) recordStateTransition(ctx context.Context, cr *operatorv1alpha1.APIGateway) {
+	controller.RecordStateTransition(r.Recorder, cr, controller.PreviousState(ctx), string(cr.Status.State), cr.Status.Description)
+}
+
 func (r *APIGatewayReconciler) reconcileFinalizer(ctx context.Context, apiGatewayCR *operatorv1alpha1.APIGateway) controller.Status {
 	if !apiGatewayCR.IsInDeletion() && !hasFinalizer(apiGatewayCR) {
 		controllerutil.AddFinalizer(apiGatewayCR, ApiGatewayFinalizer)
@@ -276,33 +389,50 @@ func (r *APIGatewayReconciler) reconcileFinalizer(ctx context.Context, apiGatewa
 	}
 
 	if apiGatewayCR.IsInDeletion() && hasFinalizer(apiGatewayCR) {
+		if isForceCleanupRequested(apiGatewayCR) {
+			if cleanupStatus := forceCleanup(ctx, r.Client); !cleanupStatus.IsReady() {
+				return cleanupStatus
+			}
+		}
+
 		apiRulesFound, err := apiRulesExist(ctx, r.Client)
 		if err != nil {
 			return controller.ErrorStatus(err, "Error during listing existing APIRules", conditions.ReconcileFailed.Condition())
 		}
-		if len(apiRulesFound) > 0 {
-			return controller.WarningStatus(errors.New("could not delete API-Gateway CR since there are APIRule(s) that block its deletion"),
-				"There are APIRule(s) that block the deletion of API-Gateway CR. Please take a look at kyma-system/api-gateway-controller-manager logs to see more information about the warning",
-				conditions.DeletionBlockedExistingResources.AdditionalMessage(": "+strings.Join(apiRulesFound, ", ")).Condition())
-		}
-
 		oryRulesFound, err := oryRulesExist(ctx, r.Client)
 		if err != nil {
 			return controller.ErrorStatus(err, "Error during listing existing ORY Oathkeeper Rules", conditions.ReconcileFailed.Condition())
 		}
-		if len(oryRulesFound) > 0 {
-			return controller.WarningStatus(errors.New("could not delete API-Gateway CR since there are ORY Oathkeeper Rule(s) that block its deletion"),
-				"There are ORY Oathkeeper Rule(s) that block the deletion of API-Gateway CR. Please take a look at kyma-system/api-gateway-controller-manager logs to see more information about the warning",
-				conditions.DeletionBlockedExistingResources.AdditionalMessage(": "+strings.Join(oryRulesFound, ", ")).Condition())
-		}
 		rateLimiterRules, err := rateLimitsExists(ctx, r.Client)
 		if err != nil {
 			return controller.ErrorStatus(err, "Error during listing existing Rate Limit", conditions.ReconcileFailed.Condition())
 		}
+
+		inventory := deletionInventory{apiRules: apiRulesFound, oryRules: oryRulesFound, rateLimits: rateLimiterRules}
+		if !inventory.isEmpty() {
+			if err := reconcileDeletionInventory(ctx, r.Client, inventory); err != nil {
+				return controller.ErrorStatus(err, "Could not store the resources that block the deletion", conditions.ReconcileFailed.Condition())
+			}
+		}
+
+		if len(apiRulesFound) > 0 {
+			return controller.WarningStatus(errors.New("could not delete API-Gateway CR since there are APIRule(s) that block its deletion"),
+				"There are APIRule(s) that block the deletion of API-Gateway CR. Please take a look at kyma-system/api-gateway-controller-manager logs to see more information about the warning",
+				conditions.DeletionBlockedExistingResources.AdditionalMessage(": "+blockingResourcesMessage(apiRulesFound)).Condition())
+		}
+		if len(oryRulesFound) > 0 {
+			return controller.WarningStatus(errors.New("could not delete API-Gateway CR since there are ORY Oathkeeper Rule(s) that block its deletion"),
+				"There are ORY Oathkeeper Rule(s) that block the deletion of API-Gateway CR. Please take a look at kyma-system/api-gateway-controller-manager logs to see more information about the warning",
+				conditions.DeletionBlockedExistingResources.AdditionalMessage(": "+blockingResourcesMessage(oryRulesFound)).Condition())
+		}
 		if len(rateLimiterRules) > 0 {
 			return controller.WarningStatus(errors.New("could not delete API-Gateway CR since there are RateLimit(s) that block its deletion"),
 				"There are RateLimit(s) that block the deletion of API-Gateway CR. Please take a look at kyma-system/api-gateway-controller-manager logs to see more information about the warning",
-				conditions.DeletionBlockedExistingResources.AdditionalMessage(": "+strings.Join(rateLimiterRules, ", ")).Condition())
+				conditions.DeletionBlockedExistingResources.AdditionalMessage(": "+blockingResourcesMessage(rateLimiterRules)).Condition())
+		}
+
+		if err := deleteDeletionInventory(ctx, r.Client); err != nil {
+			return controller.ErrorStatus(err, "Could not delete the inventory of resources that blocked the deletion", conditions.ReconcileFailed.Condition())
 		}
 		if err := removeFinalizer(ctx, r.Client, apiGatewayCR); err != nil {
 			ctrl.Log.Error(err, "Error happened during API-Gateway CR finalizer removal")
@@ -320,6 +450,9 @@ func rateLimitsExists(ctx context.Context, k8sClient client.Client) ([]string, e
 		// RateLimits does not exist, there is not blocking rate limits
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	ctrl.Log.Info(fmt.Sprintf("There are %d RateLimit(s) found on cluster", len(rateLimits.Items)))
 	var blockingRateLimits []string
@@ -346,9 +479,7 @@ func apiRulesExist(ctx context.Context, k8sClient client.Client) ([]string, erro
 	for _, rule := range apiRuleList.Items {
 		blocking := rule.GetNamespace() + "/" + rule.GetName()
 		ctrl.Log.Info("APIRule blocking deletion", "rule", blocking)
-		if len(blockingApiRules) < 5 {
-			blockingApiRules = append(blockingApiRules, blocking)
-		}
+		blockingApiRules = append(blockingApiRules, blocking)
 	}
 	return blockingApiRules, nil
 }
@@ -369,9 +500,7 @@ func oryRulesExist(ctx context.Context, k8sClient client.Client) ([]string, erro
 	for _, rule := range oryRulesList.Items {
 		blocking := rule.GetNamespace() + "/" + rule.GetName()
 		ctrl.Log.Info("ORY Oathkeeper rule blocking deletion", "rule", blocking)
-		if len(blockingOryRules) < 5 {
-			blockingOryRules = append(blockingOryRules, blocking)
-		}
+		blockingOryRules = append(blockingOryRules, blocking)
 	}
 	return blockingOryRules, nil
 }
@@ -10,9 +10,12 @@ import (
 	"github.com/kyma-project/api-gateway/internal/conditions"
 
 	"github.com/go-logr/logr"
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
 	oryv1alpha1 "github.com/kyma-project/api-gateway/internal/types/ory/oathkeeper-maester/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -149,6 +152,45 @@ var _ = Describe("API-Gateway Controller", func() {
 			})))
 		})
 
+		It("Should set status to Warning and add condition when the APIRule settings are invalid", func() {
+			// given
+			apiGatewayCR := &operatorv1alpha1.APIGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       apiGatewayCRName,
+					Namespace:  testNamespace,
+					Finalizers: []string{ApiGatewayFinalizer},
+				},
+				Spec: operatorv1alpha1.APIGatewaySpec{
+					APIRules: &operatorv1alpha1.APIRulesConfig{DisabledValidationChecks: []string{"unknown"}},
+				},
+			}
+
+			c := createFakeClient(apiGatewayCR)
+			agr := &APIGatewayReconciler{
+				Client:               c,
+				Scheme:               getTestScheme(),
+				log:                  logr.Discard(),
+				oathkeeperReconciler: oathkeeperReconcilerWithoutVerification{},
+			}
+
+			// when
+			result, err := agr.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: apiGatewayCRName}})
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(Equal(reconcile.Result{
+				RequeueAfter: defaultApiGatewayReconciliationInterval,
+			}))
+
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(apiGatewayCR), apiGatewayCR)).Should(Succeed())
+			Expect(apiGatewayCR.Status.State).Should(Equal(operatorv1alpha1.Warning))
+			Expect(apiGatewayCR.Status.Description).To(ContainSubstring("apiRules.disabledValidationChecks[0]"))
+			Expect(apiGatewayCR.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Reason": Equal(conditions.CustomResourceMisconfigured.Condition().Reason),
+				"Status": Equal(metav1.ConditionFalse),
+			})))
+		})
+
 		It("Should set status Ready on the older APIGateway CR when there are two in the cluster", func() {
 			// given
 			apiGatewayCR := &operatorv1alpha1.APIGateway{
@@ -348,9 +390,15 @@ var _ = Describe("API-Gateway Controller", func() {
 
 			Expect(apiGatewayCR.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
 				"Type":    Equal(conditions.DeletionBlockedExistingResources.Condition().Type),
-				"Message": Equal("API Gateway deletion blocked because of the existing custom resources: default/api-rule-0, default/api-rule-1, default/api-rule-2, default/api-rule-3, default/api-rule-4"),
+				"Message": Equal("API Gateway deletion blocked because of the existing custom resources: default/api-rule-0, default/api-rule-1, default/api-rule-2, default/api-rule-3, default/api-rule-4 and 1 more, see ConfigMap kyma-system/api-gateway-deletion-inventory for the full list"),
 				"Status":  Equal(metav1.ConditionFalse),
 			})))
+
+			inventory := corev1.ConfigMap{}
+			Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: DeletionInventoryConfigMapName}, &inventory)).Should(Succeed())
+			Expect(inventory.Data).To(HaveKeyWithValue("apiRules", "default/api-rule-0\ndefault/api-rule-1\ndefault/api-rule-2\ndefault/api-rule-3\ndefault/api-rule-4\ndefault/api-rule-5"))
+			Expect(inventory.Data).To(HaveKeyWithValue("oryRules", ""))
+			Expect(inventory.Data).To(HaveKeyWithValue("rateLimits", ""))
 		})
 
 		It("Should not delete API-Gateway CR if there are any ORY Oathkeeper Rules on cluster", func() {
@@ -399,10 +447,98 @@ var _ = Describe("API-Gateway Controller", func() {
 
 			Expect(apiGatewayCR.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
 				"Type":    Equal(conditions.DeletionBlockedExistingResources.Condition().Type),
-				"Message": Equal("API Gateway deletion blocked because of the existing custom resources: default/ory-rule-0, default/ory-rule-1, default/ory-rule-2, default/ory-rule-3, default/ory-rule-4"),
+				"Message": Equal("API Gateway deletion blocked because of the existing custom resources: default/ory-rule-0, default/ory-rule-1, default/ory-rule-2, default/ory-rule-3, default/ory-rule-4 and 1 more, see ConfigMap kyma-system/api-gateway-deletion-inventory for the full list"),
+				"Status":  Equal(metav1.ConditionFalse),
+			})))
+		})
+
+		It("Should delete RateLimits first when force cleanup is requested", func() {
+			// given
+			now := metav1.NewTime(time.Now())
+			apiGatewayCR := &operatorv1alpha1.APIGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              apiGatewayCRName,
+					Namespace:         testNamespace,
+					DeletionTimestamp: &now,
+					Finalizers:        []string{ApiGatewayFinalizer},
+					Annotations:       map[string]string{ForceCleanupAnnotation: "true"},
+				},
+			}
+			apiRule := &gatewayv1beta1.APIRule{ObjectMeta: metav1.ObjectMeta{Name: "api-rule", Namespace: "default"}}
+			rateLimit := &ratelimitv1alpha1.RateLimit{ObjectMeta: metav1.ObjectMeta{Name: "rate-limit", Namespace: "default"}}
+
+			c := createFakeClient(apiGatewayCR, apiRule, rateLimit)
+			agr := &APIGatewayReconciler{
+				Client:               c,
+				Scheme:               getTestScheme(),
+				log:                  logr.Discard(),
+				oathkeeperReconciler: oathkeeperReconcilerWithoutVerification{},
+			}
+
+			// when
+			result, err := agr.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: apiGatewayCRName}})
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(Equal(reconcile.Result{RequeueAfter: forceCleanupReconciliationInterval}))
+
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(rateLimit), rateLimit))).To(BeTrue())
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(apiRule), apiRule)).Should(Succeed())
+
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(apiGatewayCR), apiGatewayCR)).Should(Succeed())
+			Expect(apiGatewayCR.Status.State).To(Equal(operatorv1alpha1.Deleting))
+			Expect(apiGatewayCR.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Reason":  Equal(conditions.DeletionForceCleanupInProgress.Condition().Reason),
+				"Message": Equal("API Gateway deletion is deleting the existing custom resources: deleting 1 RateLimit(s)"),
 				"Status":  Equal(metav1.ConditionFalse),
 			})))
 		})
+
+		It("Should delete API-Gateway CR after force cleanup deleted all blocking resources", func() {
+			// given
+			now := metav1.NewTime(time.Now())
+			apiGatewayCR := &operatorv1alpha1.APIGateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              apiGatewayCRName,
+					Namespace:         testNamespace,
+					DeletionTimestamp: &now,
+					Finalizers:        []string{ApiGatewayFinalizer},
+					Annotations:       map[string]string{ForceCleanupAnnotation: "true"},
+				},
+			}
+			apiRule := &gatewayv1beta1.APIRule{ObjectMeta: metav1.ObjectMeta{Name: "api-rule", Namespace: "default"}}
+			oryRule := &oryv1alpha1.Rule{ObjectMeta: metav1.ObjectMeta{Name: "ory-rule", Namespace: "default"}}
+			externalGateway := &externalv1alpha1.ExternalGateway{ObjectMeta: metav1.ObjectMeta{Name: "external-gateway", Namespace: "default"}}
+			inventory := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: DeletionInventoryConfigMapName, Namespace: "kyma-system"}}
+
+			c := createFakeClient(apiGatewayCR, apiRule, oryRule, externalGateway, inventory)
+			agr := &APIGatewayReconciler{
+				Client:               c,
+				Scheme:               getTestScheme(),
+				log:                  logr.Discard(),
+				oathkeeperReconciler: oathkeeperReconcilerWithoutVerification{},
+			}
+
+			// when
+			for _, kind := range []string{"APIRule", "ORY Oathkeeper Rule", "ExternalGateway"} {
+				result, err := agr.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: apiGatewayCRName}})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).Should(Equal(reconcile.Result{RequeueAfter: forceCleanupReconciliationInterval}))
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(apiGatewayCR), apiGatewayCR)).Should(Succeed())
+				Expect(apiGatewayCR.Status.Description).To(Equal(fmt.Sprintf("Force cleanup is deleting 1 %s(s)", kind)))
+			}
+			result, err := agr.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: apiGatewayCRName}})
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result).Should(Equal(reconcile.Result{}))
+
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(apiRule), apiRule))).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(oryRule), oryRule))).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(externalGateway), externalGateway))).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(inventory), inventory))).To(BeTrue())
+			Expect(errors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(apiGatewayCR), apiGatewayCR))).To(BeTrue())
+		})
 	})
 })
 
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
 	"github.com/kyma-project/api-gateway/internal/controller"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -15,6 +16,7 @@ type APIGatewayReconciler struct {
 	Scheme               *runtime.Scheme
 	log                  logr.Logger
 	oathkeeperReconciler ReadyVerifyingReconciler
+	Recorder             events.EventRecorder
 }
 
 type ReadyVerifyingReconciler interface {
@@ -0,0 +1,191 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
+	"github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	oryv1alpha1 "github.com/kyma-project/api-gateway/internal/types/ory/oathkeeper-maester/api/v1alpha1"
+)
+
+const (
+	// ForceCleanupAnnotation is the APIGateway CR annotation that enables ("true") the deletion of all custom resources
+	// that block the deletion of the APIGateway CR.
+	ForceCleanupAnnotation = "apigateways.operator.kyma-project.io/force-cleanup"
+
+	// DeletionInventoryConfigMapName is the name of the ConfigMap in the kyma-system namespace that lists all custom
+	// resources that block the deletion of the APIGateway CR.
+	DeletionInventoryConfigMapName      = "api-gateway-deletion-inventory"
+	deletionInventoryConfigMapNamespace = "kyma-system"
+
+	// maxBlockingResourcesInCondition is the number of blocking resources that are listed in the condition of the
+	// APIGateway CR. All blocking resources are listed in the deletion inventory ConfigMap.
+	maxBlockingResourcesInCondition    = 5
+	forceCleanupReconciliationInterval = time.Second * 10
+)
+
+// forceCleanupAnnotationChangedPredicate triggers the reconciliation if the force cleanup annotation is changed, e.g.
+// when it is set on an APIGateway CR whose deletion is blocked.
+var forceCleanupAnnotationChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[ForceCleanupAnnotation] != e.ObjectNew.GetAnnotations()[ForceCleanupAnnotation]
+	},
+}
+
+// deletionInventory lists the namespaced names of the custom resources that block the deletion of the APIGateway CR.
+type deletionInventory struct {
+	apiRules   []string
+	oryRules   []string
+	rateLimits []string
+}
+
+func (i deletionInventory) isEmpty() bool {
+	return len(i.apiRules) == 0 && len(i.oryRules) == 0 && len(i.rateLimits) == 0
+}
+
+func isForceCleanupRequested(apiGatewayCR *operatorv1alpha1.APIGateway) bool {
+	enabled, err := strconv.ParseBool(apiGatewayCR.GetAnnotations()[ForceCleanupAnnotation])
+	return err == nil && enabled
+}
+
+// forceCleanupStep deletes all custom resources of a kind during the force cleanup.
+type forceCleanupStep struct {
+	kind string
+	list func() client.ObjectList
+}
+
+// forceCleanupSteps are executed in order. RateLimits are deleted first, since they only configure the workloads, and
+// APIRules are deleted before the remaining Ory Oathkeeper Rules, since APIRules delete their own Rules. ExternalGateways
+// are deleted last, because APIRules might reference them.
+var forceCleanupSteps = []forceCleanupStep{
+	{kind: "RateLimit", list: func() client.ObjectList { return &ratelimitv1alpha1.RateLimitList{} }},
+	{kind: "APIRule", list: func() client.ObjectList { return &v1beta1.APIRuleList{} }},
+	{kind: "ORY Oathkeeper Rule", list: func() client.ObjectList { return &oryv1alpha1.RuleList{} }},
+	{kind: "ExternalGateway", list: func() client.ObjectList { return &externalv1alpha1.ExternalGatewayList{} }},
+}
+
+// forceCleanup deletes the custom resources that block the deletion of the APIGateway CR and the ExternalGateways. The
+// resources of a step are only deleted once all resources of the previous step are gone, so their controllers can
+// remove the resources depending on them. A Deleting status is returned as long as resources are left.
+func forceCleanup(ctx context.Context, k8sClient client.Client) controller.Status {
+	for _, step := range forceCleanupSteps {
+		remaining, err := deleteAllOfKind(ctx, k8sClient, step.list())
+		if err != nil {
+			return controller.ErrorStatus(err, fmt.Sprintf("Error during force cleanup of %s(s)", step.kind), conditions.ReconcileFailed.Condition())
+		}
+		if remaining > 0 {
+			ctrl.Log.Info("Force cleanup is waiting for the deletion of resources", "kind", step.kind, "remaining", remaining)
+			message := fmt.Sprintf("deleting %d %s(s)", remaining, step.kind)
+			return controller.DeletingStatus(fmt.Sprintf("Force cleanup is %s", message),
+				conditions.DeletionForceCleanupInProgress.AdditionalMessage(": "+message).Condition())
+		}
+	}
+
+	return controller.ReadyStatus(conditions.ReconcileSucceeded.Condition())
+}
+
+// deleteAllOfKind deletes all resources of the list kind that are not yet being deleted and returns the number of
+// resources that still exist. Kinds whose CRD is not installed are skipped.
+func deleteAllOfKind(ctx context.Context, k8sClient client.Client, list client.ObjectList) (int, error) {
+	err := k8sClient.List(ctx, list)
+	if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return 0, fmt.Errorf("%T is not a client object", item)
+		}
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		ctrl.Log.Info("Force cleanup is deleting resource", "kind", fmt.Sprintf("%T", obj), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		if err := k8sClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+// blockingResourcesMessage lists the first blocking resources for the condition of the APIGateway CR and refers to the
+// deletion inventory ConfigMap for the full list.
+func blockingResourcesMessage(resources []string) string {
+	if len(resources) <= maxBlockingResourcesInCondition {
+		return strings.Join(resources, ", ")
+	}
+	return fmt.Sprintf("%s and %d more, see ConfigMap %s/%s for the full list",
+		strings.Join(resources[:maxBlockingResourcesInCondition], ", "), len(resources)-maxBlockingResourcesInCondition,
+		deletionInventoryConfigMapNamespace, DeletionInventoryConfigMapName)
+}
+
+// reconcileDeletionInventory stores all resources that block the deletion of the APIGateway CR in the deletion
+// inventory ConfigMap.
+func reconcileDeletionInventory(ctx context.Context, k8sClient client.Client, inventory deletionInventory) error {
+	ctrl.Log.Info("Reconciling deletion inventory ConfigMap", "name", DeletionInventoryConfigMapName, "namespace", deletionInventoryConfigMapNamespace)
+	configMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeletionInventoryConfigMapName,
+			Namespace: deletionInventoryConfigMapNamespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, k8sClient, &configMap, func() error {
+		if configMap.Labels == nil {
+			configMap.Labels = map[string]string{}
+		}
+		configMap.Labels["kyma-project.io/module"] = "api-gateway"
+		configMap.Data = map[string]string{
+			"apiRules":   inventoryList(inventory.apiRules),
+			"oryRules":   inventoryList(inventory.oryRules),
+			"rateLimits": inventoryList(inventory.rateLimits),
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update ConfigMap %s/%s: %w", deletionInventoryConfigMapNamespace, DeletionInventoryConfigMapName, err)
+	}
+	return nil
+}
+
+func inventoryList(resources []string) string {
+	return strings.Join(slices.Sorted(slices.Values(resources)), "\n")
+}
+
+func deleteDeletionInventory(ctx context.Context, k8sClient client.Client) error {
+	configMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeletionInventoryConfigMapName,
+			Namespace: deletionInventoryConfigMapNamespace,
+		},
+	}
+	if err := k8sClient.Delete(ctx, &configMap); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete ConfigMap %s/%s: %w", deletionInventoryConfigMapNamespace, DeletionInventoryConfigMapName, err)
+	}
+	return nil
+}
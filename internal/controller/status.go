@@ -70,10 +70,11 @@ func ReadyStatus(condition *metav1.Condition) Status {
 	}
 }
 
-func DeletingStatus(condition *metav1.Condition) Status {
+func DeletingStatus(description string, condition *metav1.Condition) Status {
 	return status{
-		state:     Deleting,
-		condition: condition,
+		description: description,
+		state:       Deleting,
+		condition:   condition,
 	}
 }
 
@@ -200,6 +201,12 @@ func UpdateApiGatewayStatus(ctx context.Context, k8sClient client.Client, apiGat
 			return getErr
 		}
 
+		// Only the Ready condition is owned by the status, so the other conditions are kept.
+		for _, condition := range apiGatewayCR.Status.Conditions {
+			if meta.FindStatusCondition(newStatus.Conditions, condition.Type) == nil {
+				newStatus.Conditions = append(newStatus.Conditions, condition)
+			}
+		}
 		apiGatewayCR.Status = newStatus
 		if updateErr := k8sClient.Status().Update(ctx, apiGatewayCR); updateErr != nil {
 			return updateErr
@@ -209,6 +216,34 @@ func UpdateApiGatewayStatus(ctx context.Context, k8sClient client.Client, apiGat
 	})
 }
 
+// SetApiGatewayCondition sets the condition in the status of the APIGateway CR without changing its state.
+func SetApiGatewayCondition(ctx context.Context, k8sClient client.Client, apiGatewayCR *operatorv1alpha1.APIGateway, condition metav1.Condition) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := k8sClient.Get(ctx, client.ObjectKeyFromObject(apiGatewayCR), apiGatewayCR); getErr != nil {
+			return getErr
+		}
+
+		if !meta.SetStatusCondition(&apiGatewayCR.Status.Conditions, condition) {
+			return nil
+		}
+		return k8sClient.Status().Update(ctx, apiGatewayCR)
+	})
+}
+
+// RemoveApiGatewayCondition removes the condition of the given type from the status of the APIGateway CR.
+func RemoveApiGatewayCondition(ctx context.Context, k8sClient client.Client, apiGatewayCR *operatorv1alpha1.APIGateway, conditionType string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if getErr := k8sClient.Get(ctx, client.ObjectKeyFromObject(apiGatewayCR), apiGatewayCR); getErr != nil {
+			return getErr
+		}
+
+		if !meta.RemoveStatusCondition(&apiGatewayCR.Status.Conditions, conditionType) {
+			return nil
+		}
+		return k8sClient.Status().Update(ctx, apiGatewayCR)
+	})
+}
+
 func (s status) Condition() *metav1.Condition {
 	return s.condition
 }
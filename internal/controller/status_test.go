@@ -6,6 +6,7 @@ import (
 	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"reflect"
@@ -69,6 +70,46 @@ var _ = Describe("status", func() {
 			Expect(result).ToNot(BeNil())
 			Expect(ok).To(BeTrue())
 		})
+
+		It("Should keep conditions of other types than the Ready condition", func() {
+			// given
+			cr := operatorv1alpha1.APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			}
+			k8sClient := createFakeClient(&cr)
+			Expect(SetApiGatewayCondition(context.Background(), k8sClient, &cr, metav1.Condition{Type: "CertificateReady", Status: "True", Reason: "CertificateIssued"})).To(Succeed())
+
+			// when
+			err := UpdateApiGatewayStatus(context.Background(), k8sClient, &cr, ReadyStatus(&metav1.Condition{Type: "Ready", Status: "True", Reason: "ReconcileSucceeded"}))
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: "test"}, &cr)).To(Succeed())
+			Expect(cr.Status.Conditions).To(HaveLen(2))
+			Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, "CertificateReady")).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, "Ready")).To(BeTrue())
+		})
+	})
+
+	Context("RemoveApiGatewayCondition", func() {
+		It("Should remove the condition of the given type", func() {
+			// given
+			cr := operatorv1alpha1.APIGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			}
+			k8sClient := createFakeClient(&cr)
+			Expect(UpdateApiGatewayStatus(context.Background(), k8sClient, &cr, ReadyStatus(&metav1.Condition{Type: "Ready", Status: "True", Reason: "ReconcileSucceeded"}))).To(Succeed())
+			Expect(SetApiGatewayCondition(context.Background(), k8sClient, &cr, metav1.Condition{Type: "CertificateReady", Status: "True", Reason: "CertificateIssued"})).To(Succeed())
+
+			// when
+			err := RemoveApiGatewayCondition(context.Background(), k8sClient, &cr, "CertificateReady")
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: "test"}, &cr)).To(Succeed())
+			Expect(cr.Status.Conditions).To(HaveLen(1))
+			Expect(cr.Status.Conditions[0].Type).To(Equal("Ready"))
+		})
 	})
 
 	Context("ToAPIGatewayStatus", func() {
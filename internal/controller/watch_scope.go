@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+// operatorNamespace is the namespace of the NetworkPolicy that is managed by the APIGateway operator.
+const operatorNamespace = "kyma-system"
+
+// WatchScope restricts the APIRules, RateLimits and ExternalGateways that are watched and reconciled by the operator
+// to a list of namespaces and a label selector. This allows running separate operator instances for groups of tenants,
+// each caching only the resources of its tenants. AuthorizationPolicies, RequestAuthentications and NetworkPolicies
+// are restricted to the subresources of APIRules in the namespaces, see SubresourceCacheByObject. Other resources,
+// e.g. the VirtualServices validated for host conflicts, are still watched in all namespaces, so requests enqueued for
+// them must be checked with Contains or ContainsNamespace.
+type WatchScope struct {
+	// Namespaces are the namespaces that are watched. All namespaces are watched if empty.
+	Namespaces []string
+	// LabelSelector selects the watched resources. All resources are watched if nil.
+	LabelSelector labels.Selector
+}
+
+// ParseWatchScope parses the comma separated list of namespaces and the label selector of the watch scope.
+func ParseWatchScope(namespaces, labelSelector string) (WatchScope, error) {
+	var scope WatchScope
+	for _, ns := range strings.Split(namespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return WatchScope{}, fmt.Errorf("invalid namespace %q: %s", ns, strings.Join(errs, ", "))
+			}
+			scope.Namespaces = append(scope.Namespaces, ns)
+		}
+	}
+
+	if strings.TrimSpace(labelSelector) != "" {
+		selector, err := labels.Parse(labelSelector)
+		if err != nil {
+			return WatchScope{}, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+		}
+		scope.LabelSelector = selector
+	}
+
+	return scope, nil
+}
+
+// IsRestricted returns whether the scope restricts the watched resources.
+func (s WatchScope) IsRestricted() bool {
+	return len(s.Namespaces) > 0 || s.LabelSelector != nil
+}
+
+// ContainsNamespace returns whether resources in the namespace can be in the scope.
+func (s WatchScope) ContainsNamespace(namespace string) bool {
+	return len(s.Namespaces) == 0 || slices.Contains(s.Namespaces, namespace)
+}
+
+// Contains returns whether the object is in the namespaces of the scope and its labels match the label selector.
+func (s WatchScope) Contains(obj client.Object) bool {
+	if !s.ContainsNamespace(obj.GetNamespace()) {
+		return false
+	}
+	return s.LabelSelector == nil || s.LabelSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// CacheByObject returns the cache options that restrict the watches of the scoped resources. It returns nil if the
+// scope is not restricted.
+func (s WatchScope) CacheByObject() map[client.Object]cache.ByObject {
+	if !s.IsRestricted() {
+		return nil
+	}
+
+	byObject := cache.ByObject{Label: s.LabelSelector}
+	if len(s.Namespaces) > 0 {
+		byObject.Namespaces = make(map[string]cache.Config, len(s.Namespaces))
+		for _, ns := range s.Namespaces {
+			byObject.Namespaces[ns] = cache.Config{}
+		}
+	}
+
+	return map[client.Object]cache.ByObject{
+		&gatewayv1beta1.APIRule{}:           byObject,
+		&gatewayv2alpha1.APIRule{}:          byObject,
+		&gatewayv2.APIRule{}:                byObject,
+		&ratelimitv1alpha1.RateLimit{}:      byObject,
+		&externalv1alpha1.ExternalGateway{}: byObject,
+	}
+}
+
+// SubresourceCacheByObject returns the cache options that restrict the watches of the AuthorizationPolicies,
+// RequestAuthentications and NetworkPolicies to the subresources created by the module. If the scope has namespaces,
+// only subresources of APIRules in these namespaces are cached. Subresources are created in the namespaces of the
+// exposed workloads, so they are selected by the owner namespace label instead of their own namespace. The label
+// selector of the scope isn't applied, because subresources don't have the labels of their APIRule.
+// NetworkPolicies in the operator namespace are cached regardless of their labels, because the NetworkPolicy of the
+// APIGateway operator doesn't have the module label. VirtualServices aren't restricted, because all VirtualServices
+// are validated for host conflicts.
+func (s WatchScope) SubresourceCacheByObject() map[client.Object]cache.ByObject {
+	selector := labels.SelectorFromSet(labels.Set{processing.ModuleLabelKey: processing.ApiGatewayLabelValue})
+	if len(s.Namespaces) > 0 {
+		// ParseWatchScope only accepts valid namespace names, which are valid label values.
+		ownerNamespace, err := labels.NewRequirement(processing.OwnerLabelNamespace, selection.In, s.Namespaces)
+		if err == nil {
+			selector = selector.Add(*ownerNamespace)
+		}
+	}
+
+	return map[client.Object]cache.ByObject{
+		&securityv1beta1.AuthorizationPolicy{}:   {Label: selector},
+		&securityv1beta1.RequestAuthentication{}: {Label: selector},
+		&networkingv1.NetworkPolicy{}: {
+			Namespaces: map[string]cache.Config{
+				cache.AllNamespaces: {LabelSelector: selector},
+				operatorNamespace:   {LabelSelector: labels.Everything()},
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+var _ = Describe("ParseWatchScope", func() {
+	It("should not restrict the scope if no namespaces and label selector are given", func() {
+		scope, err := ParseWatchScope("", " ")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scope.IsRestricted()).To(BeFalse())
+		Expect(scope.CacheByObject()).To(BeNil())
+	})
+
+	It("should parse the namespaces and the label selector", func() {
+		scope, err := ParseWatchScope("tenant-a, tenant-b,,", "tenant-group in (a,b)")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scope.Namespaces).To(Equal([]string{"tenant-a", "tenant-b"}))
+		Expect(scope.LabelSelector.Matches(labels.Set{"tenant-group": "a"})).To(BeTrue())
+		Expect(scope.LabelSelector.Matches(labels.Set{"tenant-group": "c"})).To(BeFalse())
+	})
+
+	It("should return an error for an invalid namespace", func() {
+		_, err := ParseWatchScope("tenant-a,Tenant_B", "")
+
+		Expect(err).To(MatchError(ContainSubstring(`invalid namespace "Tenant_B"`)))
+	})
+
+	It("should return an error for an invalid label selector", func() {
+		_, err := ParseWatchScope("", "tenant-group in (a")
+
+		Expect(err).To(MatchError(ContainSubstring("invalid label selector")))
+	})
+
+	It("should restrict the cache of the scoped resources to the namespaces and label selector", func() {
+		scope, err := ParseWatchScope("tenant-a,tenant-b", "tenant-group=a")
+		Expect(err).ToNot(HaveOccurred())
+
+		byObject := scope.CacheByObject()
+
+		Expect(byObject).To(HaveLen(5))
+		for obj, options := range byObject {
+			Expect(options.Namespaces).To(Equal(map[string]cache.Config{"tenant-a": {}, "tenant-b": {}}), "%T", obj)
+			Expect(options.Label.String()).To(Equal("tenant-group=a"), "%T", obj)
+		}
+	})
+
+	It("should only restrict the label selector if no namespaces are given", func() {
+		scope, err := ParseWatchScope("", "tenant-group=a")
+		Expect(err).ToNot(HaveOccurred())
+
+		for obj, options := range scope.CacheByObject() {
+			if _, ok := obj.(*gatewayv2alpha1.APIRule); ok {
+				Expect(options.Namespaces).To(BeNil())
+				Expect(options.Label.String()).To(Equal("tenant-group=a"))
+			}
+		}
+	})
+})
+
+var _ = Describe("WatchScope", func() {
+	newAPIRule := func(namespace string, labels map[string]string) *gatewayv2alpha1.APIRule {
+		return &gatewayv2alpha1.APIRule{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: namespace, Labels: labels}}
+	}
+
+	It("should contain all objects if the scope is not restricted", func() {
+		scope := WatchScope{}
+
+		Expect(scope.ContainsNamespace("tenant-c")).To(BeTrue())
+		Expect(scope.Contains(newAPIRule("tenant-c", nil))).To(BeTrue())
+	})
+
+	It("should only contain objects in the namespaces that match the label selector", func() {
+		scope, err := ParseWatchScope("tenant-a,tenant-b", "tenant-group=a")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(scope.ContainsNamespace("tenant-b")).To(BeTrue())
+		Expect(scope.ContainsNamespace("tenant-c")).To(BeFalse())
+		Expect(scope.Contains(newAPIRule("tenant-a", map[string]string{"tenant-group": "a"}))).To(BeTrue())
+		Expect(scope.Contains(newAPIRule("tenant-a", map[string]string{"tenant-group": "b"}))).To(BeFalse())
+		Expect(scope.Contains(newAPIRule("tenant-a", nil))).To(BeFalse())
+		Expect(scope.Contains(newAPIRule("tenant-c", map[string]string{"tenant-group": "a"}))).To(BeFalse())
+	})
+	It("should restrict the cache of the subresources to the objects created by the module", func() {
+		byObject := WatchScope{}.SubresourceCacheByObject()
+
+		Expect(byObject).To(HaveLen(3))
+		for obj, options := range byObject {
+			if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
+				Expect(options.Namespaces).To(HaveLen(2))
+				Expect(options.Namespaces[cache.AllNamespaces].LabelSelector.String()).To(Equal("kyma-project.io/module=api-gateway"))
+				Expect(options.Namespaces["kyma-system"].LabelSelector.Empty()).To(BeTrue())
+				continue
+			}
+			Expect(obj).To(Or(BeAssignableToTypeOf(&securityv1beta1.AuthorizationPolicy{}), BeAssignableToTypeOf(&securityv1beta1.RequestAuthentication{})))
+			Expect(options.Namespaces).To(BeNil())
+			Expect(options.Label.String()).To(Equal("kyma-project.io/module=api-gateway"))
+		}
+	})
+	It("should restrict the cache of the subresources to the APIRules in the namespaces of the scope", func() {
+		scope, err := ParseWatchScope("tenant-a,tenant-b", "tenant-group=a")
+		Expect(err).ToNot(HaveOccurred())
+
+		byObject := scope.SubresourceCacheByObject()
+
+		selector := "apirule.gateway.kyma-project.io/namespace in (tenant-a,tenant-b),kyma-project.io/module=api-gateway"
+		for obj, options := range byObject {
+			if _, ok := obj.(*networkingv1.NetworkPolicy); ok {
+				Expect(options.Namespaces[cache.AllNamespaces].LabelSelector.String()).To(Equal(selector))
+				continue
+			}
+			Expect(options.Label.String()).To(Equal(selector), "%T", obj)
+		}
+	})
+})
@@ -29,6 +29,22 @@ func Gardener() Dependencies {
 	}
 }
 
+func CertManager() Dependencies {
+	return &dependencies{
+		CRDNames: []string{
+			"certificates.cert-manager.io",
+		},
+	}
+}
+
+func ExternalDNS() Dependencies {
+	return &dependencies{
+		CRDNames: []string{
+			"dnsendpoints.externaldns.k8s.io",
+		},
+	}
+}
+
 func RateLimit() Dependencies {
 	return &dependencies{
 		CRDNames: []string{
@@ -129,4 +129,42 @@ var _ = Describe("Check", func() {
 			Expect(name).To(BeEmpty())
 		})
 	})
+
+	Context("APIGateway cert-manager dependencies", func() {
+		It("Should fail if required CRDs are missing", func() {
+			k8sClient := createFakeClient()
+			name, err := dependencies.CertManager().AreAvailable(context.Background(), k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(name).To(Equal("certificates.cert-manager.io"))
+		})
+
+		It("Should not fail if required CRDs are present", func() {
+			k8sClient := createFakeClient()
+			crd := v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"}}
+			Expect(k8sClient.Create(context.Background(), &crd)).To(Succeed())
+
+			name, err := dependencies.CertManager().AreAvailable(context.Background(), k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(BeEmpty())
+		})
+	})
+
+	Context("APIGateway external-dns dependencies", func() {
+		It("Should fail if required CRDs are missing", func() {
+			k8sClient := createFakeClient()
+			name, err := dependencies.ExternalDNS().AreAvailable(context.Background(), k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(name).To(Equal("dnsendpoints.externaldns.k8s.io"))
+		})
+
+		It("Should not fail if required CRDs are present", func() {
+			k8sClient := createFakeClient()
+			crd := v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "dnsendpoints.externaldns.k8s.io"}}
+			Expect(k8sClient.Create(context.Background(), &crd)).To(Succeed())
+
+			name, err := dependencies.ExternalDNS().AreAvailable(context.Background(), k8sClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(BeEmpty())
+		})
+	})
 })
@@ -1,6 +1,10 @@
 package metrics
 
 import (
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -8,20 +12,107 @@ import (
 
 type ApiGatewayMetrics struct {
 	apiRuleObjectModifiedErrorsCounter prometheus.Counter
+	orphanedSubresourcesGauge          *prometheus.GaugeVec
+	orphanedSubresourcesDeletedCounter *prometheus.CounterVec
+	apiRuleReconcilePhaseDuration      *prometheus.HistogramVec
+	apiRuleSubresourceChangesCounter   *prometheus.CounterVec
+	apiRuleValidationFailuresCounter   *prometheus.CounterVec
 }
 
 func NewApiGatewayMetrics() *ApiGatewayMetrics {
-	apiGatewayMetrics := &ApiGatewayMetrics{
+	apiGatewayMetrics := newApiGatewayMetrics()
+	ctrlmetrics.Registry.MustRegister(
+		apiGatewayMetrics.apiRuleObjectModifiedErrorsCounter,
+		apiGatewayMetrics.orphanedSubresourcesGauge,
+		apiGatewayMetrics.orphanedSubresourcesDeletedCounter,
+		apiGatewayMetrics.apiRuleReconcilePhaseDuration,
+		apiGatewayMetrics.apiRuleSubresourceChangesCounter,
+		apiGatewayMetrics.apiRuleValidationFailuresCounter,
+	)
+	return apiGatewayMetrics
+}
+
+// newApiGatewayMetrics creates the metrics without registering them.
+func newApiGatewayMetrics() *ApiGatewayMetrics {
+	return &ApiGatewayMetrics{
 		apiRuleObjectModifiedErrorsCounter: prometheus.NewCounter(prometheus.CounterOpts{
 			Name:      "api_rule_object_modified_errors_total",
 			Namespace: "api_gateway",
 			Help:      "The total number of errors that occurred while modifying the APIRule object",
 		}),
+		orphanedSubresourcesGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:      "orphaned_subresources",
+			Namespace: "api_gateway",
+			Help:      "The number of subresources without an existing owning APIRule found in the last janitor run",
+		}, []string{"kind"}),
+		orphanedSubresourcesDeletedCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "orphaned_subresources_deleted_total",
+			Namespace: "api_gateway",
+			Help:      "The total number of subresources deleted by the janitor because the owning APIRule did not exist",
+		}, []string{"kind"}),
+		apiRuleReconcilePhaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:      "apirule_reconcile_phase_duration_seconds",
+			Namespace: "api_gateway",
+			Help:      "The duration of the phases of the APIRule reconciliation. The phase is validation, status_update or the processor",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"phase"}),
+		apiRuleSubresourceChangesCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "apirule_subresource_changes_total",
+			Namespace: "api_gateway",
+			Help:      "The total number of changes of APIRule subresources applied to the cluster, by kind, action and result",
+		}, []string{"kind", "action", "result"}),
+		apiRuleValidationFailuresCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:      "apirule_validation_failures_total",
+			Namespace: "api_gateway",
+			Help:      "The total number of APIRule validation failures, by category of the attribute path",
+		}, []string{"category"}),
 	}
-	ctrlmetrics.Registry.MustRegister(apiGatewayMetrics.apiRuleObjectModifiedErrorsCounter)
-	return apiGatewayMetrics
 }
 
 func (m *ApiGatewayMetrics) IncreaseApiRuleObjectModifiedErrorsCounter() {
 	m.apiRuleObjectModifiedErrorsCounter.Inc()
 }
+
+func (m *ApiGatewayMetrics) SetOrphanedSubresources(kind string, count int) {
+	m.orphanedSubresourcesGauge.WithLabelValues(kind).Set(float64(count))
+}
+
+func (m *ApiGatewayMetrics) IncreaseOrphanedSubresourcesDeletedCounter(kind string) {
+	m.orphanedSubresourcesDeletedCounter.WithLabelValues(kind).Inc()
+}
+
+func (m *ApiGatewayMetrics) ObserveAPIRuleReconcilePhaseDuration(phase string, duration time.Duration) {
+	m.apiRuleReconcilePhaseDuration.WithLabelValues(phase).Observe(duration.Seconds())
+}
+
+// IncreaseAPIRuleSubresourceChangesCounter counts a change of an APIRule subresource. The result is either success
+// or error.
+func (m *ApiGatewayMetrics) IncreaseAPIRuleSubresourceChangesCounter(kind, action, result string) {
+	m.apiRuleSubresourceChangesCounter.WithLabelValues(kind, action, result).Inc()
+}
+
+func (m *ApiGatewayMetrics) IncreaseAPIRuleValidationFailuresCounter(attributePath string) {
+	m.apiRuleValidationFailuresCounter.WithLabelValues(AttributePathCategory(attributePath)).Inc()
+}
+
+var attributePathIndex = regexp.MustCompile(`\[[^]]*]`)
+
+// maxAttributePathCategoryDepth limits the number of segments of the attribute path used as category. Deeper segments
+// can contain user provided values, e.g. the names of external authorizers, and would lead to an unbounded number
+// of label values.
+const maxAttributePathCategoryDepth = 3
+
+// AttributePathCategory returns the category of a validation failure attribute path, e.g. "spec.rules.jwt" for
+// ".spec.rules[0].jwt.authentications[1].issuer". Indices are removed and the path is truncated, so the category
+// has a bounded number of values.
+func AttributePathCategory(attributePath string) string {
+	path := strings.Trim(attributePathIndex.ReplaceAllString(attributePath, ""), ".")
+	if path == "" {
+		return "unknown"
+	}
+	segments := strings.Split(path, ".")
+	if len(segments) > maxAttributePathCategoryDepth {
+		segments = segments[:maxAttributePathCategoryDepth]
+	}
+	return strings.Join(segments, ".")
+}
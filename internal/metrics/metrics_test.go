@@ -1,10 +1,23 @@
 package metrics
 
 import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	dto "github.com/prometheus/client_model/go"
+
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 )
 
 var _ = Describe("ApiGateway metrics", func() {
@@ -21,3 +34,106 @@ var _ = Describe("ApiGateway metrics", func() {
 		Expect(pb.GetCounter().GetValue()).To(Equal(float64(1)))
 	})
 })
+
+var _ = Describe("APIRule reconciliation metrics", func() {
+	It("Should count subresource changes by kind, action and result", func() {
+		metrics := newApiGatewayMetrics()
+		metrics.IncreaseAPIRuleSubresourceChangesCounter("VirtualService", "create", "success")
+		metrics.IncreaseAPIRuleSubresourceChangesCounter("VirtualService", "create", "success")
+		metrics.IncreaseAPIRuleSubresourceChangesCounter("AuthorizationPolicy", "delete", "error")
+
+		Expect(counterValue(metrics.apiRuleSubresourceChangesCounter.WithLabelValues("VirtualService", "create", "success"))).To(Equal(float64(2)))
+		Expect(counterValue(metrics.apiRuleSubresourceChangesCounter.WithLabelValues("AuthorizationPolicy", "delete", "error"))).To(Equal(float64(1)))
+	})
+
+	It("Should count validation failures by attribute path category", func() {
+		metrics := newApiGatewayMetrics()
+		metrics.IncreaseAPIRuleValidationFailuresCounter(".spec.rules[0].jwt.authentications[0].issuer")
+		metrics.IncreaseAPIRuleValidationFailuresCounter(".spec.rules[1].jwt")
+
+		Expect(counterValue(metrics.apiRuleValidationFailuresCounter.WithLabelValues("spec.rules.jwt"))).To(Equal(float64(2)))
+	})
+
+	It("Should observe reconcile phase durations", func() {
+		metrics := newApiGatewayMetrics()
+		metrics.ObserveAPIRuleReconcilePhaseDuration("validation", 20*time.Millisecond)
+
+		pb := &dto.Metric{}
+		Expect(metrics.apiRuleReconcilePhaseDuration.WithLabelValues("validation").(prometheus.Metric).Write(pb)).To(Succeed())
+		Expect(pb.GetHistogram().GetSampleCount()).To(Equal(uint64(1)))
+		Expect(pb.GetHistogram().GetSampleSum()).To(BeNumerically("~", 0.02))
+	})
+
+	DescribeTable("AttributePathCategory",
+		func(attributePath, expected string) {
+			Expect(AttributePathCategory(attributePath)).To(Equal(expected))
+		},
+		Entry("nested path with indices", ".spec.rules[0].extAuth.externalAuthorizers.my-authorizer", "spec.rules.extAuth"),
+		Entry("short path", ".spec.hosts[2]", "spec.hosts"),
+		Entry("path without leading dot", "spec.gateway", "spec.gateway"),
+		Entry("empty path", "", "unknown"),
+	)
+})
+
+var _ = Describe("ResourceStateCollector", func() {
+	It("Should expose the number of resources by kind, version and state once the informers have synced", func() {
+		scheme := runtime.NewScheme()
+		Expect(gatewayv2alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(ratelimitv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(externalv1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&gatewayv2alpha1.APIRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "v1beta1-rule", Namespace: "default", Annotations: map[string]string{"gateway.kyma-project.io/original-version": "v1beta1"}},
+				Status:     gatewayv2alpha1.APIRuleStatus{State: gatewayv2alpha1.Warning},
+			},
+			&gatewayv2alpha1.APIRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "v2-rule-1", Namespace: "default", Annotations: map[string]string{"gateway.kyma-project.io/original-version": "v2"}},
+				Status:     gatewayv2alpha1.APIRuleStatus{State: gatewayv2alpha1.Ready},
+			},
+			&gatewayv2alpha1.APIRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "v2-rule-2", Namespace: "default"},
+				Status:     gatewayv2alpha1.APIRuleStatus{State: gatewayv2alpha1.Ready},
+			},
+			&ratelimitv1alpha1.RateLimit{
+				ObjectMeta: metav1.ObjectMeta{Name: "rate-limit", Namespace: "default"},
+			},
+			&externalv1alpha1.ExternalGateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+				Status:     externalv1alpha1.ExternalGatewayStatus{State: externalv1alpha1.Error},
+			},
+		).Build()
+
+		collector := NewResourceStateCollector(k8sClient, &informertest.FakeInformers{Scheme: scheme}, logr.Discard())
+		registry := prometheus.NewPedanticRegistry()
+		Expect(registry.Register(collector)).To(Succeed())
+
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(families).To(BeEmpty())
+
+		Expect(collector.Start(context.Background())).To(Succeed())
+		families, err = registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(families).To(HaveLen(1))
+		gauges := map[string]float64{}
+		for _, m := range families[0].GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			gauges[labels["kind"]+"/"+labels["version"]+"/"+labels["state"]] = m.GetGauge().GetValue()
+		}
+		Expect(gauges).To(Equal(map[string]float64{
+			"APIRule/v2/Ready":               2,
+			"APIRule/v1beta1/Warning":        1,
+			"ExternalGateway/v1alpha1/Error": 1,
+			"RateLimit/v1alpha1/Unknown":     1,
+		}))
+	})
+})
+
+func counterValue(counter prometheus.Counter) float64 {
+	pb := &dto.Metric{}
+	Expect(counter.Write(pb)).To(Succeed())
+	return pb.GetCounter().GetValue()
+}
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	ratelimitv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/ratelimit/v1alpha1"
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+const (
+	resourceListTimeout = 5 * time.Second
+	unknownState        = "Unknown"
+)
+
+var resourcesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName("api_gateway", "", "resources"),
+	"The number of APIRules, RateLimits and ExternalGateways by kind, version and state",
+	[]string{"kind", "version", "state"}, nil,
+)
+
+// ResourceStateCollector exposes the number of APIRules, RateLimits and ExternalGateways by version and state.
+// The resources are listed from the cache of the manager when the metrics are scraped, so the gauges never contain
+// resources that were deleted in the meantime. The collector is started as a runnable of the manager and doesn't
+// collect any metrics until the informers of the resources have synced. As it requires leader election, replicas that
+// aren't the leader don't expose the metrics and don't create informers for the resources.
+type ResourceStateCollector struct {
+	reader    client.Reader
+	informers cache.Informers
+	log       logr.Logger
+	synced    atomic.Bool
+}
+
+func NewResourceStateCollector(reader client.Reader, informers cache.Informers, log logr.Logger) *ResourceStateCollector {
+	return &ResourceStateCollector{reader: reader, informers: informers, log: log}
+}
+
+// RegisterResourceStateCollector registers a ResourceStateCollector that lists the resources from the cache of the
+// manager, and adds it to the manager so that it starts collecting once the cache has synced.
+func RegisterResourceStateCollector(mgr manager.Manager, log logr.Logger) error {
+	collector := NewResourceStateCollector(mgr.GetCache(), mgr.GetCache(), log)
+	if err := mgr.Add(collector); err != nil {
+		return err
+	}
+	return ctrlmetrics.Registry.Register(collector)
+}
+
+// Start waits until the informers of the collected resources have synced.
+func (c *ResourceStateCollector) Start(ctx context.Context) error {
+	for _, obj := range []client.Object{&gatewayv2alpha1.APIRule{}, &ratelimitv1alpha1.RateLimit{}, &externalv1alpha1.ExternalGateway{}} {
+		// GetInformer blocks until the informer has synced.
+		if _, err := c.informers.GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("waiting for the informer of %T to sync: %w", obj, err)
+		}
+	}
+	c.synced.Store(true)
+	return nil
+}
+
+func (c *ResourceStateCollector) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *ResourceStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourcesDesc
+}
+
+func (c *ResourceStateCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.synced.Load() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resourceListTimeout)
+	defer cancel()
+
+	var apiRules gatewayv2alpha1.APIRuleList
+	if err := c.reader.List(ctx, &apiRules); err != nil {
+		c.log.Error(err, "Failed to list APIRules for metrics")
+	} else {
+		counts := resourceCounts{}
+		for _, apiRule := range apiRules.Items {
+			counts.add(apiRuleVersion(apiRule), string(apiRule.Status.State))
+		}
+		counts.collect(ch, "APIRule")
+	}
+
+	var rateLimits ratelimitv1alpha1.RateLimitList
+	if err := c.reader.List(ctx, &rateLimits); err != nil {
+		c.log.Error(err, "Failed to list RateLimits for metrics")
+	} else {
+		counts := resourceCounts{}
+		for _, rateLimit := range rateLimits.Items {
+			counts.add(ratelimitv1alpha1.GroupVersion.Version, rateLimit.Status.State)
+		}
+		counts.collect(ch, "RateLimit")
+	}
+
+	var externalGateways externalv1alpha1.ExternalGatewayList
+	if err := c.reader.List(ctx, &externalGateways); err != nil {
+		c.log.Error(err, "Failed to list ExternalGateways for metrics")
+	} else {
+		counts := resourceCounts{}
+		for _, externalGateway := range externalGateways.Items {
+			counts.add(externalv1alpha1.GroupVersion.Version, string(externalGateway.Status.State))
+		}
+		counts.collect(ch, "ExternalGateway")
+	}
+}
+
+// apiRuleVersion returns the version the APIRule was created with. APIRules without the annotation were created
+// with version v2.
+func apiRuleVersion(apiRule gatewayv2alpha1.APIRule) string {
+	if version, ok := apiRule.Annotations[gatewayv2.OriginalVersionAnnotation]; ok && version != "" {
+		return version
+	}
+	return gatewayv2.GroupVersion.Version
+}
+
+type resourceKey struct {
+	version, state string
+}
+
+type resourceCounts map[resourceKey]int
+
+func (c resourceCounts) add(version, state string) {
+	if state == "" {
+		state = unknownState
+	}
+	c[resourceKey{version: version, state: state}]++
+}
+
+func (c resourceCounts) collect(ch chan<- prometheus.Metric, kind string) {
+	for key, count := range c {
+		ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(count), kind, key.version, key.state)
+	}
+}
@@ -0,0 +1,111 @@
+package processing
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager is the field manager used to write the subresources of APIRules with server-side apply.
+const FieldManager = "api-gateway-apirule-controller"
+
+// legacyFieldManagers are the field managers that own the fields of subresources written with Create and Update.
+// "manager" is the default field manager derived from the name of the operator binary.
+var legacyFieldManagers = sets.New("manager", FieldManager)
+
+// createObject creates the object. Objects that only have a generated name can't be created with server-side apply,
+// so they are created with a regular Create and their fields are moved to the apply field manager on the next update.
+func createObject(ctx context.Context, k8sClient client.Client, obj client.Object) error {
+	if obj.GetName() == "" {
+		return k8sClient.Create(ctx, obj, client.FieldOwner(FieldManager))
+	}
+	return applyObject(ctx, k8sClient, obj, false)
+}
+
+// updateObject applies the object with the resource version it was read with, so the update fails with a conflict
+// in the same way as a regular Update if the object was changed or deleted in the meantime.
+func updateObject(ctx context.Context, k8sClient client.Client, obj client.Object) error {
+	if obj.GetResourceVersion() == "" {
+		// Without a resource version, server-side apply would create an object that doesn't exist anymore.
+		if err := ensureExists(ctx, k8sClient, obj); err != nil {
+			return err
+		}
+		return applyObject(ctx, k8sClient, obj, false)
+	}
+
+	if err := upgradeManagedFields(ctx, k8sClient, obj); err != nil {
+		return err
+	}
+	return applyObject(ctx, k8sClient, obj, true)
+}
+
+func ensureExists(ctx context.Context, k8sClient client.Client, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, k8sClient.Scheme())
+	if err != nil {
+		return err
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	return k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), current)
+}
+
+// applyObject applies the metadata managed by the controller and the spec of the object. Fields set by other field
+// managers are overwritten, so manual changes to the subresources are reverted.
+func applyObject(ctx context.Context, k8sClient client.Client, obj client.Object, withResourceVersion bool) error {
+	gvk, err := apiutil.GVKForObject(obj, k8sClient.Scheme())
+	if err != nil {
+		return err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	applyConfig := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for key, value := range content {
+		if key != "metadata" && key != "status" {
+			applyConfig.Object[key] = value
+		}
+	}
+	applyConfig.SetGroupVersionKind(gvk)
+	applyConfig.SetName(obj.GetName())
+	applyConfig.SetNamespace(obj.GetNamespace())
+	applyConfig.SetLabels(obj.GetLabels())
+	applyConfig.SetAnnotations(obj.GetAnnotations())
+	applyConfig.SetOwnerReferences(obj.GetOwnerReferences())
+	if withResourceVersion {
+		applyConfig.SetResourceVersion(obj.GetResourceVersion())
+	}
+
+	if err := k8sClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(applyConfig), client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return err
+	}
+
+	obj.SetResourceVersion(applyConfig.GetResourceVersion())
+	return nil
+}
+
+// upgradeManagedFields moves the fields owned by the legacy field managers to the apply field manager. Otherwise,
+// fields removed from the desired state would not be removed by server-side apply, because they are still owned
+// by the legacy field managers.
+func upgradeManagedFields(ctx context.Context, k8sClient client.Client, obj client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(obj, legacyFieldManagers, FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+
+	// The patch is sent for a copy, because the response would overwrite the desired state of obj.
+	current := obj.DeepCopyObject().(client.Object)
+	if err := k8sClient.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	return nil
+}
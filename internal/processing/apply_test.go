@@ -0,0 +1,82 @@
+package processing_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	"github.com/kyma-project/api-gateway/internal/builders"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/processing/status"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+var _ = Describe("Server-side apply of subresources", func() {
+	reconcileChanges := func(k8sClient client.Client, changes ...*processing.ObjectChange) status.ReconciliationV1beta1Status {
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return changes, nil
+			},
+		}
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		return processing.Reconcile(context.Background(), k8sClient, k8sClient, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+	}
+
+	newClient := func() client.Client {
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).Build()
+	}
+
+	It("should create a subresource with a name", func() {
+		// given
+		k8sClient := newClient()
+		desired := builders.VirtualService().Name("test").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("example.com")).Get()
+
+		// when
+		s := reconcileChanges(k8sClient, processing.NewObjectCreateAction(desired))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		var created networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(desired), &created)).To(Succeed())
+		Expect(created.Spec.Hosts).To(ConsistOf("example.com"))
+	})
+
+	It("should revert changes of other field managers on update", func() {
+		// given
+		k8sClient := newClient()
+		desired := builders.VirtualService().Name("test").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("example.com")).Get()
+		Expect(reconcileChanges(k8sClient, processing.NewObjectCreateAction(desired.DeepCopy())).ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+
+		var modified networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(desired), &modified)).To(Succeed())
+		modified.Spec.Hosts = []string{"changed.example.com"}
+		Expect(k8sClient.Update(context.Background(), &modified, client.FieldOwner("kubectl-edit"))).To(Succeed())
+
+		var actual networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(desired), &actual)).To(Succeed())
+		actual.Spec = *desired.Spec.DeepCopy()
+
+		// when
+		s := reconcileChanges(k8sClient, processing.NewObjectUpdateAction(&actual))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		var reverted networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(desired), &reverted)).To(Succeed())
+		Expect(reverted.Spec.Hosts).To(ConsistOf("example.com"))
+	})
+})
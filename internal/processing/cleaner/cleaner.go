@@ -8,12 +8,13 @@ import (
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/subresources/accessrule"
 	"github.com/kyma-project/api-gateway/internal/subresources/authorizationpolicy"
+	"github.com/kyma-project/api-gateway/internal/subresources/networkpolicy"
 	"github.com/kyma-project/api-gateway/internal/subresources/requestauthentication"
 	"github.com/kyma-project/api-gateway/internal/subresources/virtualservice"
 )
 
 // DeleteAPIRuleSubresources deletes all subresources (AuthorizationPolicies, RequestAuthentications,
-// VirtualServices, and AccessRules) that are owned by the given APIRule
+// VirtualServices, NetworkPolicies, and AccessRules) that are owned by the given APIRule
 func DeleteAPIRuleSubresources(k8sClient client.Client, ctx context.Context, apiRule processing.Labeler) error {
 
 	// Delete AuthorizationPolicies
@@ -52,6 +53,11 @@ func DeleteAPIRuleSubresources(k8sClient client.Client, ctx context.Context, api
 		return err
 	}
 
+	// Delete NetworkPolicies
+	if err := networkpolicy.NewRepository(k8sClient).DeleteAll(ctx, apiRule); err != nil {
+		return err
+	}
+
 	// Delete AccessRules (Ory Rules) if CRD exists
 	arRepo := accessrule.NewRepository(k8sClient)
 	var oryCRD apiextensionsv1.CustomResourceDefinition
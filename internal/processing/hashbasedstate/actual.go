@@ -9,13 +9,15 @@ const (
 
 func NewActual() Actual {
 	return Actual{
-		hashables:         make(map[string]Hashable),
+		hashables:         make(map[string][]Hashable),
 		markedForDeletion: []client.Object{},
 	}
 }
 
 type Actual struct {
-	hashables map[string]Hashable
+	// hashables holds all objects with the same hash key. There is usually only one object per hash key, but duplicates
+	// might have been created by earlier versions that generated random names for the objects.
+	hashables map[string][]Hashable
 	// Objects are marked for deletion for migration reasons. That means objects without required hashing labels must be
 	// deleted as we can't reliably compare them. This field might be removed in the future as no objects without hashing
 	// labels exist anymore.
@@ -41,7 +43,7 @@ func (a *Actual) Add(hashable Hashable) {
 		a.markedForDeletion = append(a.markedForDeletion, hashable.ToObject())
 	} else {
 		hashKey := createHashKey(hash, index)
-		a.hashables[hashKey] = hashable
+		a.hashables[hashKey] = append(a.hashables[hashKey], hashable)
 	}
 }
 
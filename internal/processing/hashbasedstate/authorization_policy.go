@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/mitchellh/hashstructure/v2"
+	"google.golang.org/protobuf/proto"
+	"istio.io/api/security/v1beta1"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,6 +25,34 @@ func AddLabelsToAuthorizationPolicy(ap *securityv1beta1.AuthorizationPolicy, ind
 	return nil
 }
 
+// AddLabelsToConsolidatedAuthorizationPolicy adds hashing labels to an AuthorizationPolicy that consolidates the rules
+// of several AuthorizationPolicies. The policy is identified by its namespace, selector and the given identity of the
+// consolidated policies instead of the operation of its first rule, so it keeps its identity if rules are added or
+// removed.
+func AddLabelsToConsolidatedAuthorizationPolicy(ap *securityv1beta1.AuthorizationPolicy, identity uint64) error {
+	hashService, err := hashstructure.Hash(ap.Spec.Selector, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
+	if err != nil {
+		return err
+	}
+
+	hashNamespace, err := hashstructure.Hash(ap.Namespace, hashstructure.FormatV2, nil)
+	if err != nil {
+		return err
+	}
+
+	// The identity is prefixed so that a consolidated policy never has the same hash as a policy for a single rule.
+	hash := fmt.Sprintf("%s.%s.c-%s", strconv.FormatUint(hashNamespace, 36), strconv.FormatUint(hashService, 32), strconv.FormatUint(identity, 32))
+	addHashingLabels(ap, hash, 0)
+
+	return nil
+}
+
+// GetAuthorizationPolicyHash returns the hash that identifies the AuthorizationPolicy by its namespace, selector and the
+// operation of its first rule, i.e. the hosts, methods and path of the APIRule rule it was created for.
+//
+// The not paths of the operation are excluded from the hash, because they are derived from the rules that precede the
+// rule in the APIRule. This way inserting, removing or reordering rules does not change the identity of the
+// AuthorizationPolicies of the other rules, so they are updated in place instead of being recreated.
 func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy) (string, error) {
 	hashService, err := hashstructure.Hash(ap.Spec.Selector, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
 	if err != nil {
@@ -31,7 +61,7 @@ func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy) (string
 
 	var hashTo uint64
 	if len(ap.Spec.Rules) > 0 && ap.Spec.Rules[0].To != nil {
-		hash, err := hashstructure.Hash(ap.Spec.Rules[0].To, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
+		hash, err := hashstructure.Hash(withoutNotPaths(ap.Spec.Rules[0].To), hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
 		if err != nil {
 			return "", err
 		}
@@ -46,6 +76,18 @@ func GetAuthorizationPolicyHash(ap *securityv1beta1.AuthorizationPolicy) (string
 	return fmt.Sprintf("%s.%s.%s", strconv.FormatUint(hashNamespace, 36), strconv.FormatUint(hashService, 32), strconv.FormatUint(hashTo, 32)), nil
 }
 
+func withoutNotPaths(to []*v1beta1.Rule_To) []*v1beta1.Rule_To {
+	result := make([]*v1beta1.Rule_To, 0, len(to))
+	for _, t := range to {
+		c := proto.Clone(t).(*v1beta1.Rule_To)
+		if c.Operation != nil {
+			c.Operation.NotPaths = nil
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
 type AuthorizationPolicyHashable struct {
 	ap *securityv1beta1.AuthorizationPolicy
 }
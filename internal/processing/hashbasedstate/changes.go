@@ -8,27 +8,44 @@ import (
 
 // GetChanges returns the changes that need to be applied to reach the desired state by comparing the hash keys
 // of the objects in the desired and actual state.
+//
+// If the desired object has a name, only the actual object with the same name is updated. Actual objects with the
+// same hash key but a different name, e.g. objects created with a generated name or duplicates of them, are deleted
+// and the desired object is created if no actual object has its name.
 func GetChanges(desiredState Desired, actualState Actual) Changes {
+	var toCreate []client.Object
 	var toDelete []client.Object
 	var toUpdate []client.Object
 
-	for actualHashKey, actual := range actualState.hashables {
+	for actualHashKey, actuals := range actualState.hashables {
+		desired, ok := desiredState.hashables[actualHashKey]
+		if !ok {
+			// If the actual object is no longer in the desired state we can assume that it was removed and can be deleted.
+			for _, actual := range actuals {
+				toDelete = append(toDelete, actual.ToObject())
+			}
+			continue
+		}
 
-		if desiredState.containsHashkey(actualHashKey) {
+		kept := keptActual(desired, actuals)
+		for i, actual := range actuals {
+			if i != kept {
+				toDelete = append(toDelete, actual.ToObject())
+				continue
+			}
 			// Since not all fields of the object may be included in the hash key, we need to update the desired changes in the object that is applied.
 			// Additionally, we want to make sure that the object is in the expected state and possible manual changes are overwritten.
-			updated := actual.updateSpec(desiredState.hashables[actualHashKey])
-			toUpdate = append(toUpdate, updated)
-		} else {
-			// If the actual object is no longer in the desired state we can assume that it was removed and can be deleted.
-			toDelete = append(toDelete, actual.ToObject())
+			toUpdate = append(toUpdate, actual.updateSpec(desired))
+		}
+		if kept < 0 {
+			toCreate = append(toCreate, desired.ToObject())
 		}
 	}
 
 	toDelete = append(toDelete, actualState.markedForDeletion...)
 
 	// We know that all objects that are in the desired state but not in the actual state must be new objects and need to be created.
-	toCreate := desiredState.getObjectsNotIn(actualState)
+	toCreate = append(toCreate, desiredState.getObjectsNotIn(actualState)...)
 
 	return Changes{
 		Create: toCreate,
@@ -37,6 +54,21 @@ func GetChanges(desiredState Desired, actualState Actual) Changes {
 	}
 }
 
+// keptActual returns the index of the actual object that is updated to the desired state, or -1 if none of the actual
+// objects can be kept and the desired object must be created.
+func keptActual(desired Hashable, actuals []Hashable) int {
+	name := desired.ToObject().GetName()
+	if name == "" {
+		return 0
+	}
+	for i, actual := range actuals {
+		if actual.ToObject().GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
+
 // Changes that need to be applied to reach the desired state
 type Changes struct {
 	Create []client.Object
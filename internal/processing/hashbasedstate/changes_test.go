@@ -0,0 +1,133 @@
+package hashbasedstate_test
+
+import (
+	"github.com/kyma-project/api-gateway/internal/processing/hashbasedstate"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("GetChanges", func() {
+	newAuthorizationPolicy := func(name, hash, index string) *securityv1beta1.AuthorizationPolicy {
+		return &securityv1beta1.AuthorizationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					"gateway.kyma-project.io/hash":  hash,
+					"gateway.kyma-project.io/index": index,
+				},
+			},
+		}
+	}
+
+	getStates := func(desired []*securityv1beta1.AuthorizationPolicy, actual ...*securityv1beta1.AuthorizationPolicy) (hashbasedstate.Desired, hashbasedstate.Actual) {
+		desiredState := hashbasedstate.NewDesired()
+		for _, ap := range desired {
+			h := hashbasedstate.NewAuthorizationPolicy(ap)
+			Expect(desiredState.Add(&h)).To(Succeed())
+		}
+		actualState := hashbasedstate.NewActual()
+		for _, ap := range actual {
+			h := hashbasedstate.NewAuthorizationPolicy(ap)
+			actualState.Add(&h)
+		}
+		return desiredState, actualState
+	}
+
+	names := func(objects []client.Object) []string {
+		var n []string
+		for _, o := range objects {
+			n = append(n, o.GetName())
+		}
+		return n
+	}
+
+	It("should update the actual object with the name of the desired object", func() {
+		// given
+		desired, actual := getStates(
+			[]*securityv1beta1.AuthorizationPolicy{newAuthorizationPolicy("ap-named", "a.b.c", "0")},
+			newAuthorizationPolicy("ap-named", "a.b.c", "0"),
+		)
+
+		// when
+		changes := hashbasedstate.GetChanges(desired, actual)
+
+		// then
+		Expect(changes.Create).To(BeEmpty())
+		Expect(changes.Delete).To(BeEmpty())
+		Expect(names(changes.Update)).To(ConsistOf("ap-named"))
+	})
+
+	It("should replace an actual object with a generated name by the desired object", func() {
+		// given
+		desired, actual := getStates(
+			[]*securityv1beta1.AuthorizationPolicy{newAuthorizationPolicy("ap-named", "a.b.c", "0")},
+			newAuthorizationPolicy("ap-x7k2p", "a.b.c", "0"),
+		)
+
+		// when
+		changes := hashbasedstate.GetChanges(desired, actual)
+
+		// then
+		Expect(names(changes.Create)).To(ConsistOf("ap-named"))
+		Expect(names(changes.Delete)).To(ConsistOf("ap-x7k2p"))
+		Expect(changes.Update).To(BeEmpty())
+	})
+
+	It("should delete duplicates of the actual object with the name of the desired object", func() {
+		// given
+		desired, actual := getStates(
+			[]*securityv1beta1.AuthorizationPolicy{newAuthorizationPolicy("ap-named", "a.b.c", "0")},
+			newAuthorizationPolicy("ap-x7k2p", "a.b.c", "0"),
+			newAuthorizationPolicy("ap-named", "a.b.c", "0"),
+			newAuthorizationPolicy("ap-q9d4m", "a.b.c", "0"),
+		)
+
+		// when
+		changes := hashbasedstate.GetChanges(desired, actual)
+
+		// then
+		Expect(changes.Create).To(BeEmpty())
+		Expect(names(changes.Delete)).To(ConsistOf("ap-x7k2p", "ap-q9d4m"))
+		Expect(names(changes.Update)).To(ConsistOf("ap-named"))
+	})
+
+	It("should keep one of the duplicates if the desired object has a generated name", func() {
+		// given
+		desiredAp := newAuthorizationPolicy("", "a.b.c", "0")
+		desiredAp.GenerateName = "ap-"
+		desired, actual := getStates(
+			[]*securityv1beta1.AuthorizationPolicy{desiredAp},
+			newAuthorizationPolicy("ap-x7k2p", "a.b.c", "0"),
+			newAuthorizationPolicy("ap-q9d4m", "a.b.c", "0"),
+		)
+
+		// when
+		changes := hashbasedstate.GetChanges(desired, actual)
+
+		// then
+		Expect(changes.Create).To(BeEmpty())
+		Expect(changes.Delete).To(HaveLen(1))
+		Expect(changes.Update).To(HaveLen(1))
+	})
+
+	It("should delete all duplicates that are no longer desired", func() {
+		// given
+		desired, actual := getStates(
+			nil,
+			newAuthorizationPolicy("ap-x7k2p", "a.b.c", "0"),
+			newAuthorizationPolicy("ap-q9d4m", "a.b.c", "0"),
+		)
+
+		// when
+		changes := hashbasedstate.GetChanges(desired, actual)
+
+		// then
+		Expect(changes.Create).To(BeEmpty())
+		Expect(names(changes.Delete)).To(ConsistOf("ap-x7k2p", "ap-q9d4m"))
+		Expect(changes.Update).To(BeEmpty())
+	})
+})
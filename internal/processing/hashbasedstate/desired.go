@@ -1,8 +1,6 @@
 package hashbasedstate
 
 import (
-	"fmt"
-
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -19,18 +17,11 @@ type Desired struct {
 // Add the value to the desired state. Since setting the hashing labels is decoupled from adding objects to the state we need to protect against the creation of objects in that do not have the required hash
 // and index labels, this function returns an error if one of these labels is missing.
 func (d *Desired) Add(h Hashable) error {
-
-	index, ok := h.index()
-	if !ok {
-		return fmt.Errorf("label %s not found on hashable", indexLabelName)
-	}
-
-	hash, ok := h.hash()
-	if !ok {
-		return fmt.Errorf("label %s not found on hashable", hashLabelName)
+	hashKey, err := HashKey(h)
+	if err != nil {
+		return err
 	}
 
-	hashKey := createHashKey(hash, index)
 	d.hashables[hashKey] = h
 
 	return nil
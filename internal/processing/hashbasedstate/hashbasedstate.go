@@ -1,12 +1,14 @@
 // Package hashbasedstate provides types and functions to compare objects by a hash and a position in a yaml sequence.
 //
-// The hashbasedstate package should only be used objects unmarshalled from a yaml sequences as they have a defined order.
 // The comparison is based on labels for a hash and an index put on a kubernetes object. The hash label holds the hash that
-// represents the object and the index value holds the position of the object in the sequence. Both of this information is
-// then used to identify if an object was changed, removed or newly added.
+// identifies the object by its content and the index label holds the position of the object in the sequence of objects
+// with the same hash. Both of this information is then used to identify if an object was changed, removed or newly added.
 //
-// Since this comparison is based on the order of objects, it means that adding a new object before an existing object in the
-// sequence triggers an update for all the following objects, since their position in the sequence has changed.
+// For AuthorizationPolicies the hash identifies the rule of the APIRule by the workload, hosts, path and methods it
+// applies to, and the index identifies the authorization or authorizer within that rule. The position of the rule in the
+// APIRule is not part of the identity, so inserting, removing or reordering rules only creates or deletes the
+// AuthorizationPolicies of the affected rules. Adding an authorization before an existing authorization in the same rule
+// updates the following AuthorizationPolicies of this rule in place, since their position in the sequence has changed.
 package hashbasedstate
 
 import (
@@ -25,6 +27,22 @@ func createHashKey(hashValue, indexValue string) string {
 	return fmt.Sprintf("%s:%s", hashValue, indexValue)
 }
 
+// HashKey returns the key that identifies the hashable in the desired and actual state. An error is returned if the
+// hash or index label is missing.
+func HashKey(h Hashable) (string, error) {
+	index, ok := h.index()
+	if !ok {
+		return "", fmt.Errorf("label %s not found on hashable", indexLabelName)
+	}
+
+	hash, ok := h.hash()
+	if !ok {
+		return "", fmt.Errorf("label %s not found on hashable", hashLabelName)
+	}
+
+	return createHashKey(hash, index), nil
+}
+
 // addHashingLabels adds labels to the desired object to be able to compare it with the actual object in the cluster.
 func addHashingLabels(o client.Object, hash string, indexInYaml int) {
 
@@ -2,6 +2,9 @@ package processing
 
 import (
 	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
@@ -80,3 +83,24 @@ func GetOwnerLabels(l Labeler) OwnerLabels {
 		Namespace: l.GetNamespace(),
 	}
 }
+
+// GetOwnerFromLabels returns the APIRule that owns a subresource based on the owner labels. Subresources created by
+// older versions of the module only have the legacy owner label with the value "<name>.<namespace>". Namespaces can't
+// contain dots, so the value is split at the last dot.
+func GetOwnerFromLabels(labels map[string]string) (types.NamespacedName, bool) {
+	name, nameOk := labels[OwnerLabelName]
+	namespace, namespaceOk := labels[OwnerLabelNamespace]
+	if nameOk && namespaceOk && name != "" && namespace != "" {
+		return types.NamespacedName{Namespace: namespace, Name: name}, true
+	}
+
+	legacy, ok := labels[LegacyOwnerLabel]
+	if !ok {
+		return types.NamespacedName{}, false
+	}
+	i := strings.LastIndex(legacy, ".")
+	if i <= 0 || i == len(legacy)-1 {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: legacy[i+1:], Name: legacy[:i]}, true
+}
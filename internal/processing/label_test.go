@@ -96,3 +96,35 @@ var _ = Describe("GetOwnerLabels", func() {
 	})
 
 })
+
+var _ = Describe("GetOwnerFromLabels", func() {
+	It("should return the owner from the owner labels", func() {
+		owner, ok := processing.GetOwnerFromLabels(map[string]string{
+			processing.OwnerLabelName:      "test-apirule",
+			processing.OwnerLabelNamespace: "test-namespace",
+			processing.LegacyOwnerLabel:    "other.other-namespace",
+		})
+
+		Expect(ok).To(BeTrue())
+		Expect(owner.Name).To(Equal("test-apirule"))
+		Expect(owner.Namespace).To(Equal("test-namespace"))
+	})
+
+	It("should split the legacy owner label at the last dot", func() {
+		owner, ok := processing.GetOwnerFromLabels(map[string]string{
+			processing.LegacyOwnerLabel: "test.apirule.test-namespace",
+		})
+
+		Expect(ok).To(BeTrue())
+		Expect(owner.Name).To(Equal("test.apirule"))
+		Expect(owner.Namespace).To(Equal("test-namespace"))
+	})
+
+	It("should not return an owner without owner labels", func() {
+		_, ok := processing.GetOwnerFromLabels(map[string]string{
+			processing.LegacyOwnerLabel: "no-namespace.",
+		})
+
+		Expect(ok).To(BeFalse())
+	})
+})
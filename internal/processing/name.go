@@ -0,0 +1,32 @@
+package processing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// maxSubresourceNameLength is the length limit of subresource names. Subresource names are used as label values,
+	// e.g. by Istio telemetry, so they are kept within the 63-character limit of labels instead of the 253-character
+	// limit of object names.
+	maxSubresourceNameLength  = 63
+	subresourceNameHashLength = 10
+)
+
+// SubresourceName returns the deterministic name of a subresource created for the APIRule with the given name and
+// namespace. The identity distinguishes the subresources of the same APIRule, e.g. the kind of the subresource and the
+// key of the rule it was created for.
+//
+// The name has the format {apiRuleName}-{hash}, where the hash is built from the namespace and name of the APIRule and
+// the identity. The APIRule name is truncated so that the name does not exceed 63 characters.
+func SubresourceName(apiRuleName, apiRuleNamespace string, identity ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(append([]string{apiRuleNamespace, apiRuleName}, identity...), "/")))
+	hashSuffix := hex.EncodeToString(hash[:])[:subresourceNameHashLength]
+
+	prefix := apiRuleName
+	if maxPrefixLength := maxSubresourceNameLength - subresourceNameHashLength - 1; len(prefix) > maxPrefixLength {
+		prefix = strings.TrimRight(prefix[:maxPrefixLength], "-.")
+	}
+	return prefix + "-" + hashSuffix
+}
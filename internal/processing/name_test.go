@@ -0,0 +1,36 @@
+package processing_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+var _ = Describe("SubresourceName", func() {
+	It("should return the same name for the same APIRule and identity", func() {
+		name := processing.SubresourceName("test-apirule", "test-namespace", "VirtualService")
+
+		Expect(name).To(HavePrefix("test-apirule-"))
+		Expect(name).To(HaveLen(len("test-apirule-") + 10))
+		Expect(processing.SubresourceName("test-apirule", "test-namespace", "VirtualService")).To(Equal(name))
+	})
+
+	It("should return different names for different identities and namespaces", func() {
+		name := processing.SubresourceName("test-apirule", "test-namespace", "AuthorizationPolicy", "hash:0")
+
+		Expect(processing.SubresourceName("test-apirule", "test-namespace", "AuthorizationPolicy", "hash:1")).NotTo(Equal(name))
+		Expect(processing.SubresourceName("test-apirule", "other-namespace", "AuthorizationPolicy", "hash:0")).NotTo(Equal(name))
+	})
+
+	It("should truncate the name of a long APIRule to 63 characters", func() {
+		apiRuleName := strings.Repeat("a", 60) + "-" + strings.Repeat("b", 20)
+
+		name := processing.SubresourceName(apiRuleName, "test-namespace", "VirtualService")
+
+		Expect(name).To(HaveLen(63))
+		Expect(name).To(HavePrefix(apiRuleName[:52] + "-"))
+	})
+})
@@ -2,8 +2,6 @@ package istio
 
 import (
 	"context"
-	"fmt"
-
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 
 	"github.com/go-logr/logr"
@@ -20,7 +18,8 @@ import (
 )
 
 const (
-	audienceKey string = "request.auth.claims[aud]"
+	audienceKey             string = "request.auth.claims[aud]"
+	authorizationPolicyKind        = "AuthorizationPolicy"
 )
 
 var (
@@ -51,11 +50,15 @@ func (r authorizationPolicyCreator) Create(ctx context.Context, client client.Cl
 
 			for _, ap := range aps.Items {
 				h := hashbasedstate.NewAuthorizationPolicy(ap)
-				err := state.Add(&h)
-
+				hashKey, err := hashbasedstate.HashKey(&h)
 				if err != nil {
 					return state, err
 				}
+				ap.Name = processing.SubresourceName(api.Name, api.Namespace, authorizationPolicyKind, hashKey)
+
+				if err := state.Add(&h); err != nil {
+					return state, err
+				}
 			}
 		}
 	}
@@ -101,7 +104,6 @@ func generateAuthorizationPolicies(ctx context.Context, client client.Client, ap
 }
 
 func generateAuthorizationPolicy(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule, authorization *gatewayv1beta1.JwtAuthorization) (*securityv1beta1.AuthorizationPolicy, error) {
-	namePrefix := fmt.Sprintf("%s-", api.Name)
 	namespace := helpers.FindServiceNamespace(api, &rule)
 
 	spec, err := generateAuthorizationPolicySpec(ctx, client, api, rule, authorization)
@@ -110,7 +112,6 @@ func generateAuthorizationPolicy(ctx context.Context, client client.Client, api
 	}
 
 	apBuilder := builders.NewAuthorizationPolicyBuilder().
-		WithGenerateName(namePrefix).
 		WithNamespace(namespace).
 		WithSpec(builders.NewAuthorizationPolicySpecBuilder().FromAP(spec).Get()).
 		WithLabel(processing.ModuleLabelKey, processing.ApiGatewayLabelValue).
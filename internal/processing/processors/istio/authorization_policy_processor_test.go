@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
@@ -61,10 +62,19 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		}
 	}
 
-	getAuthorizationPolicy := func(name string, namespace string, serviceName string, methods []string) *securityv1beta1.AuthorizationPolicy {
+	// setIndex sets the index label of the AuthorizationPolicy and the name that is derived from it.
+	setIndex := func(ap *securityv1beta1.AuthorizationPolicy, index int) {
+		ap.Labels["gateway.kyma-project.io/index"] = strconv.Itoa(index)
+
+		h := hashbasedstate.NewAuthorizationPolicy(ap)
+		hashKey, err := hashbasedstate.HashKey(&h)
+		Expect(err).ShouldNot(HaveOccurred())
+		ap.Name = processing.SubresourceName(ApiName, ApiNamespace, "AuthorizationPolicy", hashKey)
+	}
+
+	getAuthorizationPolicy := func(namespace string, serviceName string, methods []string) *securityv1beta1.AuthorizationPolicy {
 		ap := securityv1beta1.AuthorizationPolicy{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
 				Namespace: namespace,
 				Labels: map[string]string{
 					processing.LegacyOwnerLabel: fmt.Sprintf("%s.%s", ApiName, ApiNamespace),
@@ -101,7 +111,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		apHash, err := hashbasedstate.GetAuthorizationPolicyHash(&ap)
 		Expect(err).ShouldNot(HaveOccurred())
 		ap.Labels["gateway.kyma-project.io/hash"] = apHash
-		ap.Labels["gateway.kyma-project.io/index"] = "0"
+		setIndex(&ap, 0)
 
 		return &ap
 	}
@@ -224,8 +234,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		ap2 := result[1].Obj.(*securityv1beta1.AuthorizationPolicy)
 
 		Expect(ap1).NotTo(BeNil())
-		Expect(ap1.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap1.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ap1.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ap1.ObjectMeta.GenerateName).To(BeEmpty())
 
 		Expect(ap1.Spec.Selector.MatchLabels[TestSelectorKey]).NotTo(BeNil())
 		Expect(ap1.Spec.Selector.MatchLabels[TestSelectorKey]).To(Equal(ServiceName))
@@ -249,8 +259,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		}
 
 		Expect(ap2).NotTo(BeNil())
-		Expect(ap2.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap2.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ap2.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ap2.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap2.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 
 		Expect(ap2.Spec.Selector.MatchLabels[TestSelectorKey]).NotTo(BeNil())
@@ -300,8 +310,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		ap2 := result[1].Obj.(*securityv1beta1.AuthorizationPolicy)
 
 		Expect(ap1).NotTo(BeNil())
-		Expect(ap1.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap1.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ap1.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ap1.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap1.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 
 		Expect(ap1.Spec.Selector.MatchLabels[TestSelectorKey]).NotTo(BeNil())
@@ -323,8 +333,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		}
 
 		Expect(ap2).NotTo(BeNil())
-		Expect(ap2.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap2.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ap2.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ap2.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap2.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 
 		Expect(ap2.Spec.Selector.MatchLabels[TestSelectorKey]).NotTo(BeNil())
@@ -470,8 +480,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
 
 		Expect(ap).NotTo(BeNil())
-		Expect(ap.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ap.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ap.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 
 		Expect(ap.Spec.Selector.MatchLabels[TestSelectorKey]).NotTo(BeNil())
@@ -803,7 +813,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 	It("should update AP when path, methods and service name didn't change", func() {
 		// given: Cluster state
-		existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+		existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 
 		// given: New resources
 		path := "/"
@@ -833,8 +843,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 	When("Two AP for different services with JWT handler exist", func() {
 		It("should update APs and update principal when handler changed for one of the AP to noop", func() {
 			// given: Cluster state
-			beingUpdatedAp := getAuthorizationPolicy("being-updated-ap", ApiNamespace, "test-service", []string{"GET", "POST"})
-			jwtSecuredAp := getAuthorizationPolicy("jwt-secured-ap", ApiNamespace, "jwt-secured-service", []string{"GET", "POST"})
+			beingUpdatedAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
+			jwtSecuredAp := getAuthorizationPolicy(ApiNamespace, "jwt-secured-service", []string{"GET", "POST"})
 			svc1 := GetService("test-service")
 			svc2 := GetService("jwt-secured-service")
 			ctrlClient := GetFakeClient(beingUpdatedAp, jwtSecuredAp, svc1, svc2)
@@ -881,7 +891,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 	It("should delete AP when there is no desired AP", func() {
 		//given: Cluster state
-		existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+		existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 		svc := GetService("test-service")
 		ctrlClient := GetFakeClient(existingAp, svc)
 
@@ -903,7 +913,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 	When("AP with RuleTo exists", func() {
 		It("should create new AP and update existing AP when new rule with same methods and service but different path is added to ApiRule", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+			existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 			svc := GetService("test-service")
 			ctrlClient := GetFakeClient(existingAp, svc)
 
@@ -932,7 +942,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 		It("should create new AP and update existing AP when new rule with same path and service but different methods is added to ApiRule", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+			existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 			svc := GetService("test-service")
 			ctrlClient := GetFakeClient(existingAp, svc)
 
@@ -961,7 +971,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 		It("should create new AP and update existing AP when new rule with same path and methods, but different service is added to ApiRule", func() {
 			//given: Cluster state
-			existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+			existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 			// given: New resources
 			existingRule := getRuleForApTest(methodsGetPost, "/", "test-service")
 			newRule := getRuleForApTest(methodsGetPost, "/", "new-service")
@@ -989,7 +999,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 		It("should recreate AP when path in ApiRule changed", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+			existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 			svc := GetService("test-service")
 			ctrlClient := GetFakeClient(existingAp, svc)
 
@@ -1017,7 +1027,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 		It("should update AP when legacy hash label is changed to new format", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("raName", ApiNamespace, "test-service", []string{"GET", "POST"})
+			existingAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET", "POST"})
 			expectedHash := existingAp.Labels["gateway.kyma-project.io/hash"]
 			parts := strings.Split(expectedHash, ".")
 			existingAp.Labels["gateway.kyma-project.io/hash"] = fmt.Sprintf("%s.%s.%s", ApiNamespace, parts[1], parts[2])
@@ -1051,8 +1061,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 	When("Two AP with different methods for same path and service exist", func() {
 		It("should create new AP, delete old AP and update unchanged AP with matching method, when path has changed", func() {
 			// given: Cluster state
-			unchangedAp := getAuthorizationPolicy("unchanged-ap", ApiNamespace, "test-service", []string{"DELETE"})
-			toBeUpdateAp := getAuthorizationPolicy("to-be-updated-ap", ApiNamespace, "test-service", []string{"GET"})
+			unchangedAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"DELETE"})
+			toBeUpdateAp := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"GET"})
 			svc := GetService("test-service")
 			ctrlClient := GetFakeClient(toBeUpdateAp, unchangedAp, svc)
 
@@ -1081,7 +1091,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 	When("Namespace changes", func() {
 		It("should create new AP in new namespace and delete old AP, namespace on spec level", func() {
 			// given: Cluster state
-			oldAP := getAuthorizationPolicy("unchanged-ap", ApiNamespace, "test-service", []string{"DELETE"})
+			oldAP := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"DELETE"})
 			specNewServiceNamespace := "new-namespace"
 			svc := GetService("test-service")
 			svcNewNS := GetService("test-service", specNewServiceNamespace)
@@ -1109,7 +1119,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 
 		It("should create new AP in new namespace and delete old AP, namespace on rule level", func() {
 			// given: Cluster state
-			oldAP := getAuthorizationPolicy("unchanged-ap", ApiNamespace, "test-service", []string{"DELETE"})
+			oldAP := getAuthorizationPolicy(ApiNamespace, "test-service", []string{"DELETE"})
 			svc := GetService("test-service", "new-namespace")
 			ctrlClient := GetFakeClient(oldAP, svc)
 
@@ -1136,8 +1146,8 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 	When("Two AP with same RuleTo for different services exist", func() {
 		It("should update unchanged AP and update AP with matching service, when path has changed", func() {
 			// given: Cluster state
-			unchangedAp := getAuthorizationPolicy("unchanged-ap", ApiNamespace, "first-service", []string{"GET"})
-			toBeUpdateAp := getAuthorizationPolicy("to-be-updated-ap", ApiNamespace, "second-service", []string{"GET"})
+			unchangedAp := getAuthorizationPolicy(ApiNamespace, "first-service", []string{"GET"})
+			toBeUpdateAp := getAuthorizationPolicy(ApiNamespace, "second-service", []string{"GET"})
 			svc1 := GetService("first-service")
 			svc2 := GetService("second-service")
 			ctrlClient := GetFakeClient(toBeUpdateAp, unchangedAp, svc1, svc2)
@@ -1169,7 +1179,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", ApiNamespace, serviceName, []string{"GET"})
+			ap1 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1177,7 +1187,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", ApiNamespace, serviceName, []string{"GET"})
+			ap2 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1185,7 +1195,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
 			svc := GetService(serviceName)
 			ctrlClient := GetFakeClient(ap1, ap2, svc)
@@ -1236,7 +1246,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", ApiNamespace, serviceName, []string{"GET"})
+			ap1 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1244,7 +1254,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", ApiNamespace, serviceName, []string{"GET"})
+			ap2 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1252,7 +1262,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
 			svc := GetService(serviceName)
 			ctrlClient := GetFakeClient(ap1, ap2, svc)
@@ -1303,7 +1313,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", ApiNamespace, serviceName, []string{"GET"})
+			ap1 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1311,7 +1321,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", ApiNamespace, serviceName, []string{"GET"})
+			ap2 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1319,7 +1329,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
 			svc := GetService(serviceName)
 			ctrlClient := GetFakeClient(ap1, ap2, svc)
@@ -1369,7 +1379,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", ApiNamespace, serviceName, []string{"GET"})
+			ap1 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1377,7 +1387,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", ApiNamespace, serviceName, []string{"GET"})
+			ap2 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1385,9 +1395,9 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
-			ap3 := getAuthorizationPolicy("ap3", ApiNamespace, serviceName, []string{"GET"})
+			ap3 := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap3.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -1395,7 +1405,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap3.Labels["gateway.kyma-project.io/index"] = "2"
+			setIndex(ap3, 2)
 
 			svc := GetService(serviceName)
 			ctrlClient := GetFakeClient(ap1, ap2, ap3, svc)
@@ -1544,7 +1554,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap := getAuthorizationPolicy("ap", ApiNamespace, serviceName, []string{"GET"})
+			ap := getAuthorizationPolicy(ApiNamespace, serviceName, []string{"GET"})
 			ap.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
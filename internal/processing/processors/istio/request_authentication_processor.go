@@ -2,8 +2,6 @@ package istio
 
 import (
 	"context"
-	"fmt"
-
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/builders"
 	"github.com/kyma-project/api-gateway/internal/helpers"
@@ -25,6 +23,8 @@ func Newv1beta1RequestAuthenticationProcessor(_ processing.ReconciliationConfig,
 	}
 }
 
+const requestAuthenticationKind = "RequestAuthentication"
+
 type requestAuthenticationCreator struct{}
 
 // Create returns the Virtual Service using the configuration of the APIRule.
@@ -36,14 +36,15 @@ func (r requestAuthenticationCreator) Create(ctx context.Context, client client.
 			if err != nil {
 				return requestAuthentications, err
 			}
-			requestAuthentications[processors.GetRequestAuthenticationKey(ra)] = ra
+			key := processors.GetRequestAuthenticationKey(ra)
+			ra.Name = processing.SubresourceName(api.Name, api.Namespace, requestAuthenticationKind, key)
+			requestAuthentications[key] = ra
 		}
 	}
 	return requestAuthentications, nil
 }
 
 func generateRequestAuthentication(ctx context.Context, client client.Client, api *gatewayv1beta1.APIRule, rule gatewayv1beta1.Rule) (*securityv1beta1.RequestAuthentication, error) {
-	namePrefix := fmt.Sprintf("%s-", api.Name)
 	namespace := helpers.FindServiceNamespace(api, &rule)
 
 	spec, err := generateRequestAuthenticationSpec(ctx, client, api, rule)
@@ -52,7 +53,6 @@ func generateRequestAuthentication(ctx context.Context, client client.Client, ap
 	}
 
 	raBuilder := builders.NewRequestAuthenticationBuilder().
-		WithGenerateName(namePrefix).
 		WithNamespace(namespace).
 		WithSpec(builders.NewRequestAuthenticationSpecBuilder().WithFrom(spec).Get()).
 		WithLabel(processing.OwnerLabelName, api.Name).
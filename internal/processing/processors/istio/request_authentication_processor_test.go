@@ -17,6 +17,7 @@ import (
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	. "github.com/kyma-project/api-gateway/internal/processing/processing_test"
+	"github.com/kyma-project/api-gateway/internal/processing/processors"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/istio"
 )
 
@@ -34,10 +35,9 @@ var _ = Describe("Request Authentication Processor", func() {
 		}
 	}
 
-	getRequestAuthentication := func(name string, serviceName string, jwksUri string, issuer string) securityv1beta1.RequestAuthentication {
-		return securityv1beta1.RequestAuthentication{
+	getRequestAuthentication := func(serviceName string, jwksUri string, issuer string) *securityv1beta1.RequestAuthentication {
+		ra := securityv1beta1.RequestAuthentication{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
 				Namespace: ApiNamespace,
 				Labels: map[string]string{
 					processing.LegacyOwnerLabel: fmt.Sprintf("%s.%s", ApiName, ApiNamespace),
@@ -57,6 +57,8 @@ var _ = Describe("Request Authentication Processor", func() {
 				},
 			},
 		}
+		ra.Name = processing.SubresourceName(ApiName, ApiNamespace, "RequestAuthentication", processors.GetRequestAuthenticationKey(&ra))
+		return &ra
 	}
 
 	getActionMatcher := func(action string, namespace string, serviceName string, jwksUri string, issuer string) gomegatypes.GomegaMatcher {
@@ -104,8 +106,8 @@ var _ = Describe("Request Authentication Processor", func() {
 		Expect(result).To(HaveLen(1))
 		ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
 		Expect(ra).NotTo(BeNil())
-		Expect(ra.ObjectMeta.Name).To(BeEmpty())
-		Expect(ra.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ra.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ra.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ra.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 		expectLabelsToBeFilled(ra.Labels)
 
@@ -237,8 +239,8 @@ var _ = Describe("Request Authentication Processor", func() {
 		ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
 
 		Expect(ra).NotTo(BeNil())
-		Expect(ra.ObjectMeta.Name).To(BeEmpty())
-		Expect(ra.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+		Expect(ra.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+		Expect(ra.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ra.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 		expectLabelsToBeFilled(ra.Labels)
 
@@ -312,9 +314,9 @@ var _ = Describe("Request Authentication Processor", func() {
 
 	It("should delete RA when there is no rule configured in ApiRule", func() {
 		// given: Cluster state
-		existingRa := getRequestAuthentication("raName", "test-service", JwksUri, JwtIssuer)
+		existingRa := getRequestAuthentication("test-service", JwksUri, JwtIssuer)
 
-		ctrlClient := GetFakeClient(&existingRa)
+		ctrlClient := GetFakeClient(existingRa)
 
 		// given: New resources
 		apiRule := GetAPIRuleFor([]gatewayv1beta1.Rule{})
@@ -331,11 +333,40 @@ var _ = Describe("Request Authentication Processor", func() {
 
 	When("RA with JWT config exists", func() {
 
+		It("should replace RA with a generated name and its duplicates by RA with a deterministic name", func() {
+			// given: Cluster state
+			existingRa := getRequestAuthentication("test-service", JwksUri, JwtIssuer)
+			desiredName := existingRa.Name
+			generatedRa, duplicateRa := existingRa.DeepCopy(), existingRa.DeepCopy()
+			generatedRa.Name = ApiName + "-x7k2p"
+			duplicateRa.Name = ApiName + "-q9d4m"
+			svc := GetService("test-service")
+			ctrlClient := GetFakeClient(generatedRa, duplicateRa, svc)
+
+			// given: New resources
+			jwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "test-service")
+			rules := []gatewayv1beta1.Rule{jwtRule}
+			apiRule := GetAPIRuleFor(rules)
+			processor := istio.Newv1beta1RequestAuthenticationProcessor(GetTestConfig(), apiRule, ctrlClient)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].Action.String()).To(Equal("create"))
+			Expect(result[0].Obj.GetName()).To(Equal(desiredName))
+			Expect(result[1].Action.String()).To(Equal("delete"))
+			Expect(result[2].Action.String()).To(Equal("delete"))
+			Expect([]string{result[1].Obj.GetName(), result[2].Obj.GetName()}).To(ConsistOf(generatedRa.Name, duplicateRa.Name))
+		})
+
 		It("should update RA when nothing changed", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "test-service", JwksUri, JwtIssuer)
+			existingRa := getRequestAuthentication("test-service", JwksUri, JwtIssuer)
 			svc := GetService("test-service")
-			ctrlClient := GetFakeClient(&existingRa, svc)
+			ctrlClient := GetFakeClient(existingRa, svc)
 
 			// given: New resources
 			jwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "test-service")
@@ -355,10 +386,10 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should delete and create new RA when only service name in JWT Rule has changed", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "old-service", JwksUri, JwtIssuer)
+			existingRa := getRequestAuthentication("old-service", JwksUri, JwtIssuer)
 			svcOld := GetService("old-service")
 			svcUpdated := GetService("updated-service")
-			ctrlClient := GetFakeClient(&existingRa, svcOld, svcUpdated)
+			ctrlClient := GetFakeClient(existingRa, svcOld, svcUpdated)
 
 			// given: New resources
 			jwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "updated-service")
@@ -380,10 +411,10 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should create new RA when new service with new JWT config is added to ApiRule", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "existing-service", JwksUri, JwtIssuer)
+			existingRa := getRequestAuthentication("existing-service", JwksUri, JwtIssuer)
 			svcExisting := GetService("existing-service")
 			svcNew := GetService("new-service")
-			ctrlClient := GetFakeClient(&existingRa, svcExisting, svcNew)
+			ctrlClient := GetFakeClient(existingRa, svcExisting, svcNew)
 
 			// given: New resources
 			existingJwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "existing-service")
@@ -408,9 +439,9 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should create new RA and delete old RA when JWT ApiRule has new JWKS URI", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "test-service", JwksUri, JwtIssuer)
+			existingRa := getRequestAuthentication("test-service", JwksUri, JwtIssuer)
 			svc := GetService("test-service")
-			ctrlClient := GetFakeClient(&existingRa, svc)
+			ctrlClient := GetFakeClient(existingRa, svc)
 
 			// given: New resources
 			jwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri2, "test-service")
@@ -436,11 +467,11 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should update RAs and create new RA for first-service and delete old RA when JWT issuer in JWT Rule for first-service has changed", func() {
 			// given: Cluster state
-			firstServiceRa := getRequestAuthentication("firstRa", "first-service", JwksUri, JwtIssuer)
-			secondServiceRa := getRequestAuthentication("secondRa", "second-service", JwksUri, JwtIssuer)
+			firstServiceRa := getRequestAuthentication("first-service", JwksUri, JwtIssuer)
+			secondServiceRa := getRequestAuthentication("second-service", JwksUri, JwtIssuer)
 			svcFirst := GetService("first-service")
 			svcSecond := GetService("second-service")
-			ctrlClient := GetFakeClient(&firstServiceRa, &secondServiceRa, svcFirst, svcSecond)
+			ctrlClient := GetFakeClient(firstServiceRa, secondServiceRa, svcFirst, svcSecond)
 
 			// given: New resources
 			firstJwtRule := GetJwtRuleWithService("https://new.issuer.com/", JwksUri, "first-service")
@@ -464,11 +495,11 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should delete only first-service RA when it was removed from ApiRule", func() {
 			// given: Cluster state
-			firstServiceRa := getRequestAuthentication("firstRa", "first-service", JwksUri, JwtIssuer)
-			secondServiceRa := getRequestAuthentication("secondRa", "second-service", JwksUri, JwtIssuer)
+			firstServiceRa := getRequestAuthentication("first-service", JwksUri, JwtIssuer)
+			secondServiceRa := getRequestAuthentication("second-service", JwksUri, JwtIssuer)
 			svcFirst := GetService("first-service")
 			svcSecond := GetService("second-service")
-			ctrlClient := GetFakeClient(&firstServiceRa, &secondServiceRa, svcFirst, svcSecond)
+			ctrlClient := GetFakeClient(firstServiceRa, secondServiceRa, svcFirst, svcSecond)
 
 			// given: New resources
 			secondJwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "second-service")
@@ -490,12 +521,12 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should create new RA when it has different service", func() {
 			// given: Cluster state
-			firstServiceRa := getRequestAuthentication("firstRa", "first-service", JwksUri, JwtIssuer)
-			secondServiceRa := getRequestAuthentication("secondRa", "second-service", JwksUri, JwtIssuer)
+			firstServiceRa := getRequestAuthentication("first-service", JwksUri, JwtIssuer)
+			secondServiceRa := getRequestAuthentication("second-service", JwksUri, JwtIssuer)
 			svcFirst := GetService("first-service")
 			svcSecond := GetService("second-service")
 			svcNew := GetService("new-service")
-			ctrlClient := GetFakeClient(&firstServiceRa, &secondServiceRa, svcFirst, svcSecond, svcNew)
+			ctrlClient := GetFakeClient(firstServiceRa, secondServiceRa, svcFirst, svcSecond, svcNew)
 
 			// given: New resources
 			firstJwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "first-service")
@@ -520,10 +551,10 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should delete and create new RA when it has different namespace on spec level", func() {
 			// given: Cluster state
-			oldRa := getRequestAuthentication("firstRa", "old-service", JwksUri, JwtIssuer)
+			oldRa := getRequestAuthentication("old-service", JwksUri, JwtIssuer)
 			svcOld := GetService("old-service")
 			svcNewNS := GetService("old-service", "new-namespace")
-			ctrlClient := GetFakeClient(&oldRa, svcOld, svcNewNS)
+			ctrlClient := GetFakeClient(oldRa, svcOld, svcNewNS)
 
 			// given: New resources
 			jwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "old-service", "new-namespace")
@@ -547,10 +578,10 @@ var _ = Describe("Request Authentication Processor", func() {
 
 		It("should delete and create new RA when it has different namespace on rule level", func() {
 			// given: Cluster state
-			oldRa := getRequestAuthentication("firstRa", "old-service", JwksUri, JwtIssuer)
+			oldRa := getRequestAuthentication("old-service", JwksUri, JwtIssuer)
 			svcOld := GetService("old-service")
 			svcNewNS := GetService("old-service", "new-namespace")
-			ctrlClient := GetFakeClient(&oldRa, svcOld, svcNewNS)
+			ctrlClient := GetFakeClient(oldRa, svcOld, svcNewNS)
 
 			// given: New resources
 			jwtRule := GetJwtRuleWithService(JwtIssuer, JwksUri, "old-service", "new-namespace")
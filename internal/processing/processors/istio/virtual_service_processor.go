@@ -1,8 +1,6 @@
 package istio
 
 import (
-	"fmt"
-
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -15,6 +13,8 @@ import (
 	"github.com/kyma-project/api-gateway/internal/subresources/virtualservice"
 )
 
+const virtualServiceKind = "VirtualService"
+
 // Newv1beta1VirtualServiceProcessor returns a VirtualServiceProcessor with the desired state handling specific for the Istio handler.
 func Newv1beta1VirtualServiceProcessor(config processing.ReconciliationConfig, api *gatewayv1beta1.APIRule, client client.Client) processors.VirtualServiceProcessor {
 	return processors.VirtualServiceProcessor{
@@ -36,10 +36,9 @@ type virtualServiceCreator struct {
 	defaultDomainName string
 }
 
-// Create returns the Virtual Service using the configuration of the APIRule.
+// Create returns the Virtual Service using the configuration of the APIRule. The name of the Virtual Service is
+// derived from the APIRule, since there is only one Virtual Service per APIRule.
 func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv1beta1.VirtualService, error) {
-	virtualServiceNamePrefix := fmt.Sprintf("%s-", api.Name)
-
 	vsSpecBuilder := builders.VirtualServiceSpec()
 	vsSpecBuilder.AddHost(default_domain.GetHostWithDomain(*api.Spec.Host, r.defaultDomainName))
 	vsSpecBuilder.Gateway(*api.Spec.Gateway)
@@ -131,7 +130,7 @@ func (r virtualServiceCreator) Create(api *gatewayv1beta1.APIRule) (*networkingv
 	}
 
 	vsBuilder := builders.VirtualService().
-		GenerateName(virtualServiceNamePrefix).
+		Name(processing.SubresourceName(api.Name, api.Namespace, virtualServiceKind)).
 		Namespace(api.ObjectMeta.Namespace).
 		Label(processing.OwnerLabelName, api.Name).
 		Label(processing.OwnerLabelNamespace, api.Namespace).
@@ -71,8 +71,8 @@ var _ = Describe("Virtual Service Processor", func() {
 				Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 				Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-				Expect(vs.ObjectMeta.Name).To(BeEmpty())
-				Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+				Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+				Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 				Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 				expectLabelsToBeFilled(vs.Labels)
 			})
@@ -263,6 +263,7 @@ var _ = Describe("Virtual Service Processor", func() {
 
 				vs := networkingv1beta1.VirtualService{
 					ObjectMeta: metav1.ObjectMeta{
+						Name: processing.SubresourceName(apiRule.Name, apiRule.Namespace, "VirtualService"),
 						Labels: map[string]string{
 							processing.LegacyOwnerLabel: fmt.Sprintf("%s.%s", apiRule.Name, apiRule.Namespace),
 						},
@@ -312,6 +313,72 @@ var _ = Describe("Virtual Service Processor", func() {
 		})
 	})
 
+	When("virtual services with generated names exist", func() {
+		noopAPIRule := func() *v1beta1.APIRule {
+			noop := []*v1beta1.Authenticator{
+				{
+					Handler: &v1beta1.Handler{
+						Name: "noop",
+					},
+				},
+			}
+			return GetAPIRuleFor([]v1beta1.Rule{GetRuleFor(ApiPath, ApiMethods, []*v1beta1.Mutator{}, noop)})
+		}
+
+		It("should replace a virtual service with a generated name by one with a deterministic name", func() {
+			// given
+			apiRule := noopAPIRule()
+			processor := istio.Newv1beta1VirtualServiceProcessor(GetTestConfig(), apiRule, GetFakeClient())
+			result, err := processor.EvaluateReconciliation(context.Background(), GetFakeClient())
+			Expect(err).To(BeNil())
+			desired := result[0].Obj.(*networkingv1beta1.VirtualService)
+			Expect(desired.Name).To(Equal(processing.SubresourceName(apiRule.Name, apiRule.Namespace, "VirtualService")))
+
+			generated := desired.DeepCopy()
+			generated.Name = apiRule.Name + "-x7k2p"
+			client := GetFakeClient(generated)
+
+			// when
+			processor = istio.Newv1beta1VirtualServiceProcessor(GetTestConfig(), apiRule, client)
+			result, err = processor.EvaluateReconciliation(context.Background(), client)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].Action.String()).To(Equal("create"))
+			Expect(result[0].Obj.GetName()).To(Equal(desired.Name))
+			Expect(result[1].Action.String()).To(Equal("delete"))
+			Expect(result[1].Obj.GetName()).To(Equal(generated.Name))
+		})
+
+		It("should update the virtual service with the deterministic name and delete duplicates", func() {
+			// given
+			apiRule := noopAPIRule()
+			processor := istio.Newv1beta1VirtualServiceProcessor(GetTestConfig(), apiRule, GetFakeClient())
+			result, err := processor.EvaluateReconciliation(context.Background(), GetFakeClient())
+			Expect(err).To(BeNil())
+			desired := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+			duplicate1, duplicate2 := desired.DeepCopy(), desired.DeepCopy()
+			duplicate1.Name = apiRule.Name + "-x7k2p"
+			duplicate2.Name = apiRule.Name + "-q9d4m"
+			client := GetFakeClient(duplicate1, desired.DeepCopy(), duplicate2)
+
+			// when
+			processor = istio.Newv1beta1VirtualServiceProcessor(GetTestConfig(), apiRule, client)
+			result, err = processor.EvaluateReconciliation(context.Background(), client)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].Action.String()).To(Equal("update"))
+			Expect(result[0].Obj.GetName()).To(Equal(desired.Name))
+			Expect(result[1].Action.String()).To(Equal("delete"))
+			Expect(result[2].Action.String()).To(Equal("delete"))
+			Expect([]string{result[1].Obj.GetName(), result[2].Obj.GetName()}).To(ConsistOf(duplicate1.Name, duplicate2.Name))
+		})
+	})
+
 	When("multiple handler", func() {
 		It("should return service for given paths", func() {
 			// given
@@ -397,8 +464,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 			expectLabelsToBeFilled(vs.Labels)
 		})
@@ -476,8 +543,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 			expectLabelsToBeFilled(vs.Labels)
 		})
@@ -567,8 +634,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 			expectLabelsToBeFilled(vs.Labels)
 		})
@@ -636,8 +703,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 			expectLabelsToBeFilled(vs.Labels)
 		})
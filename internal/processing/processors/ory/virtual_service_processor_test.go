@@ -72,8 +72,8 @@ var _ = Describe("Virtual Service Processor", func() {
 				Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 				Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-				Expect(vs.ObjectMeta.Name).To(BeEmpty())
-				Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+				Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+				Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 				Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 			})
 
@@ -261,6 +261,7 @@ var _ = Describe("Virtual Service Processor", func() {
 
 				vs := networkingv1beta1.VirtualService{
 					ObjectMeta: metav1.ObjectMeta{
+						Name: processing.SubresourceName(apiRule.Name, apiRule.Namespace, "VirtualService"),
 						Labels: map[string]string{
 							processing.LegacyOwnerLabel: fmt.Sprintf("%s.%s", apiRule.Name, apiRule.Namespace),
 						},
@@ -395,8 +396,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 		})
 
@@ -474,8 +475,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 		})
 
@@ -564,8 +565,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[1].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 		})
 
@@ -633,8 +634,8 @@ var _ = Describe("Virtual Service Processor", func() {
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(Equal(TestCors.AllowMethods))
 			Expect(vs.Spec.Http[0].CorsPolicy.AllowHeaders).To(Equal(TestCors.AllowHeaders))
 
-			Expect(vs.ObjectMeta.Name).To(BeEmpty())
-			Expect(vs.ObjectMeta.GenerateName).To(Equal(ApiName + "-"))
+			Expect(vs.ObjectMeta.Name).To(HavePrefix(ApiName + "-"))
+			Expect(vs.ObjectMeta.GenerateName).To(BeEmpty())
 			Expect(vs.ObjectMeta.Namespace).To(Equal(ApiNamespace))
 		})
 	})
@@ -27,7 +27,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -58,7 +58,7 @@ var _ = Describe("JwtAuthorization Policy Processor", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -0,0 +1,82 @@
+package authorizationpolicy
+
+import (
+	"strconv"
+
+	"github.com/mitchellh/hashstructure/v2"
+	"istio.io/api/security/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing/hashbasedstate"
+)
+
+// ConsolidationAnnotationName is the APIRule annotation that enables ("true") or disables ("false") the consolidation of
+// AuthorizationPolicies for the APIRule, overriding the global setting.
+const ConsolidationAnnotationName = "gateway.kyma-project.io/consolidate-authorization-policies"
+
+// consolidationEnabled returns whether the AuthorizationPolicies of the APIRule are consolidated.
+func consolidationEnabled(apiRule *gatewayv2alpha1.APIRule, global bool) bool {
+	if enabled, err := strconv.ParseBool(apiRule.Annotations[ConsolidationAnnotationName]); err == nil {
+		return enabled
+	}
+	return global
+}
+
+// consolidate merges the rules of AuthorizationPolicies that have the same namespace, selector, action, provider and
+// sources into a single AuthorizationPolicy. Istio allows a request if any rule of any ALLOW policy of the workload
+// matches, and calls the provider of CUSTOM policies if any of their rules match, so merging the rules of such policies
+// does not change which requests are allowed. Each rule keeps its own operation, including the not paths, and
+// conditions.
+//
+// The order of the policies and rules is kept, so the result is the same for the same APIRule. The consolidated
+// AuthorizationPolicies are identified by the merged namespace, selector, action, provider and sources instead of the
+// operation of their first rule.
+func consolidate(aps []*securityv1beta1.AuthorizationPolicy) ([]*securityv1beta1.AuthorizationPolicy, error) {
+	var consolidated []*securityv1beta1.AuthorizationPolicy
+	byKey := make(map[uint64]*securityv1beta1.AuthorizationPolicy)
+
+	for _, ap := range aps {
+		key, err := consolidationKey(ap)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing, ok := byKey[key]; ok {
+			existing.Spec.Rules = append(existing.Spec.Rules, ap.Spec.Rules...)
+			continue
+		}
+
+		merged := ap.DeepCopy()
+		if err := hashbasedstate.AddLabelsToConsolidatedAuthorizationPolicy(merged, key); err != nil {
+			return nil, err
+		}
+		byKey[key] = merged
+		consolidated = append(consolidated, merged)
+	}
+
+	return consolidated, nil
+}
+
+// consolidationKey identifies the AuthorizationPolicies that can be merged. The rules of a generated
+// AuthorizationPolicy all have the same sources, so only the sources of the first rule are compared.
+func consolidationKey(ap *securityv1beta1.AuthorizationPolicy) (uint64, error) {
+	var from []*v1beta1.Rule_From
+	if len(ap.Spec.Rules) > 0 {
+		from = ap.Spec.Rules[0].From
+	}
+
+	return hashstructure.Hash(struct {
+		Namespace string
+		Selector  map[string]string
+		Action    v1beta1.AuthorizationPolicy_Action
+		Provider  string
+		From      []*v1beta1.Rule_From
+	}{
+		Namespace: ap.Namespace,
+		Selector:  ap.Spec.GetSelector().GetMatchLabels(),
+		Action:    ap.Spec.Action,
+		Provider:  ap.Spec.GetProvider().GetName(),
+		From:      from,
+	}, hashstructure.FormatV2, &hashstructure.HashOptions{SlicesAsSets: true})
+}
@@ -0,0 +1,166 @@
+package authorizationpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/builders/builders_test/v2alpha1_test"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
+)
+
+var _ = Describe("Consolidation", func() {
+	otherService := newServiceBuilder().
+		withName("other-service").
+		withNamespace("example-namespace").
+		addSelector("app", "other-service").
+		build()
+
+	evaluate := func(c client.Client, config processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule) []*processing.ObjectChange {
+		processor := authorizationpolicy.NewProcessor(config, &testLogger, apiRule, newGatewayBuilderWithDummyData().build(), c)
+		result, err := processor.EvaluateReconciliation(context.Background(), c)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	create := func(config processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule) []*securityv1beta1.AuthorizationPolicy {
+		c := getFakeClient(newServiceBuilderWithDummyData().build(), otherService)
+		var aps []*securityv1beta1.AuthorizationPolicy
+		for _, change := range evaluate(c, config, apiRule) {
+			Expect(change.Action.String()).To(Equal("create"))
+			aps = append(aps, change.Obj.(*securityv1beta1.AuthorizationPolicy))
+		}
+		return aps
+	}
+
+	// effectiveRules returns the rules of the AuthorizationPolicies together with the workload, action and provider they
+	// apply to. Istio evaluates the rules of all policies with the same workload, action and provider as a disjunction,
+	// so two sets of AuthorizationPolicies with the same effective rules allow and deny the same requests.
+	effectiveRules := func(aps []*securityv1beta1.AuthorizationPolicy) []string {
+		var rules []string
+		for _, ap := range aps {
+			for _, rule := range ap.Spec.Rules {
+				b, err := proto.MarshalOptions{Deterministic: true}.Marshal(rule)
+				Expect(err).ToNot(HaveOccurred())
+				rules = append(rules, fmt.Sprintf("%s|%v|%s|%s|%x", ap.Namespace, ap.Spec.GetSelector().GetMatchLabels(),
+					ap.Spec.GetAction(), ap.Spec.GetProvider().GetName(), b))
+			}
+		}
+		slices.Sort(rules)
+		return rules
+	}
+
+	jwtRule := func(path string, methods ...gatewayv2alpha1.HttpMethod) *gatewayv2alpha1.Rule {
+		return newJwtRuleBuilderWithDummyData().withPath(path).withMethods(methods...).build()
+	}
+
+	noAuthRule := func(path string, methods ...gatewayv2alpha1.HttpMethod) *gatewayv2alpha1.Rule {
+		return newNoAuthRuleBuilderWithDummyData().withPath(path).withMethods(methods...).build()
+	}
+
+	DescribeTable("should allow the same requests with fewer AuthorizationPolicies",
+		func(expectedAPs int, rules ...*gatewayv2alpha1.Rule) {
+			apiRule := newAPIRuleBuilderWithDummyData().withRules(rules...).build()
+
+			separate := create(processing.ReconciliationConfig{}, apiRule)
+			consolidated := create(processing.ReconciliationConfig{ConsolidateAuthorizationPolicies: true}, apiRule)
+
+			Expect(consolidated).To(HaveLen(expectedAPs))
+			Expect(len(consolidated)).To(BeNumerically("<=", len(separate)))
+			Expect(effectiveRules(consolidated)).To(Equal(effectiveRules(separate)))
+		},
+		Entry("no auth rules for the same service", 1,
+			noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet, http.MethodPost), noAuthRule("/{**}", http.MethodGet)),
+		Entry("JWT and no auth rules for the same service", 2,
+			jwtRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet), jwtRule("/c", http.MethodPost), noAuthRule("/{**}", http.MethodGet)),
+		Entry("rules with not paths", 2,
+			noAuthRule("/a", http.MethodGet), jwtRule("/a/{*}", http.MethodGet), noAuthRule("/a/b", http.MethodGet, http.MethodPut), jwtRule("/{**}", http.MethodGet)),
+		Entry("JWT rules with different issuers", 2,
+			jwtRule("/a", http.MethodGet),
+			newRuleBuilder().withPath("/b").addMethods(http.MethodGet).withServiceName("example-service").withServicePort(8080).
+				addJwtAuthentication("https://other-issuer.example.com/", "https://other-issuer.example.com/jwks.json").build(),
+			jwtRule("/c", http.MethodGet)),
+		Entry("JWT rules with audiences and required scopes", 1,
+			newJwtRuleBuilderWithDummyData().withPath("/a").addJwtAuthorizationAudiences("audience1").build(),
+			newJwtRuleBuilderWithDummyData().withPath("/b").addJwtAuthorizationRequiredScopes("read", "write").build(),
+			newJwtRuleBuilderWithDummyData().withPath("/c").addJwtAuthorization([]string{"admin"}, []string{"audience2"}).addJwtAuthorizationAudiences("audience3").build()),
+		Entry("rules for different services", 2,
+			noAuthRule("/a", http.MethodGet), newNoAuthRuleBuilderWithDummyData().withPath("/b").withMethods(http.MethodGet).withServiceName("other-service").build(), noAuthRule("/c", http.MethodGet)),
+		Entry("ext auth rules", 3,
+			v2alpha1_test.NewRuleBuilder().WithPath("/a").WithMethods(http.MethodGet).
+				WithExtAuth(v2alpha1_test.NewExtAuthBuilder().WithAuthorizers("authorizer").Build()).Build(),
+			v2alpha1_test.NewRuleBuilder().WithPath("/b").WithMethods(http.MethodGet).
+				WithExtAuth(v2alpha1_test.NewExtAuthBuilder().WithAuthorizers("authorizer").Build()).Build(),
+			v2alpha1_test.NewRuleBuilder().WithPath("/c").WithMethods(http.MethodGet).
+				WithExtAuth(v2alpha1_test.NewExtAuthBuilder().WithAuthorizers("other-authorizer").Build()).Build()),
+	)
+
+	It("should not consolidate AuthorizationPolicies by default", func() {
+		apiRule := newAPIRuleBuilderWithDummyData().withRules(noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet)).build()
+
+		Expect(create(processing.ReconciliationConfig{}, apiRule)).To(HaveLen(2))
+	})
+
+	It("should consolidate AuthorizationPolicies if enabled by the APIRule annotation", func() {
+		apiRule := newAPIRuleBuilderWithDummyData().withRules(noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet)).build()
+		apiRule.Annotations = map[string]string{authorizationpolicy.ConsolidationAnnotationName: "true"}
+
+		Expect(create(processing.ReconciliationConfig{}, apiRule)).To(HaveLen(1))
+	})
+
+	It("should not consolidate AuthorizationPolicies if disabled by the APIRule annotation", func() {
+		apiRule := newAPIRuleBuilderWithDummyData().withRules(noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet)).build()
+		apiRule.Annotations = map[string]string{authorizationpolicy.ConsolidationAnnotationName: "false"}
+
+		Expect(create(processing.ReconciliationConfig{ConsolidateAuthorizationPolicies: true}, apiRule)).To(HaveLen(2))
+	})
+
+	It("should update the consolidated AuthorizationPolicy in place when a rule is inserted", func() {
+		// given
+		config := processing.ReconciliationConfig{ConsolidateAuthorizationPolicies: true}
+		c := getFakeClient(newServiceBuilderWithDummyData().build())
+		apiRule := newAPIRuleBuilderWithDummyData().withRules(noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet)).build()
+		existing := create(config, apiRule)
+		Expect(existing).To(HaveLen(1))
+		Expect(c.Create(context.Background(), existing[0])).To(Succeed())
+
+		// when
+		apiRule = newAPIRuleBuilderWithDummyData().withRules(noAuthRule("/new", http.MethodGet), noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet)).build()
+		result := evaluate(c, config, apiRule)
+
+		// then
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Action.String()).To(Equal("update"))
+		Expect(result[0].Obj.GetName()).To(Equal(existing[0].Name))
+		Expect(result[0].Obj.(*securityv1beta1.AuthorizationPolicy).Spec.Rules).To(HaveLen(3))
+	})
+
+	It("should replace the separate AuthorizationPolicies when consolidation is enabled", func() {
+		// given
+		c := getFakeClient(newServiceBuilderWithDummyData().build())
+		apiRule := newAPIRuleBuilderWithDummyData().withRules(noAuthRule("/a", http.MethodGet), noAuthRule("/b", http.MethodGet)).build()
+		for _, ap := range create(processing.ReconciliationConfig{}, apiRule) {
+			Expect(c.Create(context.Background(), ap)).To(Succeed())
+		}
+
+		// when
+		result := evaluate(c, processing.ReconciliationConfig{ConsolidateAuthorizationPolicies: true}, apiRule)
+
+		// then
+		var actions []string
+		for _, change := range result {
+			actions = append(actions, change.Action.String())
+		}
+		// The consolidated AuthorizationPolicy is created before the separate ones are deleted.
+		Expect(actions).To(Equal([]string{"create", "delete", "delete"}))
+	})
+})
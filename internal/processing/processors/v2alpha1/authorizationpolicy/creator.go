@@ -20,7 +20,8 @@ import (
 )
 
 const (
-	audienceKey string = "request.auth.claims[aud]"
+	audienceKey             string = "request.auth.claims[aud]"
+	authorizationPolicyKind string = "AuthorizationPolicy"
 )
 
 var (
@@ -37,25 +38,44 @@ type creator struct {
 	// migrating from APIRule v1beta1 to v2alpha1.
 	oryPassthrough bool
 	gateway        *networkingv1beta1.Gateway
+	// Controls that AuthorizationPolicies are consolidated for APIRules without the ConsolidationAnnotationName
+	// annotation.
+	consolidate bool
 }
 
-// Create returns the AuthorizationPolicy using the configuration of the APIRule.
+// Create returns the AuthorizationPolicy using the configuration of the APIRule. The names of the AuthorizationPolicies
+// are derived from the APIRule and their hash key, so the same AuthorizationPolicy always gets the same name.
+// If consolidation is enabled, AuthorizationPolicies that only differ in their rules are merged.
 func (r creator) Create(ctx context.Context, client client.Client, apiRule *gatewayv2alpha1.APIRule) (hashbasedstate.Desired, error) {
 	state := hashbasedstate.NewDesired()
+	var aps []*securityv1beta1.AuthorizationPolicy
 	for _, rule := range apiRule.Spec.Rules {
 		notPaths := generateNotPaths(apiRule.Spec.Rules, rule)
-		aps, err := r.generateAuthorizationPolicies(ctx, client, apiRule, rule, notPaths)
+		ruleAps, err := r.generateAuthorizationPolicies(ctx, client, apiRule, rule, notPaths)
 		if err != nil {
 			return state, err
 		}
+		aps = append(aps, ruleAps.Items...)
+	}
 
-		for _, ap := range aps.Items {
-			h := hashbasedstate.NewAuthorizationPolicy(ap)
-			err := state.Add(&h)
+	if consolidationEnabled(apiRule, r.consolidate) {
+		consolidated, err := consolidate(aps)
+		if err != nil {
+			return state, err
+		}
+		aps = consolidated
+	}
 
-			if err != nil {
-				return state, err
-			}
+	for _, ap := range aps {
+		h := hashbasedstate.NewAuthorizationPolicy(ap)
+		hashKey, err := hashbasedstate.HashKey(&h)
+		if err != nil {
+			return state, err
+		}
+		ap.Name = processing.SubresourceName(apiRule.Name, apiRule.Namespace, authorizationPolicyKind, hashKey)
+
+		if err := state.Add(&h); err != nil {
+			return state, err
 		}
 	}
 	return state, nil
@@ -149,14 +169,13 @@ func (r creator) generateAuthorizationPolicyForEmptyAuthorizations(ctx context.C
 }
 
 func baseAuthorizationPolicyBuilder(apiRule *gatewayv2alpha1.APIRule, rule gatewayv2alpha1.Rule) (*builders.AuthorizationPolicyBuilder, error) {
-	namePrefix := fmt.Sprintf("%s-", apiRule.Name)
 	namespace, err := gatewayv2alpha1.FindServiceNamespace(apiRule, rule)
 	if err != nil {
 		return nil, fmt.Errorf("finding service namespace: %w", err)
 	}
 
+	// The name is set once the hashing labels are added, since it is derived from them.
 	return builders.NewAuthorizationPolicyBuilder().
-			WithGenerateName(namePrefix).
 			WithNamespace(namespace).
 			WithLabel(processing.OwnerLabelName, apiRule.Name).
 			WithLabel(processing.OwnerLabelNamespace, apiRule.Namespace).
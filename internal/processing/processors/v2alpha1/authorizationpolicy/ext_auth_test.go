@@ -11,6 +11,7 @@ import (
 
 	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	"github.com/kyma-project/api-gateway/internal/builders/builders_test/v2alpha1_test"
+	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
 )
 
@@ -36,7 +37,7 @@ var _ = Describe("Processing ExtAuth rules", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		results, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -94,7 +95,7 @@ var _ = Describe("Processing ExtAuth rules", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		results, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -11,6 +11,7 @@ import (
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
 )
 
@@ -36,7 +37,7 @@ var _ = Describe("Processing JWT rules", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -49,8 +50,8 @@ var _ = Describe("Processing JWT rules", func() {
 		ap2 := result[1].Obj.(*securityv1beta1.AuthorizationPolicy)
 
 		Expect(ap1).NotTo(BeNil())
-		Expect(ap1.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap1.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ap1.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ap1.ObjectMeta.GenerateName).To(BeEmpty())
 		expectLabelsToBeFilled(ap1.Labels)
 
 		Expect(ap1.Spec.Selector.MatchLabels["app"]).NotTo(BeNil())
@@ -74,8 +75,8 @@ var _ = Describe("Processing JWT rules", func() {
 		}
 
 		Expect(ap2).NotTo(BeNil())
-		Expect(ap2.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap2.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ap2.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ap2.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap2.ObjectMeta.Namespace).To(Equal(apiRuleNamespace))
 		expectLabelsToBeFilled(ap2.Labels)
 
@@ -111,7 +112,7 @@ var _ = Describe("Processing JWT rules", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -124,8 +125,8 @@ var _ = Describe("Processing JWT rules", func() {
 		ap2 := result[1].Obj.(*securityv1beta1.AuthorizationPolicy)
 
 		Expect(ap1).NotTo(BeNil())
-		Expect(ap1.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap1.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ap1.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ap1.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap1.ObjectMeta.Namespace).To(Equal(apiRuleNamespace))
 		expectLabelsToBeFilled(ap1.Labels)
 
@@ -147,8 +148,8 @@ var _ = Describe("Processing JWT rules", func() {
 		}
 
 		Expect(ap2).NotTo(BeNil())
-		Expect(ap2.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap2.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ap2.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ap2.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap2.ObjectMeta.Namespace).To(Equal(apiRuleNamespace))
 		expectLabelsToBeFilled(ap2.Labels)
 
@@ -183,7 +184,7 @@ var _ = Describe("Processing JWT rules", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -195,8 +196,8 @@ var _ = Describe("Processing JWT rules", func() {
 		ap := result[0].Obj.(*securityv1beta1.AuthorizationPolicy)
 
 		Expect(ap).NotTo(BeNil())
-		Expect(ap.ObjectMeta.Name).To(BeEmpty())
-		Expect(ap.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ap.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ap.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ap.ObjectMeta.Namespace).To(Equal(apiRuleNamespace))
 		expectLabelsToBeFilled(ap.Labels)
 
@@ -227,8 +228,8 @@ var _ = Describe("Processing JWT rules", func() {
 	When("Two AP for different services with JWT handler exist", func() {
 		It("should update APs and update principal when handler changed for one of the AP to noAuth", func() {
 			// given: Cluster state
-			beingUpdatedAp := getAuthorizationPolicy("being-updated-ap", apiRuleNamespace, "test-service", []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
-			jwtSecuredAp := getAuthorizationPolicy("jwt-secured-ap", apiRuleNamespace, "jwt-secured-service", []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+			beingUpdatedAp := getAuthorizationPolicy(apiRuleNamespace, "test-service", []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+			jwtSecuredAp := getAuthorizationPolicy(apiRuleNamespace, "jwt-secured-service", []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 			svc1 := newServiceBuilder().
 				withName("test-service").
 				withNamespace("example-namespace").
@@ -266,7 +267,7 @@ var _ = Describe("Processing JWT rules", func() {
 				build()
 
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -289,7 +290,7 @@ var _ = Describe("Processing JWT rules", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap1 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -297,7 +298,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap2 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -305,7 +306,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
 			svc := newServiceBuilder().
 				withName(serviceName).
@@ -326,7 +327,7 @@ var _ = Describe("Processing JWT rules", func() {
 				withServiceName(serviceName).
 				withRules(jwtRule).build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -349,7 +350,7 @@ var _ = Describe("Processing JWT rules", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap1 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -357,7 +358,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap2 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -365,7 +366,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
 			svc := newServiceBuilder().
 				withName(serviceName).
@@ -385,7 +386,7 @@ var _ = Describe("Processing JWT rules", func() {
 
 			apiRule := newAPIRuleBuilderWithDummyData().withRules(jwtRule).build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -410,7 +411,7 @@ var _ = Describe("Processing JWT rules", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap1 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -418,7 +419,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap2 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -426,7 +427,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
 			svc := newServiceBuilder().
 				withName(serviceName).
@@ -444,7 +445,7 @@ var _ = Describe("Processing JWT rules", func() {
 
 			apiRule := newAPIRuleBuilderWithDummyData().withRules(jwtRule).build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -467,7 +468,7 @@ var _ = Describe("Processing JWT rules", func() {
 			// given: Cluster state
 			serviceName := "test-service"
 
-			ap1 := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap1 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap1.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -475,7 +476,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 
-			ap2 := getAuthorizationPolicy("ap2", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap2 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap2.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -483,9 +484,9 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap2.Labels["gateway.kyma-project.io/index"] = "1"
+			setIndex(ap2, 1)
 
-			ap3 := getAuthorizationPolicy("ap3", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
+			ap3 := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{"GET"})
 			ap3.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -493,7 +494,7 @@ var _ = Describe("Processing JWT rules", func() {
 				},
 			}
 			// We need to set the index to 1 as this is expected to be the second authorization configured in the rule.
-			ap3.Labels["gateway.kyma-project.io/index"] = "2"
+			setIndex(ap3, 2)
 
 			svc := newServiceBuilder().
 				withName(serviceName).
@@ -512,7 +513,7 @@ var _ = Describe("Processing JWT rules", func() {
 
 			apiRule := newAPIRuleBuilderWithDummyData().withRules(jwtRule).build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
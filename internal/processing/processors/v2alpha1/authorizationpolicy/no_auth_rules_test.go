@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
+	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
 )
 
@@ -34,7 +35,7 @@ var _ = Describe("Processing NoAuth rules", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		results, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -16,10 +16,13 @@ import (
 )
 
 // NewProcessor returns a Processor with the desired state handling for AuthorizationPolicy.
-func NewProcessor(log *logr.Logger, rule *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, client ctrlclient.Client) Processor {
+func NewProcessor(config processing.ReconciliationConfig, log *logr.Logger, rule *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, client ctrlclient.Client) Processor {
 	return Processor{
-		apiRule:    rule,
-		creator:    creator{gateway: gateway},
+		apiRule: rule,
+		creator: creator{
+			gateway:     gateway,
+			consolidate: config.ConsolidateAuthorizationPolicies,
+		},
 		Log:        log,
 		repository: authorizationpolicy.NewRepository(client),
 	}
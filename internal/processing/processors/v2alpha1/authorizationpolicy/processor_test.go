@@ -31,7 +31,7 @@ var _ = Describe("Processing", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -58,7 +58,7 @@ var _ = Describe("Processing", func() {
 		svc := newServiceBuilderWithDummyData().build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -99,7 +99,7 @@ var _ = Describe("Processing", func() {
 			build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -137,7 +137,7 @@ var _ = Describe("Processing", func() {
 			build()
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -169,7 +169,7 @@ var _ = Describe("Processing", func() {
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(svc)
 
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -183,7 +183,7 @@ var _ = Describe("Processing", func() {
 
 	It("should update AP when path, methods and service name didn't change", func() {
 		// given: Cluster state
-		existingAp := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+		existingAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 
 		// given: New resources
 		rule := newJwtRuleBuilderWithDummyData().
@@ -196,7 +196,7 @@ var _ = Describe("Processing", func() {
 		gateway := newGatewayBuilderWithDummyData().build()
 		client := getFakeClient(existingAp, svc)
 
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -212,14 +212,14 @@ var _ = Describe("Processing", func() {
 
 	It("should delete AP when there is no desired AP", func() {
 		//given: Cluster state
-		existingAp := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+		existingAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 
 		// given: New resources
 		apiRule := newAPIRuleBuilderWithDummyData().build()
 		svc := newServiceBuilderWithDummyData().build()
 		ctrlClient := getFakeClient(existingAp, svc)
 		gateway := newGatewayBuilderWithDummyData().build()
-		processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 		// when
 		result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -233,9 +233,9 @@ var _ = Describe("Processing", func() {
 	})
 
 	When("AP with RuleTo exists", func() {
-		It("should create new AP and delete old AP when new rule with same methods and service but different path is added to ApiRule", func() {
+		It("should create new AP and update existing AP with not paths when new rule with same methods and service but different path is added to ApiRule", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+			existingAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 			svc := newServiceBuilderWithDummyData().build()
 			ctrlClient := getFakeClient(existingAp, svc)
 
@@ -251,24 +251,24 @@ var _ = Describe("Processing", func() {
 				withRules(existingRule, newRule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
 
 			// then
 			Expect(err).To(BeNil())
-			Expect(result).To(HaveLen(3))
+			Expect(result).To(HaveLen(2))
 
-			newApMatcher := getActionMatcher("create", apiRuleNamespace, serviceName, "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet, http.MethodPost), ContainElements("/"), ContainElements("/new-path"))
-			newApMatcher2 := getActionMatcher("create", apiRuleNamespace, serviceName, "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet, http.MethodPost), ContainElements("/new-path"), BeNil())
-			deleteApMatcher := getActionMatcher("delete", apiRuleNamespace, serviceName, "RequestPrincipals", ContainElements("*"), ContainElements(http.MethodGet, http.MethodPost), ContainElements("/"), BeNil())
-			Expect(result).To(ContainElements(newApMatcher, newApMatcher2, deleteApMatcher))
+			// The not paths are not part of the identity of the AP, so the existing AP is updated in place.
+			updatedApMatcher := getActionMatcher("update", apiRuleNamespace, serviceName, "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet, http.MethodPost), ContainElements("/"), ContainElements("/new-path"))
+			newApMatcher := getActionMatcher("create", apiRuleNamespace, serviceName, "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet, http.MethodPost), ContainElements("/new-path"), BeNil())
+			Expect(result).To(ContainElements(updatedApMatcher, newApMatcher))
 		})
 
 		It("should create new AP and update existing AP when new rule with same path and service but different methods is added to ApiRule", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+			existingAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 			svc := newServiceBuilderWithDummyData().build()
 			ctrlClient := getFakeClient(existingAp, svc)
 
@@ -283,7 +283,7 @@ var _ = Describe("Processing", func() {
 				withRules(existingRule, newRule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -301,7 +301,7 @@ var _ = Describe("Processing", func() {
 
 		It("should create new AP and update existing AP when new rule with same path and methods, but different service is added to ApiRule", func() {
 			//given: Cluster state
-			existingAp := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+			existingAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 			// given: New resources
 			existingRule := newJwtRuleBuilderWithDummyData().
 				withMethods(http.MethodGet, http.MethodPost).
@@ -321,7 +321,7 @@ var _ = Describe("Processing", func() {
 				build()
 			ctrlClient := getFakeClient(existingAp, svc1, svc2)
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -339,7 +339,7 @@ var _ = Describe("Processing", func() {
 
 		It("should recreate AP when path in ApiRule changed", func() {
 			// given: Cluster state
-			existingAp := getAuthorizationPolicy("ap1", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
+			existingAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet, http.MethodPost})
 			svc := newServiceBuilderWithDummyData().build()
 			ctrlClient := getFakeClient(existingAp, svc)
 
@@ -352,7 +352,7 @@ var _ = Describe("Processing", func() {
 				withRules(rule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -369,10 +369,10 @@ var _ = Describe("Processing", func() {
 	})
 
 	When("Two AP with different methods for same path and service exist", func() {
-		It("should delete and create AP, when path has changed", func() {
+		It("should delete and create AP when path has changed and update the AP with not paths", func() {
 			// given: Cluster state
-			unchangedAp := getAuthorizationPolicy("unchanged-ap", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodDelete})
-			toBeUpdateAp := getAuthorizationPolicy("to-be-updated-ap", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet})
+			unchangedAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodDelete})
+			toBeUpdateAp := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet})
 			svc := newServiceBuilderWithDummyData().build()
 			ctrlClient := getFakeClient(toBeUpdateAp, unchangedAp, svc)
 
@@ -388,7 +388,7 @@ var _ = Describe("Processing", func() {
 				withRules(unchangedRule, updatedRule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -407,7 +407,7 @@ var _ = Describe("Processing", func() {
 	When("Namespace changes", func() {
 		It("should create new AP in new namespace and delete old AP when namespace is on APIRule spec level", func() {
 			// given: Cluster state
-			oldAP := getAuthorizationPolicy("unchanged-ap", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodDelete})
+			oldAP := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodDelete})
 
 			svc := newServiceBuilderWithDummyData().build()
 			specNewServiceNamespace := "new-namespace"
@@ -428,7 +428,7 @@ var _ = Describe("Processing", func() {
 				withServiceNamespace(specNewServiceNamespace).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -444,7 +444,7 @@ var _ = Describe("Processing", func() {
 
 		It("should create new AP in new namespace and delete old AP when namespace on rule level", func() {
 			// given: Cluster state
-			oldAP := getAuthorizationPolicy("unchanged-ap", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodDelete})
+			oldAP := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodDelete})
 			ruleServiceNamespace := "new-namespace"
 			svc := newServiceBuilderWithDummyData().
 				withNamespace(ruleServiceNamespace).
@@ -461,7 +461,7 @@ var _ = Describe("Processing", func() {
 				withRules(movedRule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -477,10 +477,10 @@ var _ = Describe("Processing", func() {
 	})
 
 	When("Two AP with same RuleTo for different services exist", func() {
-		It("should delete and create AP, when path has changed", func() {
+		It("should delete and create AP when path has changed and update the AP with not paths", func() {
 			// given: Cluster state
-			unchangedAp := getAuthorizationPolicy("unchanged-ap", apiRuleNamespace, "first-service", []string{"example-host.example.com"}, []string{http.MethodGet})
-			toBeUpdateAp := getAuthorizationPolicy("to-be-updated-ap", apiRuleNamespace, "second-service", []string{"example-host.example.com"}, []string{http.MethodGet})
+			unchangedAp := getAuthorizationPolicy(apiRuleNamespace, "first-service", []string{"example-host.example.com"}, []string{http.MethodGet})
+			toBeUpdateAp := getAuthorizationPolicy(apiRuleNamespace, "second-service", []string{"example-host.example.com"}, []string{http.MethodGet})
 			svc1 := newServiceBuilder().
 				withName("first-service").
 				withNamespace(apiRuleNamespace).
@@ -506,20 +506,19 @@ var _ = Describe("Processing", func() {
 				withRules(unchangedRule, updatedRule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
 
 			// then
 			Expect(err).To(BeNil())
-			Expect(result).To(HaveLen(4))
+			Expect(result).To(HaveLen(3))
 
 			createdApMatcher := getActionMatcher("create", apiRuleNamespace, "second-service", "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet), ContainElements("/new-path"), BeNil())
-			createdApMatcher2 := getActionMatcher("create", apiRuleNamespace, "first-service", "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet), ContainElements("/"), ContainElements("/new-path"))
+			updatedApMatcher := getActionMatcher("update", apiRuleNamespace, "first-service", "RequestPrincipals", ContainElements("https://oauth2.example.com//*"), ContainElements(http.MethodGet), ContainElements("/"), ContainElements("/new-path"))
 			deleteMatcher := getActionMatcher("delete", apiRuleNamespace, "second-service", "RequestPrincipals", ContainElements("*"), ContainElements(http.MethodGet), ContainElements("/"), BeNil())
-			deleteMatcher2 := getActionMatcher("delete", apiRuleNamespace, "first-service", "RequestPrincipals", ContainElements("*"), ContainElements(http.MethodGet), ContainElements("/"), BeNil())
-			Expect(result).To(ContainElements(createdApMatcher, createdApMatcher2, deleteMatcher, deleteMatcher2))
+			Expect(result).To(ContainElements(createdApMatcher, updatedApMatcher, deleteMatcher))
 		})
 	})
 
@@ -538,7 +537,7 @@ var _ = Describe("Processing", func() {
 			client := getFakeClient(svc)
 			gateway := newGatewayBuilderWithDummyData().build()
 
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -573,7 +572,7 @@ var _ = Describe("Processing", func() {
 			client := getFakeClient(svc)
 			gateway := newGatewayBuilderWithDummyData().build()
 
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -605,7 +604,7 @@ var _ = Describe("Processing", func() {
 			client := getFakeClient(svc)
 			gateway := newGatewayBuilderWithDummyData().build()
 
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, client)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, client)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), client)
@@ -630,7 +629,7 @@ var _ = Describe("Processing", func() {
 			// given: Cluster state
 			serviceName := serviceName
 
-			ap := getAuthorizationPolicy("ap", apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet})
+			ap := getAuthorizationPolicy(apiRuleNamespace, serviceName, []string{"example-host.example.com"}, []string{http.MethodGet})
 			ap.Spec.Rules[0].When = []*v1beta1.Condition{
 				{
 					Key:    "request.auth.claims[aud]",
@@ -653,7 +652,7 @@ var _ = Describe("Processing", func() {
 				withRules(rule).
 				build()
 			gateway := newGatewayBuilderWithDummyData().build()
-			processor := authorizationpolicy.NewProcessor(&testLogger, apiRule, gateway, ctrlClient)
+			processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, gateway, ctrlClient)
 
 			// when
 			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
@@ -0,0 +1,129 @@
+package authorizationpolicy_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
+)
+
+var _ = Describe("Rule order", func() {
+	jwtRule := func(path string) *gatewayv2alpha1.Rule {
+		return newJwtRuleBuilderWithDummyData().withPath(path).withMethods(http.MethodGet).build()
+	}
+
+	noAuthRule := func(path string) *gatewayv2alpha1.Rule {
+		return newNoAuthRuleBuilderWithDummyData().withPath(path).withMethods(http.MethodGet).build()
+	}
+
+	evaluate := func(c client.Client, rules ...*gatewayv2alpha1.Rule) []*processing.ObjectChange {
+		apiRule := newAPIRuleBuilderWithDummyData().withRules(rules...).build()
+		processor := authorizationpolicy.NewProcessor(processing.ReconciliationConfig{}, &testLogger, apiRule, newGatewayBuilderWithDummyData().build(), c)
+
+		result, err := processor.EvaluateReconciliation(context.Background(), c)
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	// reconciled returns a client with the AuthorizationPolicies created for the given rules.
+	reconciled := func(rules ...*gatewayv2alpha1.Rule) client.Client {
+		c := getFakeClient(newServiceBuilderWithDummyData().build())
+		for _, change := range evaluate(c, rules...) {
+			Expect(change.Action.String()).To(Equal("create"))
+			Expect(c.Create(context.Background(), change.Obj)).To(Succeed())
+		}
+		return c
+	}
+
+	actions := func(changes []*processing.ObjectChange) map[string][]string {
+		result := map[string][]string{}
+		for _, change := range changes {
+			ap := change.Obj.(*securityv1beta1.AuthorizationPolicy)
+			path := ap.Spec.Rules[0].To[0].Operation.Paths[0]
+			result[change.Action.String()] = append(result[change.Action.String()], path)
+		}
+		return result
+	}
+
+	notPaths := func(changes []*processing.ObjectChange, path string) []string {
+		for _, change := range changes {
+			ap := change.Obj.(*securityv1beta1.AuthorizationPolicy)
+			if ap.Spec.Rules[0].To[0].Operation.Paths[0] == path {
+				return ap.Spec.Rules[0].To[0].Operation.NotPaths
+			}
+		}
+		return nil
+	}
+
+	It("should only create the AuthorizationPolicy of a rule inserted before other rules", func() {
+		// given
+		c := reconciled(jwtRule("/a"), noAuthRule("/b"), jwtRule("/c"))
+
+		// when
+		result := evaluate(c, noAuthRule("/new"), jwtRule("/a"), noAuthRule("/b"), jwtRule("/c"))
+
+		// then
+		Expect(actions(result)).To(HaveLen(2))
+		Expect(actions(result)["create"]).To(ConsistOf("/new"))
+		Expect(actions(result)["update"]).To(ConsistOf("/a", "/b", "/c"))
+		Expect(notPaths(result, "/c")).To(ConsistOf("/new", "/a", "/b"))
+	})
+
+	It("should only update the AuthorizationPolicies when rules are reordered", func() {
+		// given
+		c := reconciled(jwtRule("/a"), noAuthRule("/b"), jwtRule("/c"))
+
+		// when
+		result := evaluate(c, jwtRule("/c"), jwtRule("/a"), noAuthRule("/b"))
+
+		// then
+		Expect(actions(result)).To(HaveLen(1))
+		Expect(actions(result)["update"]).To(ConsistOf("/a", "/b", "/c"))
+		Expect(notPaths(result, "/c")).To(BeEmpty())
+		Expect(notPaths(result, "/b")).To(ConsistOf("/c", "/a"))
+	})
+
+	It("should only delete the AuthorizationPolicy of a removed rule", func() {
+		// given
+		c := reconciled(jwtRule("/a"), noAuthRule("/b"), jwtRule("/c"))
+
+		// when
+		result := evaluate(c, noAuthRule("/b"), jwtRule("/c"))
+
+		// then
+		Expect(actions(result)).To(HaveLen(2))
+		Expect(actions(result)["delete"]).To(ConsistOf("/a"))
+		Expect(actions(result)["update"]).To(ConsistOf("/b", "/c"))
+		Expect(notPaths(result, "/c")).To(ConsistOf("/b"))
+	})
+
+	It("should keep the names of the AuthorizationPolicies when rules are inserted", func() {
+		// given
+		c := reconciled(jwtRule("/a"), noAuthRule("/b"))
+		var existing securityv1beta1.AuthorizationPolicyList
+		Expect(c.List(context.Background(), &existing)).To(Succeed())
+		var names []string
+		for _, ap := range existing.Items {
+			names = append(names, ap.Name)
+		}
+
+		// when
+		result := evaluate(c, noAuthRule("/new"), jwtRule("/a"), noAuthRule("/b"))
+
+		// then
+		var updated []string
+		for _, change := range result {
+			if change.Action.String() == "update" {
+				updated = append(updated, change.Obj.GetName())
+			}
+		}
+		Expect(updated).To(ConsistOf(names))
+	})
+})
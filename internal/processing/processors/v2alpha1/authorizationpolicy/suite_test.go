@@ -2,6 +2,7 @@ package authorizationpolicy_test
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/kyma-project/api-gateway/internal/processing"
@@ -41,10 +42,9 @@ var serviceName = "example-service"
 var testLogger = ctrl.Log.WithName("istio-test")
 var testExpectedScopeKeys = []string{"request.auth.claims[scp]", "request.auth.claims[scope]", "request.auth.claims[scopes]"}
 
-var getAuthorizationPolicy = func(name string, namespace string, serviceName string, hosts, methods []string) *securityv1beta1.AuthorizationPolicy {
+var getAuthorizationPolicy = func(namespace string, serviceName string, hosts, methods []string) *securityv1beta1.AuthorizationPolicy {
 	ap := securityv1beta1.AuthorizationPolicy{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
 			Namespace: namespace,
 			Labels: map[string]string{
 				processing.LegacyOwnerLabel: fmt.Sprintf("%s.%s", apiRuleName, apiRuleNamespace),
@@ -82,11 +82,21 @@ var getAuthorizationPolicy = func(name string, namespace string, serviceName str
 	apHash, err := hashbasedstate.GetAuthorizationPolicyHash(&ap)
 	Expect(err).ShouldNot(HaveOccurred())
 	ap.Labels["gateway.kyma-project.io/hash"] = apHash
-	ap.Labels["gateway.kyma-project.io/index"] = "0"
+	setIndex(&ap, 0)
 
 	return &ap
 }
 
+// setIndex sets the index label of the AuthorizationPolicy and the name that is derived from it.
+func setIndex(ap *securityv1beta1.AuthorizationPolicy, index int) {
+	ap.Labels["gateway.kyma-project.io/index"] = strconv.Itoa(index)
+
+	h := hashbasedstate.NewAuthorizationPolicy(ap)
+	hashKey, err := hashbasedstate.HashKey(&h)
+	Expect(err).ShouldNot(HaveOccurred())
+	ap.Name = processing.SubresourceName(apiRuleName, apiRuleNamespace, "AuthorizationPolicy", hashKey)
+}
+
 var getActionMatcher = func(action string, namespace string, serviceName string, principalsName string, principals types.GomegaMatcher, methods types.GomegaMatcher, paths types.GomegaMatcher, notPaths types.GomegaMatcher) types.GomegaMatcher {
 	return PointTo(MatchFields(IgnoreExtras, Fields{
 		"Action": WithTransform(ActionToString, Equal(action)),
@@ -0,0 +1,134 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+)
+
+const (
+	networkPolicyKind = "NetworkPolicy"
+
+	// istioTLSModeLabel is set by the Istio sidecar injection on all Pods with a sidecar.
+	istioTLSModeLabel = "security.istio.io/tlsMode"
+)
+
+// defaultIngressGatewaySelector are the labels of the Istio ingress gateway that are used if the Gateway of the APIRule
+// doesn't define a selector.
+var defaultIngressGatewaySelector = map[string]string{
+	"app":   "istio-ingressgateway",
+	"istio": "ingressgateway",
+}
+
+type creator struct {
+	// enabled is the global setting, which can be overridden per APIRule with an annotation.
+	enabled          bool
+	allowMeshTraffic bool
+	gateway          *networkingv1beta1.Gateway
+}
+
+// Create returns the NetworkPolicies of the workloads exposed by the APIRule by their name. The workloads are selected
+// by the selectors of the Services of the rules, so one NetworkPolicy is created for each distinct Service selector.
+// No NetworkPolicy is created for Services without a selector, because a NetworkPolicy with an empty Pod selector would
+// restrict all Pods of the namespace.
+func (c creator) Create(ctx context.Context, k8sClient client.Client, apiRule *gatewayv2alpha1.APIRule) (map[string]*networkingv1.NetworkPolicy, error) {
+	policies := make(map[string]*networkingv1.NetworkPolicy)
+	if !enabled(apiRule, c.enabled) || apiRule.DeletionTimestamp != nil {
+		return policies, nil
+	}
+
+	for _, rule := range apiRule.Spec.Rules {
+		podSelector, err := gatewayv2alpha1.GetSelectorFromService(ctx, k8sClient, apiRule, rule)
+		if err != nil {
+			return nil, err
+		}
+		if podSelector.Selector == nil || len(podSelector.Selector.MatchLabels) == 0 {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%s", podSelector.Namespace, getSelectorsKey(podSelector.Selector.MatchLabels))
+		name := processing.SubresourceName(apiRule.Name, apiRule.Namespace, networkPolicyKind, key)
+		if _, ok := policies[name]; ok {
+			continue
+		}
+		policies[name] = c.networkPolicy(apiRule, name, podSelector)
+	}
+
+	return policies, nil
+}
+
+func (c creator) networkPolicy(apiRule *gatewayv2alpha1.APIRule, name string, podSelector gatewayv2alpha1.PodSelector) *networkingv1.NetworkPolicy {
+	// Istio applies the selector of a Gateway to the Pods in all namespaces, so the ingress gateway Pods are selected in
+	// all namespaces as well.
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: c.ingressGatewaySelector(),
+			},
+		},
+	}
+	if c.allowMeshTraffic {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{istioTLSModeLabel: "istio"},
+			},
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+			Kind:       networkPolicyKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: podSelector.Namespace,
+			Labels: map[string]string{
+				processing.OwnerLabelName:       apiRule.Name,
+				processing.OwnerLabelNamespace:  apiRule.Namespace,
+				processing.ModuleLabelKey:       processing.ApiGatewayLabelValue,
+				processing.K8sManagedByLabelKey: processing.ApiGatewayLabelValue,
+				processing.K8sComponentLabelKey: processing.ApiGatewayLabelValue,
+				processing.K8sPartOfLabelKey:    processing.ApiGatewayLabelValue,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: maps.Clone(podSelector.Selector.MatchLabels),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: peers},
+			},
+		},
+	}
+}
+
+// ingressGatewaySelector returns the labels of the Istio ingress gateway Pods that serve the Gateway of the APIRule.
+func (c creator) ingressGatewaySelector() map[string]string {
+	if c.gateway != nil && len(c.gateway.Spec.Selector) > 0 {
+		return maps.Clone(c.gateway.Spec.Selector)
+	}
+	return maps.Clone(defaultIngressGatewaySelector)
+}
+
+func getSelectorsKey(labels map[string]string) string {
+	// The keys are sorted, because the key of a NetworkPolicy is used to derive its name.
+	var selectors []string
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", key, labels[key]))
+	}
+	return strings.Join(selectors, ",")
+}
@@ -0,0 +1,90 @@
+package networkpolicy
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/subresources/networkpolicy"
+)
+
+// AnnotationName is the APIRule annotation that enables ("true") or disables ("false") the NetworkPolicies of the
+// workloads exposed by the APIRule, overriding the global setting.
+const AnnotationName = "gateway.kyma-project.io/backend-network-policy"
+
+// NewProcessor returns a Processor with the desired state handling for the NetworkPolicies of the workloads exposed by
+// the APIRule.
+func NewProcessor(config processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, client ctrlclient.Client) Processor {
+	return Processor{
+		apiRule: apiRule,
+		creator: creator{
+			enabled:          config.BackendNetworkPolicies,
+			allowMeshTraffic: config.BackendNetworkPoliciesAllowMeshTraffic,
+			gateway:          gateway,
+		},
+		repository: networkpolicy.NewRepository(client),
+	}
+}
+
+// Processor handles the NetworkPolicies that restrict the ingress traffic of the workloads exposed by an APIRule to the
+// Istio ingress gateway. The NetworkPolicies of the APIRule are deleted if the NetworkPolicies are disabled.
+type Processor struct {
+	apiRule    *gatewayv2alpha1.APIRule
+	creator    creator
+	repository networkpolicy.Repository
+}
+
+func (p Processor) EvaluateReconciliation(ctx context.Context, client ctrlclient.Client) ([]*processing.ObjectChange, error) {
+	desired, err := p.creator.Create(ctx, client, p.apiRule)
+	if err != nil {
+		return make([]*processing.ObjectChange, 0), err
+	}
+
+	actual, err := p.repository.GetAll(ctx, p.apiRule)
+	if err != nil {
+		return make([]*processing.ObjectChange, 0), err
+	}
+
+	return getObjectChanges(desired, actual), nil
+}
+
+// enabled returns whether NetworkPolicies are created for the workloads exposed by the APIRule.
+func enabled(apiRule *gatewayv2alpha1.APIRule, global bool) bool {
+	if enabled, err := strconv.ParseBool(apiRule.Annotations[AnnotationName]); err == nil {
+		return enabled
+	}
+	return global
+}
+
+func getObjectChanges(desired map[string]*networkingv1.NetworkPolicy, actual []*networkingv1.NetworkPolicy) []*processing.ObjectChange {
+	var changes []*processing.ObjectChange
+
+	existing := make(map[string]*networkingv1.NetworkPolicy)
+	for _, policy := range actual {
+		if _, ok := desired[policy.Name]; ok && policy.Namespace == desired[policy.Name].Namespace {
+			existing[policy.Name] = policy
+			continue
+		}
+		changes = append(changes, processing.NewObjectDeleteAction(policy))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		policy := desired[name]
+		if kept, ok := existing[name]; ok {
+			kept.Spec = *policy.Spec.DeepCopy()
+			kept.Labels = policy.Labels
+			changes = append(changes, processing.NewObjectUpdateAction(kept))
+		} else {
+			changes = append(changes, processing.NewObjectCreateAction(policy))
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,238 @@
+package networkpolicy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/networkpolicy"
+)
+
+var _ = Describe("Processor", func() {
+	enabledConfig := processing.ReconciliationConfig{BackendNetworkPolicies: true}
+
+	It("should not create a NetworkPolicy if NetworkPolicies are disabled", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		processor := networkpolicy.NewProcessor(processing.ReconciliationConfig{}, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("should create a NetworkPolicy that only allows ingress traffic from the ingress gateway", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"), newRule("/headers"))
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		processor := networkpolicy.NewProcessor(enabledConfig, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action.String()).To(Equal("create"))
+
+		policy := changes[0].Obj.(*networkingv1.NetworkPolicy)
+		Expect(policy.Namespace).To(Equal(apiRuleNamespace))
+		Expect(policy.Labels).To(HaveKeyWithValue(processing.OwnerLabelName, apiRuleName))
+		Expect(policy.Labels).To(HaveKeyWithValue(processing.OwnerLabelNamespace, apiRuleNamespace))
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"app": serviceName}))
+		Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+		Expect(policy.Spec.Ingress).To(HaveLen(1))
+		Expect(policy.Spec.Ingress[0].From).To(HaveLen(1))
+		Expect(policy.Spec.Ingress[0].From[0].NamespaceSelector).ToNot(BeNil())
+		Expect(policy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels).To(BeEmpty())
+		Expect(policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels).To(Equal(map[string]string{"app": "istio-ingressgateway", "istio": "ingressgateway"}))
+	})
+
+	It("should use the selector of the Gateway and allow mesh traffic if configured", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		gateway := &networkingv1beta1.Gateway{}
+		gateway.Spec.Selector = map[string]string{"istio": "private-ingressgateway"}
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		config := processing.ReconciliationConfig{BackendNetworkPolicies: true, BackendNetworkPoliciesAllowMeshTraffic: true}
+		processor := networkpolicy.NewProcessor(config, apiRule, gateway, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+
+		policy := changes[0].Obj.(*networkingv1.NetworkPolicy)
+		Expect(policy.Spec.Ingress[0].From).To(HaveLen(2))
+		Expect(policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels).To(Equal(map[string]string{"istio": "private-ingressgateway"}))
+		Expect(policy.Spec.Ingress[0].From[1].NamespaceSelector.MatchLabels).To(BeEmpty())
+		Expect(policy.Spec.Ingress[0].From[1].PodSelector.MatchLabels).To(Equal(map[string]string{"security.istio.io/tlsMode": "istio"}))
+	})
+
+	It("should allow ingress traffic from the ingress gateway Pods of a Gateway outside the istio-system namespace", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		gateway := &networkingv1beta1.Gateway{}
+		gateway.Namespace = "custom-gateways"
+		gateway.Spec.Selector = map[string]string{"app": "custom-ingressgateway"}
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		processor := networkpolicy.NewProcessor(enabledConfig, apiRule, gateway, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+
+		policy := changes[0].Obj.(*networkingv1.NetworkPolicy)
+		Expect(policy.Spec.Ingress[0].From).To(HaveLen(1))
+		Expect(policy.Spec.Ingress[0].From[0].NamespaceSelector).To(Equal(&metav1.LabelSelector{}))
+		Expect(policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels).To(Equal(map[string]string{"app": "custom-ingressgateway"}))
+	})
+
+	It("should create a NetworkPolicy for each Service of the rules", func() {
+		// given
+		otherRule := newRule("/other")
+		otherRule.Service = &gatewayv2alpha1.Service{
+			Name:      ptr.To("other-service"),
+			Namespace: ptr.To("other-namespace"),
+			Port:      ptr.To(uint32(8080)),
+		}
+		apiRule := newAPIRule(newRule("/"), otherRule)
+		k8sClient := getFakeClient(
+			newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}),
+			newService("other-service", "other-namespace", map[string]string{"app": "other"}),
+		)
+		processor := networkpolicy.NewProcessor(enabledConfig, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+
+		var namespaces []string
+		for _, change := range changes {
+			namespaces = append(namespaces, change.Obj.GetNamespace())
+		}
+		Expect(namespaces).To(ConsistOf(apiRuleNamespace, "other-namespace"))
+	})
+
+	It("should not create a NetworkPolicy for a Service without a selector", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, nil))
+		processor := networkpolicy.NewProcessor(enabledConfig, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("should override the global setting with the annotation of the APIRule", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		apiRule.Annotations = map[string]string{networkpolicy.AnnotationName: "true"}
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		processor := networkpolicy.NewProcessor(processing.ReconciliationConfig{}, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+	})
+
+	It("should update the existing NetworkPolicy", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		existing := createNetworkPolicy(k8sClient, enabledConfig, apiRule)
+
+		config := processing.ReconciliationConfig{BackendNetworkPolicies: true, BackendNetworkPoliciesAllowMeshTraffic: true}
+		processor := networkpolicy.NewProcessor(config, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action.String()).To(Equal("update"))
+		Expect(changes[0].Obj.GetName()).To(Equal(existing.Name))
+		Expect(changes[0].Obj.(*networkingv1.NetworkPolicy).Spec.Ingress[0].From).To(HaveLen(2))
+	})
+
+	It("should delete the NetworkPolicy of a Service that is no longer exposed", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		k8sClient := getFakeClient(
+			newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}),
+			newService("new-service", apiRuleNamespace, map[string]string{"app": "new"}),
+		)
+		existing := createNetworkPolicy(k8sClient, enabledConfig, apiRule)
+
+		apiRule.Spec.Service.Name = ptr.To("new-service")
+		processor := networkpolicy.NewProcessor(enabledConfig, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].Action.String()).To(Equal("delete"))
+		Expect(changes[0].Obj.GetName()).To(Equal(existing.Name))
+		Expect(changes[1].Action.String()).To(Equal("create"))
+		Expect(changes[1].Obj.(*networkingv1.NetworkPolicy).Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"app": "new"}))
+	})
+
+	It("should delete the NetworkPolicy if NetworkPolicies are disabled by the annotation", func() {
+		// given
+		apiRule := newAPIRule(newRule("/"))
+		k8sClient := getFakeClient(newService(serviceName, apiRuleNamespace, map[string]string{"app": serviceName}))
+		existing := createNetworkPolicy(k8sClient, enabledConfig, apiRule)
+
+		apiRule.Annotations = map[string]string{networkpolicy.AnnotationName: "false"}
+		processor := networkpolicy.NewProcessor(enabledConfig, apiRule, nil, k8sClient)
+
+		// when
+		changes, err := processor.EvaluateReconciliation(context.Background(), k8sClient)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Action.String()).To(Equal("delete"))
+		Expect(changes[0].Obj.GetName()).To(Equal(existing.Name))
+	})
+})
+
+func createNetworkPolicy(k8sClient client.Client, config processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule) *networkingv1.NetworkPolicy {
+	changes, err := networkpolicy.NewProcessor(config, apiRule, nil, k8sClient).EvaluateReconciliation(context.Background(), k8sClient)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(changes).To(HaveLen(1))
+
+	policy := changes[0].Obj.(*networkingv1.NetworkPolicy)
+	Expect(k8sClient.Create(context.Background(), policy)).To(Succeed())
+	return policy
+}
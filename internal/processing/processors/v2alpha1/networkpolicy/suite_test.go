@@ -0,0 +1,83 @@
+package networkpolicy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	ginkgotypes "github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/tests"
+)
+
+func Test(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "networkpolicy v2alpha1 Suite")
+}
+
+var _ = ReportAfterSuite("custom reporter", func(report ginkgotypes.Report) {
+	tests.GenerateGinkgoJunitReport("networkpolicy-v2alpha1-suite", report)
+})
+
+const (
+	apiRuleName      = "test-apirule"
+	apiRuleNamespace = "example-namespace"
+	serviceName      = "example-service"
+)
+
+func getFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+	Expect(gatewayv2alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(networkingv1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newAPIRule(rules ...gatewayv2alpha1.Rule) *gatewayv2alpha1.APIRule {
+	return &gatewayv2alpha1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiRuleName,
+			Namespace: apiRuleNamespace,
+		},
+		Spec: gatewayv2alpha1.APIRuleSpec{
+			Hosts:   []*gatewayv2alpha1.Host{ptr.To(gatewayv2alpha1.Host("example-host.example.com"))},
+			Gateway: ptr.To("kyma-system/kyma-gateway"),
+			Service: &gatewayv2alpha1.Service{
+				Name: ptr.To(serviceName),
+				Port: ptr.To(uint32(8080)),
+			},
+			Rules: rules,
+		},
+	}
+}
+
+func newRule(path string) gatewayv2alpha1.Rule {
+	return gatewayv2alpha1.Rule{
+		Path:    path,
+		Methods: []gatewayv2alpha1.HttpMethod{"GET"},
+		NoAuth:  ptr.To(true),
+	}
+}
+
+func newService(name, namespace string, selector map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/authorizationpolicy"
+	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/networkpolicy"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/requestauthentication"
 	"github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/rules"
 	v2alpha1VirtualService "github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/virtualservice"
@@ -59,8 +60,9 @@ func NewReconciliation(apiRuleV2alpha1 *gatewayv2alpha1.APIRule, apiRuleV1beta1
 		processors = append(processors, migration.NewMigrationProcessors(apiRuleV2alpha1, apiRuleV1beta1, gateway, config, log, client)...)
 	} else {
 		processors = append(processors, v2alpha1VirtualService.NewVirtualServiceProcessor(config, apiRuleV2alpha1, gateway, client))
-		processors = append(processors, authorizationpolicy.NewProcessor(log, apiRuleV2alpha1, gateway, client))
+		processors = append(processors, authorizationpolicy.NewProcessor(config, log, apiRuleV2alpha1, gateway, client))
 		processors = append(processors, requestauthentication.NewProcessor(apiRuleV2alpha1, client))
+		processors = append(processors, networkpolicy.NewProcessor(config, apiRuleV2alpha1, gateway, client))
 
 		// With the disablement of v1beta1 -> v2 migration path it is still possible to switch
 		// from v1beta1 to v2 without need to recreate the APIRule.
@@ -12,12 +12,15 @@ import (
 
 	"github.com/kyma-project/api-gateway/internal/builders"
 	"github.com/kyma-project/api-gateway/internal/processing"
-	"github.com/kyma-project/api-gateway/internal/processing/processors"
 )
 
 type requestAuthenticationCreator struct{}
 
-// Create returns the Virtual Service using the configuration of the APIRule.
+const requestAuthenticationKind = "RequestAuthentication"
+
+// Create returns the Request Authentications using the configuration of the APIRule. The names of the Request
+// Authentications are derived from the APIRule and their key, so the same Request Authentication always gets the same
+// name.
 func (r requestAuthenticationCreator) Create(ctx context.Context, client client.Client, api *gatewayv2alpha1.APIRule) (map[string]*securityv1beta1.RequestAuthentication, error) {
 	requestAuthentications := make(map[string]*securityv1beta1.RequestAuthentication)
 	for _, rule := range api.Spec.Rules {
@@ -26,14 +29,15 @@ func (r requestAuthenticationCreator) Create(ctx context.Context, client client.
 			if err != nil {
 				return requestAuthentications, err
 			}
-			requestAuthentications[processors.GetRequestAuthenticationKey(ra)] = ra
+			key := GetRequestAuthenticationKey(ra)
+			ra.Name = processing.SubresourceName(api.Name, api.Namespace, requestAuthenticationKind, key)
+			requestAuthentications[key] = ra
 		}
 	}
 	return requestAuthentications, nil
 }
 
 func generateRequestAuthentication(ctx context.Context, client client.Client, apiRule *gatewayv2alpha1.APIRule, rule gatewayv2alpha1.Rule) (*securityv1beta1.RequestAuthentication, error) {
-	namePrefix := fmt.Sprintf("%s-", apiRule.Name)
 	namespace, err := gatewayv2alpha1.FindServiceNamespace(apiRule, rule)
 	if err != nil {
 		return nil, fmt.Errorf("finding service namespace: %w", err)
@@ -45,7 +49,6 @@ func generateRequestAuthentication(ctx context.Context, client client.Client, ap
 	}
 
 	raBuilder := builders.NewRequestAuthenticationBuilder().
-		WithGenerateName(namePrefix).
 		WithNamespace(namespace).
 		WithSpec(builders.NewRequestAuthenticationSpecBuilder().WithFrom(spec).Get()).
 		WithLabel(processing.OwnerLabelName, apiRule.Name).
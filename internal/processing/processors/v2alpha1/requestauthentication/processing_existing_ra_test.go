@@ -20,10 +20,9 @@ import (
 
 var _ = Describe("Processing with existing RequestAuthentication", func() {
 
-	getRequestAuthentication := func(name string, serviceName string, jwksUri string, issuer string) securityv1beta1.RequestAuthentication {
-		return securityv1beta1.RequestAuthentication{
+	getRequestAuthentication := func(serviceName string, jwksUri string, issuer string) *securityv1beta1.RequestAuthentication {
+		ra := securityv1beta1.RequestAuthentication{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
 				Namespace: apiRuleNamespace,
 				Labels: map[string]string{
 					processing.LegacyOwnerLabel: fmt.Sprintf("%s.%s", apiRuleName, apiRuleNamespace),
@@ -43,7 +42,10 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				},
 			},
 		}
+		ra.Name = processing.SubresourceName(apiRuleName, apiRuleNamespace, "RequestAuthentication", requestauthentication.GetRequestAuthenticationKey(&ra))
+		return &ra
 	}
+
 	getActionMatcher := func(action string, namespace string, serviceName string, jwksUri string, issuer string) gomegatypes.GomegaMatcher {
 		return PointTo(MatchFields(IgnoreExtras, Fields{
 			"Action": WithTransform(ActionToString, Equal(action)),
@@ -68,9 +70,9 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 	It("should delete RA when there is no rule configured in ApiRule", func() {
 		// given: Cluster state
-		existingRa := getRequestAuthentication("raName", serviceName, jwksUri, jwtIssuer)
+		existingRa := getRequestAuthentication(serviceName, jwksUri, jwtIssuer)
 
-		ctrlClient := getFakeClient(&existingRa)
+		ctrlClient := getFakeClient(existingRa)
 
 		// given: New resources
 		apiRule := newAPIRuleBuilderWithDummyData().build()
@@ -87,11 +89,41 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 	When("one resource with JWT config exists", func() {
 
+		It("should replace RA with a generated name and its duplicates by RA with a deterministic name", func() {
+			// given: Cluster state
+			existingRa := getRequestAuthentication("example-service", jwksUri, jwtIssuer)
+			desiredName := existingRa.Name
+			generatedRa, duplicateRa := existingRa.DeepCopy(), existingRa.DeepCopy()
+			generatedRa.Name = apiRuleName + "-x7k2p"
+			duplicateRa.Name = apiRuleName + "-q9d4m"
+			svc := newServiceBuilderWithDummyData().build()
+			ctrlClient := getFakeClient(generatedRa, duplicateRa, svc)
+
+			// given: New resources
+			jwtRule := newJwtRuleBuilderWithDummyData().build()
+			apiRule := newAPIRuleBuilderWithDummyData().
+				withRules(jwtRule).
+				build()
+			processor := requestauthentication.NewProcessor(apiRule, ctrlClient)
+
+			// when
+			result, err := processor.EvaluateReconciliation(context.Background(), ctrlClient)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].Action.String()).To(Equal("create"))
+			Expect(result[0].Obj.GetName()).To(Equal(desiredName))
+			Expect(result[1].Action.String()).To(Equal("delete"))
+			Expect(result[2].Action.String()).To(Equal("delete"))
+			Expect([]string{result[1].Obj.GetName(), result[2].Obj.GetName()}).To(ConsistOf(generatedRa.Name, duplicateRa.Name))
+		})
+
 		It("should update RA when nothing changed", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "example-service", jwksUri, jwtIssuer)
+			existingRa := getRequestAuthentication("example-service", jwksUri, jwtIssuer)
 			svc := newServiceBuilderWithDummyData().build()
-			ctrlClient := getFakeClient(&existingRa, svc)
+			ctrlClient := getFakeClient(existingRa, svc)
 
 			// given: New resources
 			jwtRule := newJwtRuleBuilderWithDummyData().build()
@@ -112,7 +144,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should delete and create new RA when only service name in JWT Rule has changed", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "old-service", jwksUri, jwtIssuer)
+			existingRa := getRequestAuthentication("old-service", jwksUri, jwtIssuer)
 			svcOld := newServiceBuilder().
 				withName("old-service").
 				withNamespace("example-namespace").
@@ -123,7 +155,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("example-namespace").
 				addSelector("app", "updated-service").
 				build()
-			ctrlClient := getFakeClient(&existingRa, svcOld, svcUpdated)
+			ctrlClient := getFakeClient(existingRa, svcOld, svcUpdated)
 
 			// given: New resources
 			jwtRule := newJwtRuleBuilderWithDummyData().
@@ -148,7 +180,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should create new RA when new service with new JWT config is added to ApiRule", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "existing-service", jwksUri, jwtIssuer)
+			existingRa := getRequestAuthentication("existing-service", jwksUri, jwtIssuer)
 			svcExisting := newServiceBuilder().
 				withName("existing-service").
 				withNamespace("example-namespace").
@@ -159,7 +191,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("example-namespace").
 				addSelector("app", "new-service").
 				build()
-			ctrlClient := getFakeClient(&existingRa, svcExisting, svcNew)
+			ctrlClient := getFakeClient(existingRa, svcExisting, svcNew)
 
 			// given: New resources
 			existingJwtRule := newJwtRuleBuilderWithDummyData().
@@ -195,9 +227,9 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should create new RA and delete old RA when JWT ApiRule has new JWKS URI", func() {
 			// given: Cluster state
-			existingRa := getRequestAuthentication("raName", "example-service", jwksUri, jwtIssuer)
+			existingRa := getRequestAuthentication("example-service", jwksUri, jwtIssuer)
 			svc := newServiceBuilderWithDummyData().build()
-			ctrlClient := getFakeClient(&existingRa, svc)
+			ctrlClient := getFakeClient(existingRa, svc)
 
 			// given: New resources
 			jwtRule := newRuleBuilder().
@@ -231,8 +263,8 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should update RAs and create new RA for first-service and delete old RA when JWT issuer in JWT Rule for first-service has changed", func() {
 			// given: Cluster state
-			firstServiceRa := getRequestAuthentication("firstRa", "first-service", jwksUri, jwtIssuer)
-			secondServiceRa := getRequestAuthentication("secondRa", "second-service", jwksUri, jwtIssuer)
+			firstServiceRa := getRequestAuthentication("first-service", jwksUri, jwtIssuer)
+			secondServiceRa := getRequestAuthentication("second-service", jwksUri, jwtIssuer)
 			svcFirst := newServiceBuilder().
 				withName("first-service").
 				withNamespace("example-namespace").
@@ -243,7 +275,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("example-namespace").
 				addSelector("app", "second-service").
 				build()
-			ctrlClient := getFakeClient(&firstServiceRa, &secondServiceRa, svcFirst, svcSecond)
+			ctrlClient := getFakeClient(firstServiceRa, secondServiceRa, svcFirst, svcSecond)
 
 			// given: New resources
 			firstJwtRule := newRuleBuilder().
@@ -283,8 +315,8 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should delete only first-service RA when it was removed from ApiRule", func() {
 			// given: Cluster state
-			firstServiceRa := getRequestAuthentication("firstRa", "first-service", jwksUri, jwtIssuer)
-			secondServiceRa := getRequestAuthentication("secondRa", "second-service", jwksUri, jwtIssuer)
+			firstServiceRa := getRequestAuthentication("first-service", jwksUri, jwtIssuer)
+			secondServiceRa := getRequestAuthentication("second-service", jwksUri, jwtIssuer)
 			svcFirst := newServiceBuilder().
 				withName("first-service").
 				withNamespace("example-namespace").
@@ -295,7 +327,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("example-namespace").
 				addSelector("app", "second-service").
 				build()
-			ctrlClient := getFakeClient(&firstServiceRa, &secondServiceRa, svcFirst, svcSecond)
+			ctrlClient := getFakeClient(firstServiceRa, secondServiceRa, svcFirst, svcSecond)
 
 			// given: New resources
 			secondJwtRule := newJwtRuleBuilderWithDummyData().
@@ -320,8 +352,8 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should create new RA when it has different service", func() {
 			// given: Cluster state
-			firstServiceRa := getRequestAuthentication("firstRa", "first-service", jwksUri, jwtIssuer)
-			secondServiceRa := getRequestAuthentication("secondRa", "second-service", jwksUri, jwtIssuer)
+			firstServiceRa := getRequestAuthentication("first-service", jwksUri, jwtIssuer)
+			secondServiceRa := getRequestAuthentication("second-service", jwksUri, jwtIssuer)
 			svcFirst := newServiceBuilder().
 				withName("first-service").
 				withNamespace("example-namespace").
@@ -337,7 +369,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("example-namespace").
 				addSelector("app", "new-service").
 				build()
-			ctrlClient := getFakeClient(&firstServiceRa, &secondServiceRa, svcFirst, svcSecond, svcNew)
+			ctrlClient := getFakeClient(firstServiceRa, secondServiceRa, svcFirst, svcSecond, svcNew)
 
 			// given: New resources
 			firstJwtRule := newJwtRuleBuilderWithDummyData().
@@ -372,7 +404,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should delete and create new RA when it has different namespace on spec level", func() {
 			// given: Cluster state
-			oldRa := getRequestAuthentication("firstRa", "old-service", jwksUri, jwtIssuer)
+			oldRa := getRequestAuthentication("old-service", jwksUri, jwtIssuer)
 			svcOld := newServiceBuilder().
 				withName("old-service").
 				withNamespace("example-namespace").
@@ -383,7 +415,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("new-namespace").
 				addSelector("app", "old-service").
 				build()
-			ctrlClient := getFakeClient(&oldRa, svcOld, svcNewNS)
+			ctrlClient := getFakeClient(oldRa, svcOld, svcNewNS)
 
 			// given: New resources
 			jwtRule := newRuleBuilder().
@@ -418,7 +450,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 
 		It("should delete and create new RA when it has different namespace on rule level", func() {
 			// given: Cluster state
-			oldRa := getRequestAuthentication("firstRa", "old-service", jwksUri, jwtIssuer)
+			oldRa := getRequestAuthentication("old-service", jwksUri, jwtIssuer)
 			svcOld := newServiceBuilder().
 				withName("old-service").
 				withNamespace("example-namespace").
@@ -429,7 +461,7 @@ var _ = Describe("Processing with existing RequestAuthentication", func() {
 				withNamespace("new-namespace").
 				addSelector("app", "old-service").
 				build()
-			ctrlClient := getFakeClient(&oldRa, svcOld, svcNewNS)
+			ctrlClient := getFakeClient(oldRa, svcOld, svcNewNS)
 
 			// given: New resources
 			jwtRule := newRuleBuilder().
@@ -41,8 +41,8 @@ var _ = Describe("Processing", func() {
 		Expect(result).To(HaveLen(1))
 		ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
 		Expect(ra).NotTo(BeNil())
-		Expect(ra.ObjectMeta.Name).To(BeEmpty())
-		Expect(ra.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ra.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ra.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ra.ObjectMeta.Namespace).To(Equal(apiRuleNamespace))
 		expectLabelsToBeFilled(ra.Labels)
 
@@ -183,8 +183,8 @@ var _ = Describe("Processing", func() {
 		ra := result[0].Obj.(*securityv1beta1.RequestAuthentication)
 
 		Expect(ra).NotTo(BeNil())
-		Expect(ra.ObjectMeta.Name).To(BeEmpty())
-		Expect(ra.ObjectMeta.GenerateName).To(Equal(apiRuleName + "-"))
+		Expect(ra.ObjectMeta.Name).To(HavePrefix(apiRuleName + "-"))
+		Expect(ra.ObjectMeta.GenerateName).To(BeEmpty())
 		Expect(ra.ObjectMeta.Namespace).To(Equal(apiRuleNamespace))
 		expectLabelsToBeFilled(ra.Labels)
 
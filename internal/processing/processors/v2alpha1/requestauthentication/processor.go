@@ -3,6 +3,8 @@ package requestauthentication
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
@@ -48,59 +50,65 @@ func (r Processor) getDesiredState(ctx context.Context, client ctrlclient.Client
 	return r.Creator.Create(ctx, client, api)
 }
 
-func (r Processor) getActualState(ctx context.Context, _ ctrlclient.Client, api *gatewayv2alpha1.APIRule) (map[string]*securityv1beta1.RequestAuthentication, error) {
+func (r Processor) getActualState(ctx context.Context, _ ctrlclient.Client, api *gatewayv2alpha1.APIRule) (map[string][]*securityv1beta1.RequestAuthentication, error) {
 
 	ra, err := r.Repository.GetAll(ctx, api)
 	if err != nil {
 		return nil, err
 	}
 
-	requestAuthentications := make(map[string]*securityv1beta1.RequestAuthentication)
+	// Request Authentications created with a generated name might have duplicates with the same key.
+	requestAuthentications := make(map[string][]*securityv1beta1.RequestAuthentication)
 
 	for i := range ra {
 		obj := ra[i]
-		requestAuthentications[GetRequestAuthenticationKey(obj)] = obj
+		key := GetRequestAuthenticationKey(obj)
+		requestAuthentications[key] = append(requestAuthentications[key], obj)
 	}
 
 	return requestAuthentications, nil
 }
 
-func (r Processor) getObjectChanges(desiredRas map[string]*securityv1beta1.RequestAuthentication, actualRas map[string]*securityv1beta1.RequestAuthentication) []*processing.ObjectChange {
-	raChanges := make(map[string]*processing.ObjectChange)
+func (r Processor) getObjectChanges(desiredRas map[string]*securityv1beta1.RequestAuthentication, actualRas map[string][]*securityv1beta1.RequestAuthentication) []*processing.ObjectChange {
+	var raChangesToApply []*processing.ObjectChange
 
 	for path, rule := range desiredRas {
+		// Only the Request Authentication with the desired name is updated. Others with the same key were created with
+		// a generated name and are replaced by the named one.
+		var kept *securityv1beta1.RequestAuthentication
+		for _, actual := range actualRas[path] {
+			if actual.Name == rule.Name {
+				kept = actual
+			}
+		}
 
-		if actualRas[path] != nil {
-			actualRas[path].Spec = *rule.Spec.DeepCopy()
-			actualRas[path].Labels = rule.Labels
-			raChanges[path] = processing.NewObjectUpdateAction(actualRas[path])
+		if kept != nil {
+			kept.Spec = *rule.Spec.DeepCopy()
+			kept.Labels = rule.Labels
+			raChangesToApply = append(raChangesToApply, processing.NewObjectUpdateAction(kept))
 		} else {
-			raChanges[path] = processing.NewObjectCreateAction(rule)
+			raChangesToApply = append(raChangesToApply, processing.NewObjectCreateAction(rule))
 		}
-
 	}
 
-	for path, rule := range actualRas {
-		if desiredRas[path] == nil {
-			raChanges[path] = processing.NewObjectDeleteAction(rule)
+	for path, ras := range actualRas {
+		for _, ra := range ras {
+			if desiredRas[path] == nil || desiredRas[path].Name != ra.Name {
+				raChangesToApply = append(raChangesToApply, processing.NewObjectDeleteAction(ra))
+			}
 		}
 	}
 
-	raChangesToApply := make([]*processing.ObjectChange, 0, len(raChanges))
-
-	for _, applyCommand := range raChanges {
-		raChangesToApply = append(raChangesToApply, applyCommand)
-	}
-
 	return raChangesToApply
 }
 
 func getSelectorsKey(labels map[string]string) string {
-	var selector = ""
-	for key, value := range labels {
-		selector += fmt.Sprintf("%s=%s,", key, value)
+	// The keys are sorted, because the key of a Request Authentication is used to derive its name.
+	var selectors []string
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", key, labels[key]))
 	}
-	return strings.TrimRight(selector, ",")
+	return strings.Join(selectors, ",")
 }
 
 func GetRequestAuthenticationKey(ra *securityv1beta1.RequestAuthentication) string {
@@ -77,4 +77,37 @@ var _ = Describe("CORS", func() {
 				}))
 			}}, nil, "create"),
 	)
+
+	Context("default CORS policy", func() {
+		defaultCorsPolicy := NewCorsPolicyBuilder().
+			WithAllowOrigins([]map[string]string{{"prefix": "https://default"}}).
+			WithAllowMethods([]string{"GET"}).
+			Build()
+
+		It("should apply the default CORS policy when no CORS configuration is set in APIRule", func() {
+			config := GetTestConfig()
+			config.DefaultCorsPolicy = &defaultCorsPolicy
+			processor = processors.NewVirtualServiceProcessor(config, NewAPIRuleBuilderWithDummyDataWithNoAuthRule().Build(), getTestGateway("example", "gateway"), client)
+
+			checkVirtualServices(client, processor, []verifier{func(vs *networkingv1beta1.VirtualService) {
+				Expect(vs.Spec.Http[0].CorsPolicy).NotTo(BeNil())
+				Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(ConsistOf(&istioapiv1beta1.StringMatch{MatchType: &istioapiv1beta1.StringMatch_Prefix{Prefix: "https://default"}}))
+				Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(ConsistOf("GET"))
+			}}, nil, "create")
+		})
+
+		It("should prefer the CORS policy of the APIRule over the default CORS policy", func() {
+			config := GetTestConfig()
+			config.DefaultCorsPolicy = &defaultCorsPolicy
+			apiRule := NewAPIRuleBuilderWithDummyDataWithNoAuthRule().WithCORSPolicy(
+				NewCorsPolicyBuilder().WithAllowOrigins([]map[string]string{{"exact": "example.com"}}).Build()).
+				Build()
+			processor = processors.NewVirtualServiceProcessor(config, apiRule, getTestGateway("example", "gateway"), client)
+
+			checkVirtualServices(client, processor, []verifier{func(vs *networkingv1beta1.VirtualService) {
+				Expect(vs.Spec.Http[0].CorsPolicy.AllowOrigins).To(ConsistOf(&istioapiv1beta1.StringMatch{MatchType: &istioapiv1beta1.StringMatch_Exact{Exact: "example.com"}}))
+				Expect(vs.Spec.Http[0].CorsPolicy.AllowMethods).To(BeEmpty())
+			}}, nil, "create")
+		})
+	})
 })
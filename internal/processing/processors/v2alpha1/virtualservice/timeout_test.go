@@ -1,12 +1,21 @@
 package virtualservice_test
 
 import (
+	"context"
+	"time"
+
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"k8s.io/utils/ptr"
 
+	"github.com/kyma-project/api-gateway/internal/processing"
+
 	processors "github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1/virtualservice"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	. "github.com/kyma-project/api-gateway/internal/builders/builders_test/v2alpha1_test"
+	. "github.com/kyma-project/api-gateway/internal/processing/processing_test"
 )
 
 var _ = Describe("GetVirtualServiceHttpTimeout", func() {
@@ -66,3 +75,51 @@ var _ = Describe("GetVirtualServiceHttpTimeout", func() {
 		Expect(timeout).To(Equal(uint32(20)))
 	})
 })
+
+var _ = Describe("Default timeout", func() {
+	timeouts := func(config processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule) []time.Duration {
+		client := GetFakeClient()
+		processor := processors.NewVirtualServiceProcessor(config, apiRule, getTestGateway("example", "gateway"), client)
+		result, err := processor.EvaluateReconciliation(context.Background(), client)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+
+		var durations []time.Duration
+		for _, route := range result[0].Obj.(*networkingv1beta1.VirtualService).Spec.Http {
+			durations = append(durations, route.Timeout.AsDuration())
+		}
+		return durations
+	}
+
+	It("should use the default timeout of the configuration when no timeout is set", func() {
+		// given
+		config := GetTestConfig()
+		config.DefaultTimeout = 300
+		apiRule := NewAPIRuleBuilderWithDummyDataWithNoAuthRule().Build()
+
+		// when
+		result := timeouts(config, apiRule)
+
+		// then
+		Expect(result).To(ConsistOf(300 * time.Second))
+	})
+
+	It("should prefer the timeouts of the APIRule and the rules over the default timeout of the configuration", func() {
+		// given
+		config := GetTestConfig()
+		config.DefaultTimeout = 300
+		apiRule := NewAPIRuleBuilderWithDummyData().
+			WithTimeout(20).
+			WithRules(
+				NewRuleBuilder().WithPath("/a").WithMethods("GET").NoAuth().WithTimeout(10).Build(),
+				NewRuleBuilder().WithPath("/b").WithMethods("GET").NoAuth().Build(),
+			).
+			Build()
+
+		// when
+		result := timeouts(config, apiRule)
+
+		// then
+		Expect(result).To(Equal([]time.Duration{10 * time.Second, 20 * time.Second}))
+	})
+})
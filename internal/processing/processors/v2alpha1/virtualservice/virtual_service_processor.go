@@ -17,7 +17,10 @@ import (
 	"github.com/kyma-project/api-gateway/internal/subresources/virtualservice"
 )
 
-const defaultHttpTimeout uint32 = 180
+const (
+	defaultHttpTimeout uint32 = 180
+	virtualServiceKind        = "VirtualService"
+)
 
 var (
 	envoyTemplatesTranslation = map[string]string{
@@ -26,11 +29,13 @@ var (
 	}
 )
 
-func NewVirtualServiceProcessor(_ processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, client ctrlclient.Client) VirtualServiceProcessor {
+func NewVirtualServiceProcessor(config processing.ReconciliationConfig, apiRule *gatewayv2alpha1.APIRule, gateway *networkingv1beta1.Gateway, client ctrlclient.Client) VirtualServiceProcessor {
 	return VirtualServiceProcessor{
 		ApiRule: apiRule,
 		Creator: virtualServiceCreator{
-			gateway: gateway,
+			gateway:           gateway,
+			defaultTimeout:    config.DefaultTimeout,
+			defaultCorsPolicy: config.DefaultCorsPolicy,
 		},
 		Repository: virtualservice.NewRepository(client),
 	}
@@ -60,46 +65,50 @@ func (r VirtualServiceProcessor) EvaluateReconciliation(ctx context.Context, cli
 		return make([]*processing.ObjectChange, 0), err
 	}
 
-	changes := r.getObjectChanges(desired, actual)
-
-	return []*processing.ObjectChange{changes}, nil
+	return r.getObjectChanges(desired, actual), nil
 }
 
 func (r VirtualServiceProcessor) getDesiredState(api *gatewayv2alpha1.APIRule) (*networkingv1beta1.VirtualService, error) {
 	return r.Creator.Create(api)
 }
 
-func (r VirtualServiceProcessor) getActualState(ctx context.Context, _ ctrlclient.Client, api *gatewayv2alpha1.APIRule) (*networkingv1beta1.VirtualService, error) {
-	vsList, err := r.Repository.GetAll(ctx, api)
-	if err != nil {
-		return nil, err
-	}
+func (r VirtualServiceProcessor) getActualState(ctx context.Context, _ ctrlclient.Client, api *gatewayv2alpha1.APIRule) ([]*networkingv1beta1.VirtualService, error) {
+	return r.Repository.GetAll(ctx, api)
+}
 
-	if len(vsList) >= 1 {
-		return vsList[0], nil
-	} else {
-		return nil, nil
+// getObjectChanges updates the Virtual Service with the desired name and deletes all others. Virtual Services created
+// with a generated name, including duplicates from concurrent creations, are replaced by the one with the desired name.
+func (r VirtualServiceProcessor) getObjectChanges(desired *networkingv1beta1.VirtualService, actual []*networkingv1beta1.VirtualService) []*processing.ObjectChange {
+	var kept *networkingv1beta1.VirtualService
+	var deleted []*processing.ObjectChange
+	for _, vs := range actual {
+		if kept == nil && (vs.Name == desired.Name || desired.Name == "") {
+			kept = vs
+			continue
+		}
+		deleted = append(deleted, processing.NewObjectDeleteAction(vs))
 	}
-}
 
-func (r VirtualServiceProcessor) getObjectChanges(desired *networkingv1beta1.VirtualService, actual *networkingv1beta1.VirtualService) *processing.ObjectChange {
-	if actual != nil {
-		actual.Spec = *desired.Spec.DeepCopy()
-		actual.Labels = desired.Labels
-		return processing.NewObjectUpdateAction(actual)
-	} else {
-		return processing.NewObjectCreateAction(desired)
+	// The desired Virtual Service is created or updated before the replaced ones are deleted, so that the hosts are
+	// routed at any time.
+	if kept != nil {
+		kept.Spec = *desired.Spec.DeepCopy()
+		kept.Labels = desired.Labels
+		return append([]*processing.ObjectChange{processing.NewObjectUpdateAction(kept)}, deleted...)
 	}
+	return append([]*processing.ObjectChange{processing.NewObjectCreateAction(desired)}, deleted...)
 }
 
 type virtualServiceCreator struct {
 	gateway *networkingv1beta1.Gateway
+	// defaultTimeout and defaultCorsPolicy are used for APIRules that don't define a timeout or CORS policy.
+	defaultTimeout    uint32
+	defaultCorsPolicy *gatewayv2alpha1.CorsPolicy
 }
 
-// Create returns the Virtual Service using the configuration of the APIRule.
+// Create returns the Virtual Service using the configuration of the APIRule. The name of the Virtual Service is
+// derived from the APIRule, since there is only one Virtual Service per APIRule.
 func (r virtualServiceCreator) Create(api *gatewayv2alpha1.APIRule) (*networkingv1beta1.VirtualService, error) {
-	virtualServiceNamePrefix := fmt.Sprintf("%s-", api.Name)
-
 	vsSpecBuilder := builders.VirtualServiceSpec()
 	hosts, gatewayDomain, err := getHostsAndDomainFromAPIRule(api, r)
 	if err != nil {
@@ -150,7 +159,7 @@ func (r virtualServiceCreator) Create(api *gatewayv2alpha1.APIRule) (*networking
 
 		httpRouteBuilder.Match(matchBuilder)
 
-		httpRouteBuilder.Timeout(time.Duration(GetVirtualServiceHttpTimeout(api.Spec, rule)) * time.Second)
+		httpRouteBuilder.Timeout(time.Duration(r.httpTimeout(api.Spec, rule)) * time.Second)
 
 		headersBuilder := builders.NewHttpRouteHeadersBuilder().
 			// For now, the X-Forwarded-Host header is set to the first host in the APIRule hosts list.
@@ -170,6 +179,8 @@ func (r virtualServiceCreator) Create(api *gatewayv2alpha1.APIRule) (*networking
 
 		if api.Spec.CorsPolicy != nil {
 			httpRouteBuilder.CorsPolicy(builders.CorsPolicy().FromV2Alpha1ApiRuleCorsPolicy(*api.Spec.CorsPolicy))
+		} else if r.defaultCorsPolicy != nil {
+			httpRouteBuilder.CorsPolicy(builders.CorsPolicy().FromV2Alpha1ApiRuleCorsPolicy(*r.defaultCorsPolicy))
 		}
 		headersBuilder.RemoveUpstreamCORSPolicyHeaders()
 
@@ -180,7 +191,7 @@ func (r virtualServiceCreator) Create(api *gatewayv2alpha1.APIRule) (*networking
 	}
 
 	vsBuilder := builders.VirtualService().
-		GenerateName(virtualServiceNamePrefix).
+		Name(processing.SubresourceName(api.Name, api.Namespace, virtualServiceKind)).
 		Namespace(api.ObjectMeta.Namespace).
 		Label(processing.OwnerLabelName, api.Name).
 		Label(processing.OwnerLabelNamespace, api.Namespace).
@@ -213,6 +224,15 @@ func GetVirtualServiceHttpTimeout(apiRuleSpec gatewayv2alpha1.APIRuleSpec, rule
 	return defaultHttpTimeout
 }
 
+// httpTimeout returns the timeout of the rule, using the default timeout of the creator if neither the rule nor the
+// APIRule define a timeout.
+func (r virtualServiceCreator) httpTimeout(apiRuleSpec gatewayv2alpha1.APIRuleSpec, rule gatewayv2alpha1.Rule) uint32 {
+	if rule.Timeout == nil && apiRuleSpec.Timeout == nil && r.defaultTimeout > 0 {
+		return r.defaultTimeout
+	}
+	return GetVirtualServiceHttpTimeout(apiRuleSpec, rule)
+}
+
 // getHostsAndDomainFromAPIRule extracts all FQDNs for which the APIRule should match.
 // If the APIRule contains short host names, it will use the domain of the specified gateway to generate FQDNs for them.
 // This is done by concatenating the short host name with the wildcard domain of the gateway.
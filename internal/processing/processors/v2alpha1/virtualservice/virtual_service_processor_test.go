@@ -59,6 +59,59 @@ var _ = Describe("ObjectChange", func() {
 		Expect(result[0].Action.String()).To(Equal("update"))
 		expectLabelsToBeFilled(result[0].Obj.GetLabels())
 	})
+
+	It("should replace a VirtualService with a generated name by one with a deterministic name", func() {
+		// given
+		apiRule := NewAPIRuleBuilderWithDummyData().Build()
+		processor := processors.NewVirtualServiceProcessor(GetTestConfig(), apiRule, getTestGateway("example", "gateway"), GetFakeClient())
+		result, err := processor.EvaluateReconciliation(context.Background(), GetFakeClient())
+		Expect(err).To(BeNil())
+		desired := result[0].Obj.(*networkingv1beta1.VirtualService)
+		Expect(desired.Name).To(Equal(processing.SubresourceName(apiRule.Name, apiRule.Namespace, "VirtualService")))
+
+		generated := desired.DeepCopy()
+		generated.Name = apiRule.Name + "-x7k2p"
+		fakeClient := GetFakeClient(generated)
+
+		// when
+		processor = processors.NewVirtualServiceProcessor(GetTestConfig(), apiRule, getTestGateway("example", "gateway"), fakeClient)
+		result, err = processor.EvaluateReconciliation(context.Background(), fakeClient)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Action.String()).To(Equal("create"))
+		Expect(result[0].Obj.GetName()).To(Equal(desired.Name))
+		Expect(result[1].Action.String()).To(Equal("delete"))
+		Expect(result[1].Obj.GetName()).To(Equal(generated.Name))
+	})
+
+	It("should update the VirtualService with the deterministic name and delete duplicates", func() {
+		// given
+		apiRule := NewAPIRuleBuilderWithDummyData().Build()
+		processor := processors.NewVirtualServiceProcessor(GetTestConfig(), apiRule, getTestGateway("example", "gateway"), GetFakeClient())
+		result, err := processor.EvaluateReconciliation(context.Background(), GetFakeClient())
+		Expect(err).To(BeNil())
+		desired := result[0].Obj.(*networkingv1beta1.VirtualService)
+
+		duplicate1, duplicate2 := desired.DeepCopy(), desired.DeepCopy()
+		duplicate1.Name = apiRule.Name + "-x7k2p"
+		duplicate2.Name = apiRule.Name + "-q9d4m"
+		fakeClient := GetFakeClient(duplicate1, desired.DeepCopy(), duplicate2)
+
+		// when
+		processor = processors.NewVirtualServiceProcessor(GetTestConfig(), apiRule, getTestGateway("example", "gateway"), fakeClient)
+		result, err = processor.EvaluateReconciliation(context.Background(), fakeClient)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(result).To(HaveLen(3))
+		Expect(result[0].Action.String()).To(Equal("update"))
+		Expect(result[0].Obj.GetName()).To(Equal(desired.Name))
+		Expect(result[1].Action.String()).To(Equal("delete"))
+		Expect(result[2].Action.String()).To(Equal("delete"))
+		Expect([]string{result[1].Obj.GetName(), result[2].Obj.GetName()}).To(ConsistOf(duplicate1.Name, duplicate2.Name))
+	})
 })
 
 var _ = Describe("Fully configured APIRule happy path", func() {
@@ -38,36 +38,38 @@ func (r VirtualServiceProcessor) EvaluateReconciliation(ctx context.Context, cli
 		return make([]*processing.ObjectChange, 0), err
 	}
 
-	changes := r.getObjectChanges(desired, actual)
-
-	return []*processing.ObjectChange{changes}, nil
+	return r.getObjectChanges(desired, actual), nil
 }
 
 func (r VirtualServiceProcessor) getDesiredState(api *gatewayv1beta1.APIRule) (*networkingv1beta1.VirtualService, error) {
 	return r.Creator.Create(api)
 }
 
-func (r VirtualServiceProcessor) getActualState(ctx context.Context, _ ctrlclient.Client, api *gatewayv1beta1.APIRule) (*networkingv1beta1.VirtualService, error) {
-	vsList, err := r.Repository.GetAll(ctx, api)
-	if err != nil {
-		return nil, err
-	}
+func (r VirtualServiceProcessor) getActualState(ctx context.Context, _ ctrlclient.Client, api *gatewayv1beta1.APIRule) ([]*networkingv1beta1.VirtualService, error) {
+	return r.Repository.GetAll(ctx, api)
+}
 
-	if len(vsList) >= 1 {
-		return vsList[0], nil
-	} else {
-		return nil, nil
+// getObjectChanges updates the Virtual Service with the desired name and deletes all others. Virtual Services created
+// with a generated name, including duplicates from concurrent creations, are replaced by the one with the desired name.
+func (r VirtualServiceProcessor) getObjectChanges(desired *networkingv1beta1.VirtualService, actual []*networkingv1beta1.VirtualService) []*processing.ObjectChange {
+	var kept *networkingv1beta1.VirtualService
+	var deleted []*processing.ObjectChange
+	for _, vs := range actual {
+		if kept == nil && (vs.Name == desired.Name || desired.Name == "") {
+			kept = vs
+			continue
+		}
+		deleted = append(deleted, processing.NewObjectDeleteAction(vs))
 	}
-}
 
-func (r VirtualServiceProcessor) getObjectChanges(desiredVs *networkingv1beta1.VirtualService, actualVs *networkingv1beta1.VirtualService) *processing.ObjectChange {
-	if actualVs != nil {
-		actualVs.Spec = *desiredVs.Spec.DeepCopy()
-		actualVs.Labels = desiredVs.Labels
-		return processing.NewObjectUpdateAction(actualVs)
-	} else {
-		return processing.NewObjectCreateAction(desiredVs)
+	// The desired Virtual Service is created or updated before the replaced ones are deleted, so that the hosts are
+	// routed at any time.
+	if kept != nil {
+		kept.Spec = *desired.Spec.DeepCopy()
+		kept.Labels = desired.Labels
+		return append([]*processing.ObjectChange{processing.NewObjectUpdateAction(kept)}, deleted...)
 	}
+	return append([]*processing.ObjectChange{processing.NewObjectCreateAction(desired)}, deleted...)
 }
 
 func GetVirtualServiceHttpTimeout(apiRuleSpec gatewayv1beta1.APIRuleSpec, rule gatewayv1beta1.Rule) time.Duration {
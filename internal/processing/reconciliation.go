@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	"github.com/kyma-project/api-gateway/internal/processing/status"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 	"github.com/kyma-project/api-gateway/internal/validation"
 )
 
@@ -32,11 +36,37 @@ type ReconciliationProcessor interface {
 	EvaluateReconciliation(context.Context, client.Client) ([]*ObjectChange, error)
 }
 
-// Reconcile executes the reconciliation of the APIRule using the given reconciliation command.
-func Reconcile(ctx context.Context, client client.Client, log *logr.Logger, cmd ReconciliationCommand) status.ReconciliationStatus {
-	l := log.WithValues("controller", "APIRule", "version", gatewayv1beta1.GroupVersion.String())
+// ReconciliationObserver is notified about the outcome of the steps of the reconciliation, e.g. to record Events.
+type ReconciliationObserver interface {
+	// ValidationFailed is called with the failures if the validation of the APIRule failed.
+	ValidationFailed(failures []validation.Failure)
+
+	// ChangeApplied is called for each change that was applied to the cluster or failed to be applied. Updates that
+	// didn't modify the object are not reported.
+	ChangeApplied(change *ObjectChange, err error)
+
+	// PhaseCompleted is called with the duration of each phase of the reconciliation. The phase is either
+	// PhaseValidation or the name of the processor.
+	PhaseCompleted(phase string, duration time.Duration)
+}
 
-	validationFailures, err := cmd.Validate(ctx, client)
+// PhaseValidation is the phase of the reconciliation in which the APIRule is validated.
+const PhaseValidation = "validation"
+
+// Reconcile executes the reconciliation of the APIRule using the given reconciliation command. The apiReader reads the
+// versions of the subresources before they are changed directly from the API server, so that a rollback restores the
+// latest version.
+func Reconcile(ctx context.Context, client client.Client, apiReader client.Reader, log *logr.Logger, cmd ReconciliationCommand, observers ...ReconciliationObserver) status.ReconciliationStatus {
+	l := log.WithValues("controller", "APIRule", "version", gatewayv1beta1.GroupVersion.String())
+	ctx, span := tracing.Start(ctx, "processing.Reconcile")
+	defer span.End()
+
+	validationStart := time.Now()
+	validationCtx, validationSpan := tracing.Start(ctx, "processing.Validate")
+	validationFailures, err := cmd.Validate(validationCtx, client)
+	validationSpan.SetAttributes(attribute.Int("validation.failures", len(validationFailures)))
+	tracing.End(validationSpan, err)
+	observePhase(observers, PhaseValidation, validationStart)
 	if err != nil {
 		// We set the status to skipped because it was not the validation that failed, but an error occurred during validation.
 		l.Error(err, "Error during validation")
@@ -48,23 +78,38 @@ func Reconcile(ctx context.Context, client client.Client, log *logr.Logger, cmd
 	if len(validationFailures) > 0 {
 		failuresJson, _ := json.Marshal(validationFailures)
 		l.Error(errors.New("validation failure"), "Validation failure", "failure", string(failuresJson))
+		for _, o := range observers {
+			o.ValidationFailed(validationFailures)
+		}
 		statusBase := cmd.GetStatusBase(string(gatewayv1beta1.StatusSkipped))
 		return statusBase.GenerateStatusFromFailures(validationFailures)
 	}
 
+	// The changes of all processors are applied in one transaction, so the subresources are not left in a state that
+	// mixes the routing of the new APIRule with the authorization of the previous one if a change fails.
+	tx := newTransaction(client, apiReader)
 	for _, processor := range cmd.GetProcessors() {
-		objectChanges, err := processor.EvaluateReconciliation(ctx, client)
+		processorStart := time.Now()
+		processorCtx, processorSpan := tracing.Start(ctx, ProcessorName(processor)+".EvaluateReconciliation")
+		objectChanges, err := processor.EvaluateReconciliation(processorCtx, client)
 		if err != nil {
+			tracing.End(processorSpan, err)
+			observePhase(observers, ProcessorName(processor), processorStart)
 			l.Error(err, "Error during reconciliation")
 			statusBase := cmd.GetStatusBase(string(gatewayv1beta1.StatusSkipped))
 			errorMap := map[status.ResourceSelector][]error{status.OnApiRule: {err}}
+			rollback(ctx, &l, tx, errorMap)
 			return statusBase.GetStatusForErrorMap(errorMap)
 		}
 
-		errorMap := applyChanges(ctx, client, objectChanges...)
+		processorSpan.SetAttributes(attribute.Int("changes", len(objectChanges)))
+		errorMap := applyChanges(processorCtx, client, tx, observers, objectChanges...)
+		tracing.End(processorSpan, errors.Join(flattenErrors(errorMap)...))
+		observePhase(observers, ProcessorName(processor), processorStart)
 		if len(errorMap) > 0 {
 			aggregatedErrors := aggregateErrors(errorMap)
 			l.Error(err, "Error during applying reconciliation", "objectErrors", aggregatedErrors)
+			rollback(ctx, &l, tx, errorMap)
 
 			statusBase := cmd.GetStatusBase(string(gatewayv1beta1.StatusOK))
 			return statusBase.GetStatusForErrorMap(errorMap)
@@ -75,15 +120,61 @@ func Reconcile(ctx context.Context, client client.Client, log *logr.Logger, cmd
 	return statusBase.GenerateStatusFromFailures(nil)
 }
 
-// applyChanges applies the given commands on the cluster
+// ProcessorName returns the name of the processor used as phase of the reconciliation, e.g.
+// "authorizationpolicy.Processor".
+func ProcessorName(processor ReconciliationProcessor) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", processor), "*")
+}
+
+func observePhase(observers []ReconciliationObserver, phase string, start time.Time) {
+	duration := time.Since(start)
+	for _, o := range observers {
+		o.PhaseCompleted(phase, duration)
+	}
+}
+
+// rollback reverts the changes applied in the transaction and adds the errors of changes that couldn't be reverted to
+// the error map.
+func rollback(ctx context.Context, l *logr.Logger, tx *transaction, errorMap map[status.ResourceSelector][]error) {
+	if len(tx.applied) == 0 {
+		return
+	}
+
+	l.Info("Rolling back applied changes", "changes", len(tx.applied))
+	if errs := tx.rollback(ctx); len(errs) > 0 {
+		l.Error(errors.Join(errs...), "Error during rollback of applied changes")
+		errorMap[status.OnApiRule] = append(errorMap[status.OnApiRule], errs...)
+	}
+}
+
+// applyChanges applies the given commands on the cluster and records the applied changes in the transaction
 // returns map of errors that happened for all subresources
 // the map is empty if no error happened
-func applyChanges(ctx context.Context, client client.Client, changes ...*ObjectChange) map[status.ResourceSelector][]error {
+func applyChanges(ctx context.Context, client client.Client, tx *transaction, observers []ReconciliationObserver, changes ...*ObjectChange) map[status.ResourceSelector][]error {
 	errorMap := make(map[status.ResourceSelector][]error)
 	for _, change := range changes {
+		previous, err := tx.snapshot(ctx, change)
+		if err != nil {
+			// A change that couldn't be rolled back is not applied.
+			res := objectToSelector(change.Obj)
+			errorMap[res] = append(errorMap[res], err)
+			continue
+		}
+
+		resourceVersion := change.Obj.GetResourceVersion()
 		res, err := applyChange(ctx, client, change)
 		if err != nil {
 			errorMap[res] = append(errorMap[res], err)
+		} else {
+			tx.record(change, previous)
+		}
+
+		// Updates are applied in every reconciliation, but the resource version only changes if the object was modified.
+		if err == nil && change.Action == update && change.Obj.GetResourceVersion() == resourceVersion {
+			continue
+		}
+		for _, o := range observers {
+			o.ChangeApplied(change, err)
 		}
 	}
 
@@ -91,12 +182,19 @@ func applyChanges(ctx context.Context, client client.Client, changes ...*ObjectC
 }
 
 func applyChange(ctx context.Context, client client.Client, change *ObjectChange) (status.ResourceSelector, error) {
+	ctx, span := tracing.Start(ctx, "processing.ApplyChange",
+		attribute.String("action", change.Action.String()),
+		attribute.String("object.kind", change.Obj.GetObjectKind().GroupVersionKind().Kind),
+		attribute.String("object.name", change.Obj.GetName()),
+		attribute.String("object.namespace", change.Obj.GetNamespace()))
 	var err error
+	defer func() { tracing.End(span, err) }()
+
 	switch change.Action {
 	case create:
-		err = client.Create(ctx, change.Obj)
+		err = createObject(ctx, client, change.Obj)
 	case update:
-		err = client.Update(ctx, change.Obj)
+		err = updateObject(ctx, client, change.Obj)
 	case delete:
 		err = client.Delete(ctx, change.Obj)
 	default:
@@ -129,10 +227,16 @@ func objectToSelector(obj client.Object) status.ResourceSelector {
 // aggregateErrors aggregates all errors from the errorMap to a single slice
 func aggregateErrors(errorMap map[status.ResourceSelector][]error) []string {
 	var allErrors []string
+	for _, singleError := range flattenErrors(errorMap) {
+		allErrors = append(allErrors, singleError.Error())
+	}
+	return allErrors
+}
+
+func flattenErrors(errorMap map[status.ResourceSelector][]error) []error {
+	var allErrors []error
 	for _, resourceErrors := range errorMap {
-		for _, singleError := range resourceErrors {
-			allErrors = append(allErrors, singleError.Error())
-		}
+		allErrors = append(allErrors, resourceErrors...)
 	}
 	return allErrors
 }
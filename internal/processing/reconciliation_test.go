@@ -11,11 +11,20 @@ import (
 	"github.com/kyma-project/api-gateway/internal/validation"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"time"
 )
 
 var _ = Describe("Reconcile", func() {
@@ -30,7 +39,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().Build()
 
 		// when
-		status := processing.Reconcile(context.Background(), client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+		status := processing.Reconcile(context.Background(), client, client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -56,7 +65,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().Build()
 
 		// when
-		status := processing.Reconcile(context.Background(), client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+		status := processing.Reconcile(context.Background(), client, client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -87,7 +96,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().Build()
 
 		// when
-		status := processing.Reconcile(context.Background(), client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+		status := processing.Reconcile(context.Background(), client, client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -119,7 +128,7 @@ var _ = Describe("Reconcile", func() {
 			client := fake.NewClientBuilder().Build()
 
 			// when
-			status := processing.Reconcile(context.Background(), client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+			status := processing.Reconcile(context.Background(), client, client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
 
 			// then
 			Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -161,7 +170,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(toBeUpdatedVs, toBeDeletedVs).Build()
 
 		// when
-		status := processing.Reconcile(context.Background(), client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+		status := processing.Reconcile(context.Background(), client, client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
@@ -199,7 +208,7 @@ var _ = Describe("Reconcile", func() {
 		client := fake.NewClientBuilder().WithScheme(scheme).Build()
 
 		// when
-		status := processing.Reconcile(context.Background(), client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+		status := processing.Reconcile(context.Background(), client, client, testLogger(), cmd).(status.ReconciliationV1beta1Status)
 
 		// then
 		Expect(status.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
@@ -212,6 +221,121 @@ var _ = Describe("Reconcile", func() {
 	})
 })
 
+var _ = Describe("Reconcile with observer", func() {
+	It("should notify the observer about validation failures", func() {
+		// given
+		failures := []validation.Failure{{AttributePath: "some.path", Message: "The value is not allowed"}}
+		cmd := MockReconciliationCommand{
+			validateMock: func() ([]validation.Failure, error) { return failures, nil },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusSkipped)
+			},
+		}
+		observer := &recordingObserver{}
+		k8sClient := fake.NewClientBuilder().Build()
+
+		// when
+		processing.Reconcile(context.Background(), k8sClient, k8sClient, testLogger(), cmd, observer)
+
+		// then
+		Expect(observer.failures).To(Equal(failures))
+		Expect(observer.changes).To(BeEmpty())
+	})
+
+	It("should notify the observer about applied and failed changes", func() {
+		// given
+		toBeCreatedVs := builders.VirtualService().Name("toBeCreated").Namespace("default").Get()
+		notExistingVs := builders.VirtualService().Name("notExisting").Namespace("default").Get()
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{
+					processing.NewObjectCreateAction(toBeCreatedVs),
+					processing.NewObjectUpdateAction(notExistingVs),
+				}, nil
+			},
+		}
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		observer := &recordingObserver{}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		// when
+		processing.Reconcile(context.Background(), k8sClient, k8sClient, testLogger(), cmd, observer)
+
+		// then
+		Expect(observer.failures).To(BeEmpty())
+		Expect(observer.changes).To(HaveLen(2))
+		Expect(observer.changes[0]).To(Equal("create default/toBeCreated"))
+		Expect(observer.changes[1]).To(HavePrefix("update default/notExisting failed"))
+		Expect(observer.phases).To(Equal([]string{processing.PhaseValidation, "processing_test.MockReconciliationProcessor"}))
+	})
+
+	It("should not notify the observer about updates that didn't modify the object", func() {
+		// given
+		vs := builders.VirtualService().Name("unchanged").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("example.com")).Get()
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		// The fake client changes the resource version on every apply, so an apply that doesn't modify the object
+		// on the API server is simulated.
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vs).WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(_ context.Context, _ client.WithWatch, _ runtime.ApplyConfiguration, _ ...client.ApplyOption) error {
+				return nil
+			},
+		}).Build()
+
+		var actual networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(vs), &actual)).To(Succeed())
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectUpdateAction(&actual)}, nil
+			},
+		}
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		observer := &recordingObserver{}
+
+		// when
+		processing.Reconcile(context.Background(), k8sClient, k8sClient, testLogger(), cmd, observer)
+
+		// then
+		Expect(observer.changes).To(BeEmpty())
+	})
+})
+
+type recordingObserver struct {
+	failures []validation.Failure
+	changes  []string
+	phases   []string
+}
+
+func (o *recordingObserver) PhaseCompleted(phase string, _ time.Duration) {
+	o.phases = append(o.phases, phase)
+}
+
+func (o *recordingObserver) ValidationFailed(failures []validation.Failure) {
+	o.failures = append(o.failures, failures...)
+}
+
+func (o *recordingObserver) ChangeApplied(change *processing.ObjectChange, err error) {
+	entry := fmt.Sprintf("%s %s/%s", change.Action, change.Obj.GetNamespace(), change.Obj.GetName())
+	if err != nil {
+		entry += " failed"
+	}
+	o.changes = append(o.changes, entry)
+}
+
 type MockReconciliationCommand struct {
 	validateMock      func() ([]validation.Failure, error)
 	getStatusBaseMock func() status.ReconciliationStatus
@@ -256,3 +380,257 @@ func mockStatusBase(statusCode gatewayv1beta1.StatusCode) status.ReconciliationS
 		},
 	}
 }
+
+var _ = Describe("Reconcile with tracing", func() {
+	var spanRecorder *tracetest.SpanRecorder
+
+	BeforeEach(func() {
+		spanRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+		DeferCleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	})
+
+	It("should record spans for the validation, the processors and the applied changes", func() {
+		// given
+		vs := builders.VirtualService().Name("toBeCreated").Namespace("default").Get()
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) {
+				return []*processing.ObjectChange{processing.NewObjectCreateAction(vs)}, nil
+			},
+		}
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		// when
+		processing.Reconcile(context.Background(), k8sClient, k8sClient, testLogger(), cmd)
+
+		// then
+		spans := map[string]sdktrace.ReadOnlySpan{}
+		for _, s := range spanRecorder.Ended() {
+			spans[s.Name()] = s
+		}
+		Expect(spans).To(HaveKey("processing.Reconcile"))
+		Expect(spans).To(HaveKey("processing.Validate"))
+		Expect(spans).To(HaveKey("processing_test.MockReconciliationProcessor.EvaluateReconciliation"))
+		Expect(spans).To(HaveKey("processing.ApplyChange"))
+
+		reconcileSpan := spans["processing.Reconcile"].SpanContext().SpanID()
+		Expect(spans["processing.Validate"].Parent().SpanID()).To(Equal(reconcileSpan))
+		Expect(spans["processing_test.MockReconciliationProcessor.EvaluateReconciliation"].Parent().SpanID()).To(Equal(reconcileSpan))
+		Expect(spans["processing.ApplyChange"].Parent().SpanID()).
+			To(Equal(spans["processing_test.MockReconciliationProcessor.EvaluateReconciliation"].SpanContext().SpanID()))
+	})
+
+	It("should record the error of a failing processor on its span", func() {
+		// given
+		p := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) { return nil, fmt.Errorf("error during evaluation") },
+		}
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return []processing.ReconciliationProcessor{p} },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusSkipped)
+			},
+		}
+		k8sClient := fake.NewClientBuilder().Build()
+
+		// when
+		processing.Reconcile(context.Background(), k8sClient, k8sClient, testLogger(), cmd)
+
+		// then
+		var processorSpan sdktrace.ReadOnlySpan
+		for _, s := range spanRecorder.Ended() {
+			if s.Name() == "processing_test.MockReconciliationProcessor.EvaluateReconciliation" {
+				processorSpan = s
+			}
+		}
+		Expect(processorSpan).NotTo(BeNil())
+		Expect(processorSpan.Status().Code).To(Equal(codes.Error))
+		Expect(processorSpan.Status().Description).To(Equal("error during evaluation"))
+	})
+})
+
+var _ = Describe("Reconcile with rollback", func() {
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(securityv1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	// failingApplyFor returns interceptor functions that fail the apply of objects of the given kind.
+	failingApplyFor := func(kind string) interceptor.Funcs {
+		return interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				if obj.(interface{ GetKind() string }).GetKind() == kind {
+					return fmt.Errorf("injected failure for %s", kind)
+				}
+				return c.Apply(ctx, obj, opts...)
+			},
+		}
+	}
+
+	reconcileWith := func(k8sClient client.Client, apiReader client.Reader, processors ...processing.ReconciliationProcessor) status.ReconciliationV1beta1Status {
+		cmd := MockReconciliationCommand{
+			validateMock:   func() ([]validation.Failure, error) { return []validation.Failure{}, nil },
+			processorMocks: func() []processing.ReconciliationProcessor { return processors },
+			getStatusBaseMock: func() status.ReconciliationStatus {
+				return mockStatusBase(gatewayv1beta1.StatusOK)
+			},
+		}
+		return processing.Reconcile(context.Background(), k8sClient, apiReader, testLogger(), cmd).(status.ReconciliationV1beta1Status)
+	}
+
+	changes := func(changes ...*processing.ObjectChange) MockReconciliationProcessor {
+		return MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) { return changes, nil },
+		}
+	}
+
+	It("should restore the VirtualService when the AuthorizationPolicy of a later processor fails", func() {
+		// given
+		vs := builders.VirtualService().Name("route").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("old.example.com")).Get()
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vs).WithInterceptorFuncs(failingApplyFor("AuthorizationPolicy")).Build()
+
+		var actual networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(vs), &actual)).To(Succeed())
+		actual.Spec.Hosts = []string{"new.example.com"}
+		ap := &securityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+
+		// when
+		s := reconcileWith(k8sClient, k8sClient, changes(processing.NewObjectUpdateAction(&actual)), changes(processing.NewObjectCreateAction(ap)))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		var restored networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(vs), &restored)).To(Succeed())
+		Expect(restored.Spec.Hosts).To(ConsistOf("old.example.com"))
+	})
+
+	It("should restore the version of the API server when the cache is outdated", func() {
+		// given
+		vs := builders.VirtualService().Name("route").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("old.example.com")).Get()
+		apiServer := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vs).Build()
+		// The client reads an outdated version of the VirtualService, like a cache that didn't receive the last update.
+		k8sClient := interceptor.NewClient(apiServer.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if cached, ok := obj.(*networkingv1beta1.VirtualService); ok {
+					cached.Spec.Hosts = []string{"outdated.example.com"}
+				}
+				return nil
+			},
+			Apply: failingApplyFor("AuthorizationPolicy").Apply,
+		})
+
+		var actual networkingv1beta1.VirtualService
+		Expect(apiServer.Get(context.Background(), client.ObjectKeyFromObject(vs), &actual)).To(Succeed())
+		actual.Spec.Hosts = []string{"new.example.com"}
+		ap := &securityv1beta1.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+
+		// when
+		s := reconcileWith(k8sClient, apiServer, changes(processing.NewObjectUpdateAction(&actual)), changes(processing.NewObjectCreateAction(ap)))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		var restored networkingv1beta1.VirtualService
+		Expect(apiServer.Get(context.Background(), client.ObjectKeyFromObject(vs), &restored)).To(Succeed())
+		Expect(restored.Spec.Hosts).To(ConsistOf("old.example.com"))
+	})
+
+	It("should only read objects from the API server that are modified by an update", func() {
+		// given
+		unchanged := builders.VirtualService().Name("unchanged").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("unchanged.example.com")).Get()
+		changed := builders.VirtualService().Name("changed").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("old.example.com")).Get()
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(unchanged, changed).Build()
+		var reads []string
+		apiReader := interceptor.NewClient(k8sClient.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				reads = append(reads, key.Name)
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+
+		var actualUnchanged, actualChanged networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(unchanged), &actualUnchanged)).To(Succeed())
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(changed), &actualChanged)).To(Succeed())
+		actualChanged.Spec.Hosts = []string{"new.example.com"}
+
+		// when
+		s := reconcileWith(k8sClient, apiReader, changes(processing.NewObjectUpdateAction(&actualUnchanged), processing.NewObjectUpdateAction(&actualChanged)))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusOK))
+		Expect(reads).To(ConsistOf("changed"))
+	})
+
+	It("should delete created objects and recreate deleted objects when a change fails", func() {
+		// given
+		toBeDeleted := builders.VirtualService().Name("toBeDeleted").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("deleted.example.com")).Get()
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(toBeDeleted).Build()
+
+		toBeCreated := builders.VirtualService().Name("toBeCreated").Namespace("default").Spec(builders.VirtualServiceSpec().AddHost("created.example.com")).Get()
+		notExisting := builders.VirtualService().Name("notExisting").Namespace("default").Get()
+
+		// when
+		s := reconcileWith(k8sClient, k8sClient, changes(
+			processing.NewObjectCreateAction(toBeCreated),
+			processing.NewObjectDeleteAction(toBeDeleted.DeepCopy()),
+			processing.NewObjectUpdateAction(notExisting),
+		))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(toBeCreated), &networkingv1beta1.VirtualService{})).
+			To(MatchError(ContainSubstring("not found")))
+		var restored networkingv1beta1.VirtualService
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(toBeDeleted), &restored)).To(Succeed())
+		Expect(restored.Spec.Hosts).To(ConsistOf("deleted.example.com"))
+	})
+
+	It("should roll back the changes of previous processors when a processor returns an error", func() {
+		// given
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		toBeCreated := builders.VirtualService().Name("toBeCreated").Namespace("default").Get()
+		failing := MockReconciliationProcessor{
+			evaluate: func() ([]*processing.ObjectChange, error) { return nil, fmt.Errorf("error during evaluation") },
+		}
+
+		// when
+		reconcileWith(k8sClient, k8sClient, changes(processing.NewObjectCreateAction(toBeCreated)), failing)
+
+		// then
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(toBeCreated), &networkingv1beta1.VirtualService{})).
+			To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("should report changes that couldn't be rolled back", func() {
+		// given
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.DeleteOption) error {
+				return fmt.Errorf("injected delete failure")
+			},
+		}).Build()
+		toBeCreated := builders.VirtualService().Name("toBeCreated").Namespace("default").Get()
+		notExisting := builders.VirtualService().Name("notExisting").Namespace("default").Get()
+
+		// when
+		s := reconcileWith(k8sClient, k8sClient, changes(processing.NewObjectCreateAction(toBeCreated), processing.NewObjectUpdateAction(notExisting)))
+
+		// then
+		Expect(s.ApiRuleStatus.Code).To(Equal(gatewayv1beta1.StatusError))
+		Expect(s.ApiRuleStatus.Description).To(ContainSubstring("rolling back create of VirtualService default/toBeCreated"))
+	})
+})
@@ -0,0 +1,164 @@
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/kyma-project/api-gateway/internal/tracing"
+)
+
+// transaction records the changes applied during a reconciliation together with the versions of the objects before
+// the changes, so that the changes can be rolled back if a later change fails. Otherwise, a VirtualService that was
+// already switched to a new route would stay live with the AuthorizationPolicies of the previous route.
+type transaction struct {
+	client client.Client
+	// reader reads the previous versions of the objects from the API server. The client might read from a cache that
+	// doesn't contain the latest version yet, and a rollback to an outdated version would revert changes of others.
+	reader  client.Reader
+	applied []appliedChange
+}
+
+type appliedChange struct {
+	change *ObjectChange
+	// previous is the object before an update or delete, or nil if the change created the object.
+	previous client.Object
+}
+
+func newTransaction(k8sClient client.Client, reader client.Reader) *transaction {
+	return &transaction{client: k8sClient, reader: reader}
+}
+
+// snapshot reads the version of the object that is modified by the change from the API server. It returns nil for
+// changes that create the object, for updates that don't modify the object, and for objects that don't exist anymore.
+func (t *transaction) snapshot(ctx context.Context, change *ObjectChange) (client.Object, error) {
+	if change.Action == create {
+		return nil, nil
+	}
+
+	if change.Action == update {
+		// Most updates don't modify the object, so they are compared with the version read by the client first, which
+		// avoids reading the object from the API server in every reconciliation. If the version of the client is
+		// outdated, the update fails with a conflict, because it is applied with the resource version.
+		current, err := t.newObject(change.Obj)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.client.Get(ctx, client.ObjectKeyFromObject(change.Obj), current); err != nil {
+			if apierrs.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if modified, err := modifies(change.Obj, current); err != nil || !modified {
+			return nil, err
+		}
+	}
+
+	previous, err := t.newObject(change.Obj)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.reader.Get(ctx, client.ObjectKeyFromObject(change.Obj), previous); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return previous, nil
+}
+
+// newObject returns an empty object of the same kind as obj.
+func (t *transaction) newObject(obj client.Object) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, t.client.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	runtimeObj, err := t.client.Scheme().New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	newObj, ok := runtimeObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a client object", gvk)
+	}
+	return newObj, nil
+}
+
+// modifies returns whether applying obj changes the current version of the object. Only the fields applied by
+// applyObject are compared.
+func modifies(obj, current client.Object) (bool, error) {
+	for key, value := range obj.GetLabels() {
+		if currentValue, ok := current.GetLabels()[key]; !ok || currentValue != value {
+			return true, nil
+		}
+	}
+	for key, value := range obj.GetAnnotations() {
+		if currentValue, ok := current.GetAnnotations()[key]; !ok || currentValue != value {
+			return true, nil
+		}
+	}
+
+	desiredContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, err
+	}
+	currentContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(current)
+	if err != nil {
+		return false, err
+	}
+	for key, value := range desiredContent {
+		if key == "metadata" || key == "status" || key == "apiVersion" || key == "kind" {
+			continue
+		}
+		if !equality.Semantic.DeepEqual(value, currentContent[key]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *transaction) record(change *ObjectChange, previous client.Object) {
+	t.applied = append(t.applied, appliedChange{change: change, previous: previous})
+}
+
+// rollback reverts the applied changes in reverse order. Created objects are deleted, and updated and deleted objects
+// are restored to their previous version. It returns the errors of the changes that couldn't be reverted.
+func (t *transaction) rollback(ctx context.Context) []error {
+	ctx, span := tracing.Start(ctx, "processing.Rollback")
+	var errs []error
+	defer func() { tracing.End(span, errors.Join(errs...)) }()
+
+	for i := len(t.applied) - 1; i >= 0; i-- {
+		if err := t.revert(ctx, t.applied[i]); err != nil {
+			obj := t.applied[i].change.Obj
+			gvk, _ := apiutil.GVKForObject(obj, t.client.Scheme())
+			errs = append(errs, fmt.Errorf("rolling back %s of %s %s/%s: %w", t.applied[i].change.Action,
+				gvk.Kind, obj.GetNamespace(), obj.GetName(), err))
+		}
+	}
+	t.applied = nil
+	return errs
+}
+
+func (t *transaction) revert(ctx context.Context, applied appliedChange) error {
+	switch applied.change.Action {
+	case create:
+		return client.IgnoreNotFound(t.client.Delete(ctx, applied.change.Obj))
+	case update, delete:
+		if applied.previous == nil {
+			return nil
+		}
+		// The previous version is applied without resource version, because the object was modified by the change.
+		// A deleted object is recreated with the same name.
+		return applyObject(ctx, t.client, applied.previous, false)
+	default:
+		return fmt.Errorf("rollback of action %s is not supported", applied.change.Action)
+	}
+}
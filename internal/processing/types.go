@@ -3,6 +3,8 @@ package processing
 import (
 	v1beta1 "istio.io/api/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 )
 
 type Action int
@@ -63,4 +65,18 @@ type ReconciliationConfig struct {
 	OathkeeperSvcPort uint32
 	CorsConfig        *CorsConfig
 	DefaultDomainName string
+	// ConsolidateAuthorizationPolicies controls that AuthorizationPolicies of APIRules that only differ in their rules
+	// are merged into one AuthorizationPolicy. It can be overridden per APIRule with an annotation.
+	ConsolidateAuthorizationPolicies bool
+	// DefaultTimeout is the timeout in seconds of APIRules that don't define a timeout. The default timeout of 180
+	// seconds is used if it is 0.
+	DefaultTimeout uint32
+	// DefaultCorsPolicy is the CORS policy of APIRules that don't define a CORS policy.
+	DefaultCorsPolicy *gatewayv2alpha1.CorsPolicy
+	// BackendNetworkPolicies controls that a NetworkPolicy is created for each workload exposed by an APIRule, which
+	// only allows ingress traffic from the Istio ingress gateway. It can be overridden per APIRule with an annotation.
+	BackendNetworkPolicies bool
+	// BackendNetworkPoliciesAllowMeshTraffic controls that the NetworkPolicies additionally allow ingress traffic from
+	// workloads with an Istio sidecar.
+	BackendNetworkPoliciesAllowMeshTraffic bool
 }
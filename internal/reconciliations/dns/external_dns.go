@@ -0,0 +1,132 @@
+package dns
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/internal/reconciliations"
+)
+
+var DNSEndpointGVK = schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"}
+
+//go:embed dns_endpoint.yaml
+var dnsEndpointManifest []byte
+
+// externalDNS creates the records as DNSEndpoints of external-dns. external-dns must be configured with the crd source
+// to process them.
+type externalDNS struct{}
+
+func (externalDNS) Name() string {
+	return ExternalDNSProvider
+}
+
+func (externalDNS) SupportsMultipleNames() bool {
+	return true
+}
+
+func (externalDNS) Reconcile(ctx context.Context, k8sClient client.Client, record Record) error {
+	ctrl.Log.Info("Reconciling DNSEndpoint", "name", record.Name, "namespace", record.Namespace, "dnsNames", record.DNSNames)
+
+	templateValues := make(map[string]string)
+	templateValues["Name"] = record.Name
+	templateValues["Namespace"] = record.Namespace
+
+	endpoint, err := reconciliations.CreateUnstructuredResource(dnsEndpointManifest, templateValues)
+	if err != nil {
+		return err
+	}
+
+	if err := unstructured.SetNestedSlice(endpoint.Object, endpoints(record), "spec", "endpoints"); err != nil {
+		return err
+	}
+	endpoint.SetLabels(record.Labels)
+
+	if err := reconciliations.CreateOrUpdateResource(ctx, k8sClient, endpoint); err != nil {
+		return fmt.Errorf("failed to create or update DNSEndpoint %s/%s: %w", record.Namespace, record.Name, err)
+	}
+	return nil
+}
+
+// endpoints returns the external-dns endpoints of the record. IPv4 and IPv6 addresses are published as A and AAAA
+// records. Hostnames are published as CNAME record, which is only possible if there are no addresses, because a CNAME
+// record can't coexist with other records of the same name.
+func endpoints(record Record) []interface{} {
+	var ipv4, ipv6, hostnames []interface{}
+	for _, target := range record.Targets {
+		ip := net.ParseIP(target)
+		switch {
+		case ip == nil:
+			hostnames = append(hostnames, target)
+		case ip.To4() != nil:
+			ipv4 = append(ipv4, target)
+		default:
+			ipv6 = append(ipv6, target)
+		}
+	}
+
+	var result []interface{}
+	for _, dnsName := range record.DNSNames {
+		addEndpoint := func(recordType string, targets []interface{}) {
+			if len(targets) == 0 {
+				return
+			}
+			result = append(result, map[string]interface{}{
+				"dnsName":    dnsName,
+				"recordType": recordType,
+				"recordTTL":  int64(recordTTL),
+				"targets":    targets,
+			})
+		}
+
+		if len(ipv4) == 0 && len(ipv6) == 0 {
+			addEndpoint("CNAME", hostnames)
+			continue
+		}
+		addEndpoint("A", ipv4)
+		addEndpoint("AAAA", ipv6)
+	}
+	return result
+}
+
+func (externalDNS) Delete(ctx context.Context, k8sClient client.Client, name, namespace string) error {
+	ctrl.Log.Info("Deleting DNSEndpoint if it exists", "name", name, "namespace", namespace)
+	endpoint := unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(DNSEndpointGVK)
+	endpoint.SetName(name)
+	endpoint.SetNamespace(namespace)
+
+	if err := ignoreNotFoundOrNoMatch(k8sClient.Delete(ctx, &endpoint)); err != nil {
+		return fmt.Errorf("failed to delete DNSEndpoint %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// Status returns Ready once external-dns has processed the current generation of the DNSEndpoint. external-dns
+// doesn't report errors in the status of DNSEndpoints, so a record is never reported as Failed.
+func (externalDNS) Status(ctx context.Context, k8sClient client.Client, name, namespace string) (Status, error) {
+	endpoint := unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(DNSEndpointGVK)
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &endpoint); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return Status{State: Pending}, nil
+		}
+		return Status{}, err
+	}
+
+	observedGeneration, found, err := unstructured.NestedInt64(endpoint.Object, "status", "observedGeneration")
+	if err != nil {
+		return Status{}, err
+	}
+	if !found || observedGeneration < endpoint.GetGeneration() {
+		return Status{State: Pending, Message: "DNSEndpoint is not yet processed by external-dns"}, nil
+	}
+	return Status{State: Ready}, nil
+}
@@ -0,0 +1,149 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestExternalDNSReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		dnsNames      []string
+		targets       []string
+		wantEndpoints []interface{}
+	}{
+		{
+			name:     "IPv4 addresses are published as A records",
+			dnsNames: []string{"*.test-domain.com"},
+			targets:  []string{"10.0.0.1", "10.0.0.2"},
+			wantEndpoints: []interface{}{
+				endpoint("*.test-domain.com", "A", "10.0.0.1", "10.0.0.2"),
+			},
+		},
+		{
+			name:     "dual-stack addresses are published as A and AAAA records",
+			dnsNames: []string{"*.test-domain.com"},
+			targets:  []string{"10.0.0.1", "2001:db8::1"},
+			wantEndpoints: []interface{}{
+				endpoint("*.test-domain.com", "A", "10.0.0.1"),
+				endpoint("*.test-domain.com", "AAAA", "2001:db8::1"),
+			},
+		},
+		{
+			name:     "hostname is published as CNAME record",
+			dnsNames: []string{"*.test-domain.com"},
+			targets:  []string{"some.host.name"},
+			wantEndpoints: []interface{}{
+				endpoint("*.test-domain.com", "CNAME", "some.host.name"),
+			},
+		},
+		{
+			name:     "hostname is ignored if there are addresses",
+			dnsNames: []string{"*.test-domain.com"},
+			targets:  []string{"10.0.0.1", "some.host.name"},
+			wantEndpoints: []interface{}{
+				endpoint("*.test-domain.com", "A", "10.0.0.1"),
+			},
+		},
+		{
+			name:     "every DNS name is published",
+			dnsNames: []string{"*.test-domain.com", "app.other-domain.com"},
+			targets:  []string{"10.0.0.1"},
+			wantEndpoints: []interface{}{
+				endpoint("*.test-domain.com", "A", "10.0.0.1"),
+				endpoint("app.other-domain.com", "A", "10.0.0.1"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := createFakeClientForDnsTests(t)
+			record := testRecord(tc.targets, IPStackTypeIPv4)
+			record.DNSNames = tc.dnsNames
+			record.Labels = map[string]string{"app.kubernetes.io/name": "test"}
+
+			require.NoError(t, externalDNS{}.Reconcile(context.Background(), k8sClient, record))
+
+			created := unstructured.Unstructured{}
+			created.SetGroupVersionKind(DNSEndpointGVK)
+			require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &created))
+
+			endpoints, _, err := unstructured.NestedSlice(created.Object, "spec", "endpoints")
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEndpoints, endpoints)
+			assert.Equal(t, "test", created.GetLabels()["app.kubernetes.io/name"])
+			assert.Equal(t, disclaimerValue, created.GetAnnotations()[disclaimerKey])
+		})
+	}
+}
+
+func TestExternalDNSStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		observedGeneration *int64
+		wantState          State
+	}{
+		{name: "processed endpoint is ready", observedGeneration: ptr(int64(1)), wantState: Ready},
+		{name: "endpoint processed in an older generation is pending", observedGeneration: ptr(int64(0)), wantState: Pending},
+		{name: "endpoint without status is pending", wantState: Pending},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint := &unstructured.Unstructured{}
+			endpoint.SetGroupVersionKind(DNSEndpointGVK)
+			endpoint.SetName("test")
+			endpoint.SetNamespace("test-ns")
+			endpoint.SetGeneration(1)
+			if tc.observedGeneration != nil {
+				require.NoError(t, unstructured.SetNestedField(endpoint.Object, *tc.observedGeneration, "status", "observedGeneration"))
+			}
+			k8sClient := createFakeClientForDnsTests(t, endpoint)
+
+			status, err := externalDNS{}.Status(context.Background(), k8sClient, "test", "test-ns")
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantState, status.State)
+		})
+	}
+
+	t.Run("missing endpoint is pending", func(t *testing.T) {
+		status, err := externalDNS{}.Status(context.Background(), createFakeClientForDnsTests(t), "test", "test-ns")
+
+		require.NoError(t, err)
+		assert.Equal(t, Pending, status.State)
+	})
+}
+
+func TestExternalDNSDelete(t *testing.T) {
+	k8sClient := createFakeClientForDnsTests(t)
+	require.NoError(t, externalDNS{}.Reconcile(context.Background(), k8sClient, testRecord([]string{"10.0.0.1"}, IPStackTypeIPv4)))
+
+	require.NoError(t, externalDNS{}.Delete(context.Background(), k8sClient, "test", "test-ns"))
+	require.NoError(t, externalDNS{}.Delete(context.Background(), k8sClient, "test", "test-ns"), "deleting a missing endpoint must not fail")
+
+	endpoint := unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(DNSEndpointGVK)
+	err := k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &endpoint)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func endpoint(dnsName, recordType string, targets ...interface{}) interface{} {
+	return map[string]interface{}{
+		"dnsName":    dnsName,
+		"recordType": recordType,
+		"recordTTL":  int64(recordTTL),
+		"targets":    targets,
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,104 @@
+package dns
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/internal/reconciliations"
+)
+
+const IPStackAnnotation = "dns.gardener.cloud/ip-stack"
+
+//go:embed dns_entry.yaml
+var dnsEntryManifest []byte
+
+// gardener creates the records as Gardener DNSEntries.
+type gardener struct{}
+
+func (gardener) Name() string {
+	return GardenerProvider
+}
+
+// SupportsMultipleNames returns false, because a DNSEntry has a single DNS name.
+func (gardener) SupportsMultipleNames() bool {
+	return false
+}
+
+func (gardener) Reconcile(ctx context.Context, k8sClient client.Client, record Record) error {
+	if len(record.DNSNames) != 1 {
+		return fmt.Errorf("DNSEntry %s/%s must have exactly one DNS name, got %d", record.Namespace, record.Name, len(record.DNSNames))
+	}
+	ctrl.Log.Info("Reconciling DNSEntry", "name", record.Name, "namespace", record.Namespace, "dnsName", record.DNSNames[0])
+
+	templateValues := make(map[string]string)
+	templateValues["Name"] = record.Name
+	templateValues["Namespace"] = record.Namespace
+	templateValues["DNSName"] = record.DNSNames[0]
+
+	entry, err := reconciliations.CreateUnstructuredResource(dnsEntryManifest, templateValues)
+	if err != nil {
+		return err
+	}
+
+	if err := unstructured.SetNestedStringSlice(entry.Object, record.Targets, "spec", "targets"); err != nil {
+		return err
+	}
+	entry.SetLabels(record.Labels)
+	if record.IPStackType == IPStackTypeDualStack || record.IPStackType == IPStackTypeIPv6 {
+		annotations := entry.GetAnnotations()
+		annotations[IPStackAnnotation] = record.IPStackType
+		entry.SetAnnotations(annotations)
+	}
+
+	if err := reconciliations.CreateOrUpdateResource(ctx, k8sClient, entry); err != nil {
+		return fmt.Errorf("failed to create or update DNSEntry %s/%s: %w", record.Namespace, record.Name, err)
+	}
+	return nil
+}
+
+func (gardener) Delete(ctx context.Context, k8sClient client.Client, name, namespace string) error {
+	ctrl.Log.Info("Deleting DNSEntry if it exists", "name", name, "namespace", namespace)
+	entry := dnsv1alpha1.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	if err := ignoreNotFoundOrNoMatch(k8sClient.Delete(ctx, &entry)); err != nil {
+		return fmt.Errorf("failed to delete DNSEntry %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+func (gardener) Status(ctx context.Context, k8sClient client.Client, name, namespace string) (Status, error) {
+	entry := dnsv1alpha1.DNSEntry{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &entry); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return Status{State: Pending}, nil
+		}
+		return Status{}, err
+	}
+
+	var message string
+	if entry.Status.Message != nil {
+		message = *entry.Status.Message
+	}
+
+	switch entry.Status.State {
+	case dnsv1alpha1.STATE_READY:
+		return Status{State: Ready, Message: message}, nil
+	case dnsv1alpha1.STATE_ERROR, dnsv1alpha1.STATE_INVALID, dnsv1alpha1.STATE_STALE:
+		return Status{State: Failed, Message: message}, nil
+	default:
+		return Status{State: Pending, Message: message}, nil
+	}
+}
@@ -0,0 +1,193 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func createFakeClientForDnsTests(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	require.NoError(t, corev1.AddToScheme(scheme.Scheme))
+	require.NoError(t, dnsv1alpha1.AddToScheme(scheme.Scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme.Scheme))
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+}
+
+const (
+	disclaimerKey   = "apigateways.operator.kyma-project.io/managed-by-disclaimer"
+	disclaimerValue = "DO NOT EDIT - This resource is managed by Kyma.\nAny modifications are discarded and the resource is reverted to the original state."
+
+	gardenerClassKey   = "dns.gardener.cloud/class"
+	gardenerClassValue = "garden"
+)
+
+func testRecord(targets []string, ipStackType string) Record {
+	return Record{
+		Name:        "test",
+		Namespace:   "test-ns",
+		DNSNames:    []string{"*.test-domain.com"},
+		Targets:     targets,
+		IPStackType: ipStackType,
+	}
+}
+
+func TestGardenerReconcile(t *testing.T) {
+	tests := []struct {
+		name                      string
+		targets                   []string
+		ipStackType               string
+		wantTargets               []string
+		wantAdditionalAnnotations map[string]string // additional annotations expected beyond Gardener class and disclaimer
+	}{
+		{
+			name:        "single IPv4 address - no ip-stack annotation",
+			targets:     []string{"10.0.0.1"},
+			ipStackType: IPStackTypeIPv4,
+			wantTargets: []string{"10.0.0.1"},
+		},
+		{
+			name:        "multiple IPv4 addresses - no ip-stack annotation",
+			targets:     []string{"10.0.0.1", "10.0.0.2"},
+			ipStackType: IPStackTypeIPv4,
+			wantTargets: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:        "hostname target (DNS-based LB) - no ip-stack annotation",
+			targets:     []string{"some.host.name"},
+			ipStackType: IPStackTypeIPv4,
+			wantTargets: []string{"some.host.name"},
+		},
+		{
+			name:                      "IPv6 address - ip-stack annotation set to ipv6",
+			targets:                   []string{"2001:db8::1"},
+			ipStackType:               IPStackTypeIPv6,
+			wantTargets:               []string{"2001:db8::1"},
+			wantAdditionalAnnotations: map[string]string{IPStackAnnotation: IPStackTypeIPv6},
+		},
+		{
+			name:                      "dual-stack addresses - ip-stack annotation set to dual-stack",
+			targets:                   []string{"10.0.0.1", "2001:db8::1"},
+			ipStackType:               IPStackTypeDualStack,
+			wantTargets:               []string{"10.0.0.1", "2001:db8::1"},
+			wantAdditionalAnnotations: map[string]string{IPStackAnnotation: IPStackTypeDualStack},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := createFakeClientForDnsTests(t)
+
+			err := gardener{}.Reconcile(context.Background(), k8sClient, testRecord(tc.targets, tc.ipStackType))
+			require.NoError(t, err)
+
+			created := dnsv1alpha1.DNSEntry{}
+			require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &created))
+
+			assert.Equal(t, "*.test-domain.com", created.Spec.DNSName)
+			assert.ElementsMatch(t, tc.wantTargets, created.Spec.Targets)
+			assert.Equal(t, "garden", created.Annotations["dns.gardener.cloud/class"])
+
+			if tc.wantAdditionalAnnotations == nil {
+				tc.wantAdditionalAnnotations = map[string]string{}
+			}
+			tc.wantAdditionalAnnotations[disclaimerKey] = disclaimerValue
+			tc.wantAdditionalAnnotations[gardenerClassKey] = gardenerClassValue
+
+			assert.Equal(t, tc.wantAdditionalAnnotations, created.Annotations)
+
+			if tc.ipStackType == IPStackTypeIPv4 {
+				assert.NotContains(t, created.Annotations, IPStackAnnotation, "IPv4 entries must not carry the ip-stack annotation")
+			}
+		})
+	}
+}
+
+func TestGardenerReconcile_ReappliesAnnotationsAndLabels(t *testing.T) {
+	k8sClient := createFakeClientForDnsTests(t)
+
+	require.NoError(t, gardener{}.Reconcile(context.Background(), k8sClient, testRecord([]string{"10.0.0.1"}, IPStackTypeIPv4)))
+
+	// Simulate a manual edit that strips all annotations.
+	dnsEntry := dnsv1alpha1.DNSEntry{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &dnsEntry))
+	dnsEntry.Annotations = nil
+	dnsEntry.Labels = nil
+	require.NoError(t, k8sClient.Update(context.Background(), &dnsEntry))
+
+	// Reconcile again — annotations must be restored.
+	require.NoError(t, gardener{}.Reconcile(context.Background(), k8sClient, testRecord([]string{"10.0.0.1"}, IPStackTypeIPv4)))
+
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &dnsEntry))
+	assert.Equal(t, "garden", dnsEntry.Annotations["dns.gardener.cloud/class"])
+	assert.Equal(t, disclaimerValue, dnsEntry.Annotations[disclaimerKey])
+	assert.Equal(t, "api-gateway", dnsEntry.Labels["kyma-project.io/module"])
+}
+
+func TestGardenerReconcile_RequiresSingleDNSName(t *testing.T) {
+	k8sClient := createFakeClientForDnsTests(t)
+	record := testRecord([]string{"10.0.0.1"}, IPStackTypeIPv4)
+	record.DNSNames = append(record.DNSNames, "other.test-domain.com")
+
+	err := gardener{}.Reconcile(context.Background(), k8sClient, record)
+
+	assert.ErrorContains(t, err, "must have exactly one DNS name")
+}
+
+func TestGardenerStatus(t *testing.T) {
+	message := "some message"
+	tests := []struct {
+		name      string
+		state     string
+		wantState State
+	}{
+		{name: "ready entry", state: dnsv1alpha1.STATE_READY, wantState: Ready},
+		{name: "entry with error", state: dnsv1alpha1.STATE_ERROR, wantState: Failed},
+		{name: "invalid entry", state: dnsv1alpha1.STATE_INVALID, wantState: Failed},
+		{name: "stale entry", state: dnsv1alpha1.STATE_STALE, wantState: Failed},
+		{name: "entry without state", state: "", wantState: Pending},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := &dnsv1alpha1.DNSEntry{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+				Status:     dnsv1alpha1.DNSEntryStatus{State: tc.state, Message: &message},
+			}
+			k8sClient := createFakeClientForDnsTests(t, entry)
+
+			status, err := gardener{}.Status(context.Background(), k8sClient, "test", "test-ns")
+
+			require.NoError(t, err)
+			assert.Equal(t, Status{State: tc.wantState, Message: message}, status)
+		})
+	}
+
+	t.Run("missing entry is pending", func(t *testing.T) {
+		status, err := gardener{}.Status(context.Background(), createFakeClientForDnsTests(t), "test", "test-ns")
+
+		require.NoError(t, err)
+		assert.Equal(t, Pending, status.State)
+	})
+}
+
+func TestGardenerDelete(t *testing.T) {
+	entry := &dnsv1alpha1.DNSEntry{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"}}
+	k8sClient := createFakeClientForDnsTests(t, entry)
+
+	require.NoError(t, gardener{}.Delete(context.Background(), k8sClient, "test", "test-ns"))
+	require.NoError(t, gardener{}.Delete(context.Background(), k8sClient, "test", "test-ns"), "deleting a missing entry must not fail")
+
+	err := k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &dnsv1alpha1.DNSEntry{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
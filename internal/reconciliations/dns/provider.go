@@ -0,0 +1,126 @@
+// Package dns manages the DNS records that point the domains of the gateways to the Istio ingress gateway. The records
+// are created by a Provider, which is either Gardener or external-dns.
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/internal/dependencies"
+)
+
+const (
+	GardenerProvider    = "gardener"
+	ExternalDNSProvider = "external-dns"
+
+	// recordTTL is the TTL in seconds of the DNS records.
+	recordTTL = 600
+)
+
+// Record is a DNS record that points DNS names to the targets of the Istio ingress gateway.
+type Record struct {
+	Name      string
+	Namespace string
+	// DNSNames are the names of the record, e.g. "*.example.com".
+	DNSNames []string
+	// Targets are the IP addresses and hostnames the DNS names resolve to.
+	Targets []string
+	// IPStackType is the IP stack of the targets, see IngressGatewayTargets.
+	IPStackType string
+	Labels      map[string]string
+}
+
+// State is the state of a DNS record in the DNS provider.
+type State int
+
+const (
+	Pending State = iota
+	Ready
+	Failed
+)
+
+// Status is the status of a DNS record in the DNS provider.
+type Status struct {
+	State   State
+	Message string
+}
+
+// Provider creates DNS records.
+type Provider interface {
+	Name() string
+	// SupportsMultipleNames returns whether a record of the provider can have more than one DNS name.
+	SupportsMultipleNames() bool
+	Reconcile(ctx context.Context, k8sClient client.Client, record Record) error
+	// Delete deletes the record. It doesn't fail if the record or the CRD of the provider doesn't exist.
+	Delete(ctx context.Context, k8sClient client.Client, name, namespace string) error
+	Status(ctx context.Context, k8sClient client.Client, name, namespace string) (Status, error)
+}
+
+// Providers returns all DNS providers.
+func Providers() []Provider {
+	return []Provider{gardener{}, externalDNS{}}
+}
+
+// ProviderByName returns the DNS provider with the given name.
+func ProviderByName(name string) (Provider, error) {
+	for _, p := range Providers() {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown DNS provider %q", name)
+}
+
+// ProviderDependencies returns the CRDs that must be installed to use the DNS provider.
+func ProviderDependencies(name string) (dependencies.Dependencies, error) {
+	switch name {
+	case GardenerProvider:
+		return dependencies.Gardener(), nil
+	case ExternalDNSProvider:
+		return dependencies.ExternalDNS(), nil
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q", name)
+	}
+}
+
+// DetectProvider returns Gardener if it is installed in the cluster, otherwise external-dns if it is installed. It
+// returns nil if no DNS provider is installed.
+func DetectProvider(ctx context.Context, k8sClient client.Client) (Provider, error) {
+	for _, p := range Providers() {
+		deps, err := ProviderDependencies(p.Name())
+		if err != nil {
+			return nil, err
+		}
+		if _, err := deps.AreAvailable(ctx, k8sClient); err == nil {
+			return p, nil
+		} else if !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check dependencies of DNS provider %s: %w", p.Name(), err)
+		}
+	}
+	return nil, nil
+}
+
+// DeleteAll deletes the record of all DNS providers except the given one, which can be nil.
+func DeleteAll(ctx context.Context, k8sClient client.Client, name, namespace string, except Provider) error {
+	for _, p := range Providers() {
+		if except != nil && p.Name() == except.Name() {
+			continue
+		}
+		if err := p.Delete(ctx, k8sClient, name, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ignoreNotFoundOrNoMatch ignores errors of resources that don't exist or whose CRD is not installed.
+func ignoreNotFoundOrNoMatch(err error) error {
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func crd(name string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func TestProviderByName(t *testing.T) {
+	provider, err := ProviderByName(ExternalDNSProvider)
+	require.NoError(t, err)
+	assert.Equal(t, ExternalDNSProvider, provider.Name())
+
+	_, err = ProviderByName("unknown")
+	assert.ErrorContains(t, err, `unknown DNS provider "unknown"`)
+}
+
+func TestDetectProvider(t *testing.T) {
+	gardenerCRDs := []client.Object{crd("dnsentries.dns.gardener.cloud"), crd("certificates.cert.gardener.cloud")}
+	externalDNSCRDs := []client.Object{crd("dnsendpoints.externaldns.k8s.io")}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		wantProvider string
+	}{
+		{name: "no provider installed", wantProvider: ""},
+		{name: "Gardener installed", objects: gardenerCRDs, wantProvider: GardenerProvider},
+		{name: "external-dns installed", objects: externalDNSCRDs, wantProvider: ExternalDNSProvider},
+		{name: "Gardener is preferred over external-dns", objects: append(gardenerCRDs, externalDNSCRDs...), wantProvider: GardenerProvider},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := createFakeClientForDnsTests(t, tc.objects...)
+
+			provider, err := DetectProvider(context.Background(), k8sClient)
+
+			require.NoError(t, err)
+			if tc.wantProvider == "" {
+				assert.Nil(t, provider)
+			} else {
+				require.NotNil(t, provider)
+				assert.Equal(t, tc.wantProvider, provider.Name())
+			}
+		})
+	}
+}
+
+func TestDeleteAll(t *testing.T) {
+	record := testRecord([]string{"10.0.0.1"}, IPStackTypeIPv4)
+
+	t.Run("deletes the records of all providers", func(t *testing.T) {
+		k8sClient := createFakeClientForDnsTests(t)
+		require.NoError(t, gardener{}.Reconcile(context.Background(), k8sClient, record))
+		require.NoError(t, externalDNS{}.Reconcile(context.Background(), k8sClient, record))
+
+		require.NoError(t, DeleteAll(context.Background(), k8sClient, "test", "test-ns", nil))
+
+		assert.True(t, k8serrors.IsNotFound(getDNSEntry(k8sClient)))
+		assert.True(t, k8serrors.IsNotFound(getDNSEndpoint(k8sClient)))
+	})
+
+	t.Run("keeps the record of the excepted provider", func(t *testing.T) {
+		k8sClient := createFakeClientForDnsTests(t)
+		require.NoError(t, gardener{}.Reconcile(context.Background(), k8sClient, record))
+		require.NoError(t, externalDNS{}.Reconcile(context.Background(), k8sClient, record))
+
+		require.NoError(t, DeleteAll(context.Background(), k8sClient, "test", "test-ns", externalDNS{}))
+
+		assert.True(t, k8serrors.IsNotFound(getDNSEntry(k8sClient)))
+		assert.NoError(t, getDNSEndpoint(k8sClient))
+	})
+}
+
+func getDNSEntry(k8sClient client.Client) error {
+	return k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &dnsv1alpha1.DNSEntry{})
+}
+
+func getDNSEndpoint(k8sClient client.Client) error {
+	endpoint := unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(DNSEndpointGVK)
+	return k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &endpoint)
+}
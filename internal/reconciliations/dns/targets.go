@@ -0,0 +1,67 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	IPStackTypeIPv4      = "ipv4"
+	IPStackTypeIPv6      = "ipv6"
+	IPStackTypeDualStack = "dual-stack"
+)
+
+// DefaultIngressGatewayService is the Service of the default Istio ingress gateway.
+var DefaultIngressGatewayService = types.NamespacedName{
+	Name:      "istio-ingressgateway",
+	Namespace: "istio-system",
+}
+
+// IngressGatewayTargets returns the external IPs and hostnames of the istio-ingressgateway service.
+// The second return value indicates the type of the Service (IPv4, IPv6 or DualStack) based on IPFamilies field of the Service spec.
+// In case the IPFamilies field is not set, it defaults to IPv4.
+func IngressGatewayTargets(ctx context.Context, k8sClient client.Client) ([]string, string, error) {
+	return ServiceTargets(ctx, k8sClient, DefaultIngressGatewayService)
+}
+
+// ServiceTargets returns the external IPs and hostnames of the given Service of an Istio ingress gateway like
+// IngressGatewayTargets.
+func ServiceTargets(ctx context.Context, k8sClient client.Client, istioIngressGatewayNamespaceName types.NamespacedName) ([]string, string, error) {
+	svc := corev1.Service{}
+	if err := k8sClient.Get(ctx, istioIngressGatewayNamespaceName, &svc); err != nil {
+		return nil, "", err
+	}
+
+	stackType := IPStackTypeIPv4
+	if len(svc.Spec.IPFamilies) == 2 {
+		stackType = IPStackTypeDualStack
+	} else if len(svc.Spec.IPFamilies) == 1 && svc.Spec.IPFamilies[0] == corev1.IPv6Protocol {
+		stackType = IPStackTypeIPv6
+	}
+
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return nil, stackType, fmt.Errorf("no ingress exists for %s", istioIngressGatewayNamespaceName.String())
+	}
+
+	var targets []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			targets = append(targets, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			targets = append(targets, ingress.Hostname)
+		}
+	}
+
+	ctrl.Log.Info("Found istio ingress gateway IP addresses", "targets", targets, "stackType", stackType)
+	if len(targets) > 0 {
+		return targets, stackType, nil
+	}
+
+	return nil, stackType, fmt.Errorf("no ingress targets found for %s", istioIngressGatewayNamespaceName.String())
+}
@@ -0,0 +1,183 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIngressGatewayTargets(t *testing.T) {
+	tests := []struct {
+		name            string
+		service         corev1.Service
+		wantAddresses   []string
+		wantStackType   string
+		wantErrContains string
+	}{
+		{
+			name: "single IPv4 LoadBalancer IP",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{IP: "172.0.0.1"}},
+					},
+				},
+			},
+			wantAddresses: []string{"172.0.0.1"},
+			wantStackType: IPStackTypeIPv4,
+		},
+		{
+			name: "multiple IPv4 LoadBalancer IPs",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{IP: "172.0.0.1"},
+							{IP: "172.0.0.2"},
+						},
+					},
+				},
+			},
+			wantAddresses: []string{"172.0.0.1", "172.0.0.2"},
+			wantStackType: IPStackTypeIPv4,
+		},
+		{
+			name: "IPv6 LoadBalancer IP",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{IP: "2001:db8::1"}},
+					},
+				},
+			},
+			wantAddresses: []string{"2001:db8::1"},
+			wantStackType: IPStackTypeIPv6,
+		},
+		{
+			name: "dual-stack LoadBalancer IPs",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{IP: "172.0.0.1"},
+							{IP: "2001:db8::1"},
+						},
+					},
+				},
+			},
+			wantAddresses: []string{"172.0.0.1", "2001:db8::1"},
+			wantStackType: IPStackTypeDualStack,
+		},
+		{
+			name: "DNS-based hostname LoadBalancer (IPv4)",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{Hostname: "some.host.name"}},
+					},
+				},
+			},
+			wantAddresses: []string{"some.host.name"},
+			wantStackType: IPStackTypeIPv4,
+		},
+		{
+			name: "ingress entry with both IP and hostname - both appended as targets",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{IP: "172.0.0.1", Hostname: "some.host.name"}},
+					},
+				},
+			},
+			wantAddresses: []string{"172.0.0.1", "some.host.name"},
+			wantStackType: IPStackTypeIPv4,
+		},
+		{
+			name: "multiple ingress entries each with IP and hostname",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{IP: "172.0.0.1", Hostname: "host1.example.com"},
+							{IP: "2001:db8::1", Hostname: "host2.example.com"},
+						},
+					},
+				},
+			},
+			wantAddresses: []string{"172.0.0.1", "host1.example.com", "2001:db8::1", "host2.example.com"},
+			wantStackType: IPStackTypeDualStack,
+		},
+		{
+			name: "no ingress entries returns error",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+			},
+			wantStackType:   IPStackTypeIPv4,
+			wantErrContains: "no ingress exists for",
+		},
+		{
+			name: "ingress entry with neither IP nor hostname returns error",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{}},
+					},
+				},
+			},
+			wantStackType:   IPStackTypeIPv4,
+			wantErrContains: "no ingress targets found for",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := createFakeClientForDnsTests(t, &tc.service)
+
+			addresses, stackType, err := IngressGatewayTargets(context.Background(), k8sClient)
+
+			assert.Equal(t, tc.wantStackType, stackType)
+
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.ErrorContains(t, err, tc.wantErrContains)
+				assert.Nil(t, addresses)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantAddresses, addresses)
+			}
+		})
+	}
+}
@@ -2,137 +2,48 @@ package externalgateway
 
 import (
 	"context"
-	_ "embed"
 	"fmt"
 
-	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
-	corev1 "k8s.io/api/core/v1"
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
 )
 
-// ReconcileDNSEntry creates or updates the Gardener DNSEntry for the external gateway
-func ReconcileDNSEntry(ctx context.Context, k8sClient client.Client, external *externalv1alpha1.ExternalGateway, internalDomain string) error {
+// ReconcileDNSEntry creates or updates the DNS record of the external gateway with the given DNS provider and deletes
+// the records of the other providers.
+func ReconcileDNSEntry(ctx context.Context, k8sClient client.Client, provider dns.Provider, external *externalv1alpha1.ExternalGateway, internalDomain string) error {
 	dnsName := external.DNSEntryName()
 
-	ctrl.Log.Info("Reconciling DNSEntry", "name", dnsName, "namespace", istioSystemNamespace, "domain", internalDomain)
+	ctrl.Log.Info("Reconciling DNS record", "name", dnsName, "namespace", istioSystemNamespace, "domain", internalDomain, "provider", provider.Name())
 
-	istioIngressGatewayTargets, err := fetchIngressGatewayTargets(ctx, k8sClient)
-	if err != nil {
-		return fmt.Errorf("failed to fetch Istio ingress gateway IP: %w", err)
-	}
-
-	dnsEntry := &dnsv1alpha1.DNSEntry{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      dnsName,
-			Namespace: istioSystemNamespace,
-		},
+	if err := dns.DeleteAll(ctx, k8sClient, dnsName, istioSystemNamespace, provider); err != nil {
+		return err
 	}
 
-	// create dnsEntry with the LoadBalancer IP of the Istio Ingress Gateway as target with type
-	operation, err := controllerutil.CreateOrUpdate(ctx, k8sClient, dnsEntry, func() error {
-		dnsEntry.Labels = GetStandardLabels(external)
-		dnsEntry.Annotations = map[string]string{
-			"dns.gardener.cloud/class": "garden",
-		}
-		dnsEntry.Spec = dnsv1alpha1.DNSEntrySpec{
-			DNSName: internalDomain,
-			TTL:     ptr.To(int64(600)),
-			Targets: istioIngressGatewayTargets,
-		}
-		return nil
-	})
-
+	targets, ipStackType, err := dns.IngressGatewayTargets(ctx, k8sClient)
 	if err != nil {
-		ctrl.Log.Error(err, "Failed to create or update DNSEntry ", "name", dnsName, "namespace", istioSystemNamespace, "error", err)
-		return fmt.Errorf("failed to create or update DNSEntry %s/%s: %w", istioSystemNamespace, dnsName, err)
+		return fmt.Errorf("failed to fetch Istio ingress gateway IP: %w", err)
 	}
 
-	ctrl.Log.Info("Successfully reconciled DNSEntry", "name", dnsName, "namespace", istioSystemNamespace, "operation", operation)
-	return nil
+	return provider.Reconcile(ctx, k8sClient, dns.Record{
+		Name:        dnsName,
+		Namespace:   istioSystemNamespace,
+		DNSNames:    []string{internalDomain},
+		Targets:     targets,
+		IPStackType: ipStackType,
+		Labels:      GetStandardLabels(external),
+	})
 }
 
-// DeleteDNSEntry deletes the DNSEntry resource
+// DeleteDNSEntry deletes the DNS records of all providers
 func DeleteDNSEntry(ctx context.Context, k8sClient client.Client, dnsName string) error {
-	ctrl.Log.Info("Deleting DNSEntry if it exists", "name", dnsName, "namespace", istioSystemNamespace)
-
-	dnsEntry := &dnsv1alpha1.DNSEntry{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      dnsName,
-			Namespace: istioSystemNamespace,
-		},
-	}
-
-	err := k8sClient.Delete(ctx, dnsEntry)
-	if err != nil && !k8serrors.IsNotFound(err) {
-		return fmt.Errorf("failed to delete DNSEntry %s/%s: %w", istioSystemNamespace, dnsName, err)
-	}
-
-	if k8serrors.IsNotFound(err) {
-		ctrl.Log.Info("Skipped deletion of DNSEntry as it wasn't present", "name", dnsName)
-	} else {
-		ctrl.Log.Info("Successfully deleted DNSEntry", "name", dnsName)
-	}
-
-	return nil
+	return dns.DeleteAll(ctx, k8sClient, dnsName, istioSystemNamespace, nil)
 }
 
-// GetDNSEntryStatus returns the state and message of an existing DNSEntry sub-resource.
-// Returns empty strings when the DNSEntry does not yet exist.
-func GetDNSEntryStatus(ctx context.Context, k8sClient client.Client, dnsName string) (state, message string, err error) {
-	dns := &dnsv1alpha1.DNSEntry{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: dnsName, Namespace: istioSystemNamespace}, dns); err != nil {
-		if k8serrors.IsNotFound(err) {
-			return "", "", nil
-		}
-		return "", "", err
-	}
-	if dns.Status.Message != nil {
-		message = *dns.Status.Message
-	}
-	return dns.Status.State, message, nil
-}
-
-// fetchIngressGatewayTargets retrieves the LoadBalancer IP of the Istio Ingress Gateway service
-func fetchIngressGatewayTargets(ctx context.Context, k8sClient client.Client) ([]string, error) {
-	istioIngressGatewayNamespaceName := types.NamespacedName{
-		Name:      "istio-ingressgateway",
-		Namespace: istioSystemNamespace,
-	}
-
-	var svc corev1.Service
-	if err := k8sClient.Get(ctx, istioIngressGatewayNamespaceName, &svc); err != nil {
-		return []string{}, fmt.Errorf("failed to get istio-ingressgateway service: %w", err)
-	}
-
-	if len(svc.Status.LoadBalancer.Ingress) == 0 {
-		ctrl.Log.Info("Istio ingress gateway Service has no LoadBalancer addresses; DNSEntry cannot be created yet",
-			"service", istioIngressGatewayNamespaceName.String(),
-			"hint", "check the LoadBalancer service status")
-		return []string{}, fmt.Errorf("no ingress exists for %s", istioIngressGatewayNamespaceName.String())
-	}
-
-	var targets []string
-
-	for _, ingress := range svc.Status.LoadBalancer.Ingress {
-		if ingress.IP != "" {
-			ctrl.Log.Info("Found Istio Ingress Gateway IP", "ip", ingress.IP)
-			targets = append(targets, ingress.IP)
-		}
-		if ingress.Hostname != "" {
-			ctrl.Log.Info("Found Istio Ingress Gateway hostname", "hostname", ingress.Hostname)
-			targets = append(targets, ingress.Hostname)
-		}
-	}
-	if len(targets) == 0 {
-		return []string{}, fmt.Errorf("no ingress ip set for %s", istioIngressGatewayNamespaceName.String())
-	}
-	return targets, nil
+// GetDNSEntryStatus returns the status of the DNS record of the provider.
+// The record is reported as pending when it does not yet exist.
+func GetDNSEntryStatus(ctx context.Context, k8sClient client.Client, provider dns.Provider, dnsName string) (dns.Status, error) {
+	return provider.Status(ctx, k8sClient, dnsName, istioSystemNamespace)
 }
@@ -0,0 +1,102 @@
+package externalgateway
+
+import (
+	"context"
+	"testing"
+
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
+)
+
+func newDNSTestClient(t *testing.T) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = dnsv1alpha1.AddToScheme(scheme)
+	ingressGateway := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: istioSystemNamespace},
+		Spec:       corev1.ServiceSpec{IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "172.0.0.1"}, {IP: "2001:db8::1"}},
+			},
+		},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(ingressGateway).Build()
+}
+
+func TestReconcileDNSEntry_Gardener(t *testing.T) {
+	c := newDNSTestClient(t)
+	eg := newEG("eg", "ns", "api.example.com")
+	provider, _ := dns.ProviderByName(dns.GardenerProvider)
+
+	if err := ReconcileDNSEntry(context.Background(), c, provider, eg, "eg.internal.example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entry := &dnsv1alpha1.DNSEntry{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: eg.DNSEntryName(), Namespace: istioSystemNamespace}, entry); err != nil {
+		t.Fatalf("expected DNSEntry to be created: %v", err)
+	}
+	if entry.Spec.DNSName != "eg.internal.example.com" {
+		t.Errorf("expected DNS name eg.internal.example.com, got %s", entry.Spec.DNSName)
+	}
+	if got := entry.Annotations[dns.IPStackAnnotation]; got != dns.IPStackTypeDualStack {
+		t.Errorf("expected ip-stack annotation %s, got %q", dns.IPStackTypeDualStack, got)
+	}
+	if got := entry.Labels[ExternalGatewayOwnerLabelName]; got != "eg" {
+		t.Errorf("expected owner label eg, got %q", got)
+	}
+}
+
+func TestReconcileDNSEntry_ExternalDNSReplacesGardenerEntry(t *testing.T) {
+	c := newDNSTestClient(t)
+	eg := newEG("eg", "ns", "api.example.com")
+	gardener, _ := dns.ProviderByName(dns.GardenerProvider)
+	externalDNS, _ := dns.ProviderByName(dns.ExternalDNSProvider)
+
+	if err := ReconcileDNSEntry(context.Background(), c, gardener, eg, "eg.internal.example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ReconcileDNSEntry(context.Background(), c, externalDNS, eg, "eg.internal.example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := c.Get(context.Background(), client.ObjectKey{Name: eg.DNSEntryName(), Namespace: istioSystemNamespace}, &dnsv1alpha1.DNSEntry{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected DNSEntry to be deleted, got %v", err)
+	}
+
+	endpoint := &unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(dns.DNSEndpointGVK)
+	if err := c.Get(context.Background(), client.ObjectKey{Name: eg.DNSEntryName(), Namespace: istioSystemNamespace}, endpoint); err != nil {
+		t.Fatalf("expected DNSEndpoint to be created: %v", err)
+	}
+	endpoints, _, _ := unstructured.NestedSlice(endpoint.Object, "spec", "endpoints")
+	if len(endpoints) != 2 {
+		t.Fatalf("expected an A and an AAAA record, got %v", endpoints)
+	}
+
+	status, err := GetDNSEntryStatus(context.Background(), c, externalDNS, eg.DNSEntryName())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.State != dns.Pending {
+		t.Errorf("expected unprocessed DNSEndpoint to be pending, got %v", status.State)
+	}
+
+	if err := DeleteDNSEntry(context.Background(), c, eg.DNSEntryName()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: eg.DNSEntryName(), Namespace: istioSystemNamespace}, endpoint); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected DNSEndpoint to be deleted, got %v", err)
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/kyma-project/api-gateway/internal/reconciliations"
 	"github.com/kyma-project/api-gateway/internal/version"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,23 +22,65 @@ const (
 	// Istio IngressGateway requires the TLS secret to be present in the same namespace, that's why we have to use istio-system
 	certificateDefaultNamespace = "istio-system"
 	kymaGatewayCertSecretName   = "kyma-gateway-certs"
+	kymaGatewayCASecretName     = "kyma-gateway-ca"
 )
 
 //go:embed certificate.yaml
 var certificateManifest []byte
 
-func reconcileKymaGatewayCertificate(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, domain string) error {
+// kymaGatewayCertificate returns the certificate of the Kyma Gateway for the domain.
+func kymaGatewayCertificate(apiGatewayCR v1alpha1.APIGateway, domain string) gatewayCertificate {
+	return gatewayCertificate{
+		name:            kymaGatewayCertificateName,
+		secretName:      kymaGatewayCertSecretName,
+		caSecretName:    kymaGatewayCASecretName,
+		caConfigMap:     types.NamespacedName{Name: kymaGatewayCAConfigMapName, Namespace: kymaGatewayCAConfigMapNamespace},
+		domain:          domain,
+		additionalHosts: kymaGatewayConfig(apiGatewayCR).AdditionalHosts,
+	}
+}
+
+// reconcileKymaGatewayCertificate reconciles the certificate of the Kyma Gateway with the given provider and deletes the
+// resources of the other providers, e.g. after cert-manager was configured as provider instead of Gardener.
+func reconcileKymaGatewayCertificate(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, provider certificateProvider, domain string) error {
 	isEnabled := isKymaGatewayEnabled(apiGatewayCR)
-	ctrl.Log.Info("Reconciling Certificate", "KymaGatewayEnabled", isEnabled, "name", kymaGatewayCertificateName, "namespace", certificateDefaultNamespace)
+	ctrl.Log.Info("Reconciling Kyma Gateway certificate", "KymaGatewayEnabled", isEnabled, "provider", provider.name())
+
+	cert := kymaGatewayCertificate(apiGatewayCR, domain)
+	// The certificate is not needed if the Kyma Gateway uses a certificate Secret provided by the user.
+	if !isEnabled || apiGatewayCR.IsInDeletion() || hasOwnCertificate(apiGatewayCR) {
+		return deleteGatewayCertificate(ctx, k8sClient, cert)
+	}
+
+	return reconcileGatewayCertificate(ctx, k8sClient, cert, provider)
+}
 
-	if !isEnabled || apiGatewayCR.IsInDeletion() {
-		return deleteCertificate(ctx, k8sClient, kymaGatewayCertificateName)
+// reconcileGatewayCertificate reconciles the certificate of a gateway with the given provider and deletes the resources
+// of the other providers.
+func reconcileGatewayCertificate(ctx context.Context, k8sClient client.Client, cert gatewayCertificate, provider certificateProvider) error {
+	for _, p := range certificateProviders() {
+		if p.name() == provider.name() {
+			continue
+		}
+		if err := p.delete(ctx, k8sClient, cert); err != nil {
+			return err
+		}
 	}
 
-	return reconcileCertificate(ctx, k8sClient, kymaGatewayCertificateName, domain, kymaGatewayCertSecretName)
+	return provider.reconcile(ctx, k8sClient, cert)
 }
 
-func reconcileCertificate(ctx context.Context, k8sClient client.Client, name, domain, certSecretName string) error {
+// deleteGatewayCertificate deletes the resources of all providers and the Secret of the certificate of a gateway.
+func deleteGatewayCertificate(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error {
+	for _, p := range certificateProviders() {
+		if err := p.delete(ctx, k8sClient, cert); err != nil {
+			return err
+		}
+	}
+	return deleteSecret(ctx, k8sClient, cert.secretName, certificateDefaultNamespace)
+}
+
+func reconcileCertificate(ctx context.Context, k8sClient client.Client, name, domain, certSecretName string, additionalHosts []string) error {
 	ctrl.Log.Info("Reconciling Certificate", "name", name, "namespace", certificateDefaultNamespace, "domain", domain, "secretName", certSecretName)
 	templateValues := make(map[string]string)
 	templateValues["Name"] = name
@@ -45,7 +89,15 @@ func reconcileCertificate(ctx context.Context, k8sClient client.Client, name, do
 	templateValues["SecretName"] = certSecretName
 	templateValues["Version"] = version.GetModuleVersion()
 
-	return reconciliations.ApplyResource(ctx, k8sClient, certificateManifest, templateValues)
+	cert, err := reconciliations.CreateUnstructuredResource(certificateManifest, templateValues)
+	if err != nil {
+		return err
+	}
+	if err := addCertificateDNSNames(&cert, additionalHosts); err != nil {
+		return err
+	}
+
+	return reconciliations.CreateOrUpdateResource(ctx, k8sClient, cert)
 }
 
 func deleteCertificate(ctx context.Context, k8sClient client.Client, name string) error {
@@ -59,6 +111,10 @@ func deleteCertificate(ctx context.Context, k8sClient client.Client, name string
 	err := k8sClient.Delete(ctx, &c)
 
 	if err != nil && !k8serrors.IsNotFound(err) {
+		// The Certificate CRD is not installed in clusters without Gardener.
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to delete Certificate %s/%s: %v", certificateDefaultNamespace, name, err)
 	}
 
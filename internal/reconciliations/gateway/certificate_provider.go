@@ -0,0 +1,349 @@
+package gateway
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"slices"
+
+	certv1alpha1 "github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/dependencies"
+	"github.com/kyma-project/api-gateway/internal/reconciliations"
+	"github.com/kyma-project/api-gateway/internal/version"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	GardenerCertificateProvider    = "gardener"
+	CertManagerCertificateProvider = "cert-manager"
+	defaultCertificateProvider     = "default"
+
+	defaultCertificateIssuerKind = "ClusterIssuer"
+)
+
+var certManagerCertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+//go:embed cert_manager_certificate.yaml
+var certManagerCertificateManifest []byte
+
+// certificateState is the state of the certificate of the Kyma Gateway.
+type certificateState int
+
+const (
+	certificateReady certificateState = iota
+	certificatePending
+	certificateFailed
+)
+
+type certificateReadiness struct {
+	state   certificateState
+	message string
+}
+
+// condition returns the CertificateReady condition of the APIGateway CR for the readiness.
+func (r certificateReadiness) condition() metav1.Condition {
+	reasonMessage := conditions.CertificateIssued
+	switch r.state {
+	case certificatePending:
+		reasonMessage = conditions.CertificatePending
+	case certificateFailed:
+		reasonMessage = conditions.CertificateFailed
+	}
+	if r.message != "" {
+		reasonMessage = reasonMessage.AdditionalMessage(": " + r.message)
+	}
+	return *reasonMessage.ConditionWithType(conditions.CertificateReady)
+}
+
+// gatewayCertificate is the certificate of a gateway that is issued by a certificate provider.
+type gatewayCertificate struct {
+	// name is the name of the Certificate CR in the istio-system namespace.
+	name string
+	// secretName is the name of the Secret in the istio-system namespace that stores the certificate.
+	secretName string
+	// caSecretName is the name of the Secret in the istio-system namespace that stores the CA of the self-signed
+	// certificate and its key.
+	caSecretName string
+	// caConfigMap is the ConfigMap that exposes the CA of the self-signed certificate.
+	caConfigMap types.NamespacedName
+	domain      string
+	// additionalHosts are covered by the certificate besides the wildcard host of the domain.
+	additionalHosts []string
+}
+
+// certificateProvider issues the certificate of a gateway that is stored in the Secret of the gatewayCertificate.
+type certificateProvider interface {
+	name() string
+	// reconcile creates or updates the resources that issue the certificate.
+	reconcile(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error
+	// delete deletes the resources that issue the certificate. The certificate Secret is not deleted, so that the
+	// gateway keeps serving the previous certificate until another provider has issued a new one.
+	delete(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error
+	readiness(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) (certificateReadiness, error)
+}
+
+// certificateProviders returns all providers that can issue the certificate of a gateway.
+func certificateProviders() []certificateProvider {
+	return []certificateProvider{gardenerProvider{}, certManagerProvider{}, defaultProvider{}}
+}
+
+// selectCertificateProvider returns the provider configured in the APIGateway CR. If no provider is configured,
+// Gardener is used if it is available and the cluster has a Gardener domain, cert-manager is used if an issuer is
+// configured and cert-manager is installed, and otherwise the default self-signed certificate is used.
+func selectCertificateProvider(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, domain string) (certificateProvider, error) {
+	return selectGatewayCertificateProvider(ctx, k8sClient, kymaGatewayConfig(apiGatewayCR).Certificate, domain)
+}
+
+// selectGatewayCertificateProvider returns the provider of the certificate configuration of a gateway like
+// selectCertificateProvider.
+func selectGatewayCertificateProvider(ctx context.Context, k8sClient client.Client, config *v1alpha1.KymaGatewayCertificate, domain string) (certificateProvider, error) {
+	if config == nil {
+		config = &v1alpha1.KymaGatewayCertificate{}
+	}
+
+	switch config.Provider {
+	case GardenerCertificateProvider:
+		return gardenerProvider{}, nil
+	case CertManagerCertificateProvider:
+		return certManagerProvider{issuer: config.Issuer}, nil
+	}
+
+	if _, err := dependencies.Gardener().AreAvailable(ctx, k8sClient); err == nil && domain != nonGardenerDomainName {
+		return gardenerProvider{}, nil
+	} else if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if config.Issuer != nil {
+		if _, err := dependencies.CertManager().AreAvailable(ctx, k8sClient); err == nil {
+			return certManagerProvider{issuer: config.Issuer}, nil
+		} else if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return defaultProvider{}, nil
+}
+
+// validateCertificateConfig validates the certificate section of a gateway configuration. The field is the path of
+// the gateway configuration in the APIGateway CR, e.g. kymaGateway.
+func validateCertificateConfig(ctx context.Context, k8sClient client.Client, field string, config v1alpha1.KymaGatewayCertificate) ([]error, error) {
+	var errs []error
+
+	if config.Provider == CertManagerCertificateProvider && config.Issuer == nil {
+		errs = append(errs, fmt.Errorf("%s.certificate.issuer: issuer is required for the cert-manager provider", field))
+	}
+	if config.Provider == GardenerCertificateProvider && config.Issuer != nil {
+		errs = append(errs, fmt.Errorf("%s.certificate.issuer: issuer is only supported by the cert-manager provider", field))
+	}
+
+	var providerDependencies dependencies.Dependencies
+	switch config.Provider {
+	case GardenerCertificateProvider:
+		providerDependencies = dependencies.Gardener()
+	case CertManagerCertificateProvider:
+		providerDependencies = dependencies.CertManager()
+	default:
+		return errs, nil
+	}
+
+	if name, err := providerDependencies.AreAvailable(ctx, k8sClient); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check dependencies of certificate provider %s: %w", config.Provider, err)
+		}
+		errs = append(errs, fmt.Errorf("%s.certificate.provider: CRD %s of provider %s is not installed", field, name, config.Provider))
+	}
+
+	return errs, nil
+}
+
+// ignoreNotFoundOrNoMatch ignores errors of deleting resources that don't exist or whose CRD is not installed.
+func ignoreNotFoundOrNoMatch(err error) error {
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
+
+type gardenerProvider struct{}
+
+func (gardenerProvider) name() string {
+	return GardenerCertificateProvider
+}
+
+func (gardenerProvider) reconcile(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error {
+	return reconcileCertificate(ctx, k8sClient, cert.name, cert.domain, cert.secretName, cert.additionalHosts)
+}
+
+func (gardenerProvider) delete(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error {
+	return deleteCertificate(ctx, k8sClient, cert.name)
+}
+
+func (gardenerProvider) readiness(ctx context.Context, k8sClient client.Client, gatewayCert gatewayCertificate) (certificateReadiness, error) {
+	var cert certv1alpha1.Certificate
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: gatewayCert.name, Namespace: certificateDefaultNamespace}, &cert); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return certificateReadiness{state: certificatePending}, nil
+		}
+		return certificateReadiness{}, err
+	}
+
+	var message string
+	if cert.Status.Message != nil {
+		message = *cert.Status.Message
+	}
+
+	switch cert.Status.State {
+	case certv1alpha1.StateReady:
+		return certificateReadiness{state: certificateReady}, nil
+	case certv1alpha1.StateError, certv1alpha1.StateRevoked:
+		return certificateReadiness{state: certificateFailed, message: message}, nil
+	default:
+		return certificateReadiness{state: certificatePending, message: message}, nil
+	}
+}
+
+type certManagerProvider struct {
+	issuer *v1alpha1.CertificateIssuerRef
+}
+
+func (certManagerProvider) name() string {
+	return CertManagerCertificateProvider
+}
+
+func (p certManagerProvider) reconcile(ctx context.Context, k8sClient client.Client, gatewayCert gatewayCertificate) error {
+	if p.issuer == nil {
+		return fmt.Errorf("no issuer configured for certificate provider %s", CertManagerCertificateProvider)
+	}
+	issuerKind := p.issuer.Kind
+	if issuerKind == "" {
+		issuerKind = defaultCertificateIssuerKind
+	}
+	ctrl.Log.Info("Reconciling cert-manager Certificate", "name", gatewayCert.name, "namespace", certificateDefaultNamespace,
+		"domain", gatewayCert.domain, "issuer", p.issuer.Name, "issuerKind", issuerKind)
+
+	templateValues := make(map[string]string)
+	templateValues["Name"] = gatewayCert.name
+	templateValues["Namespace"] = certificateDefaultNamespace
+	templateValues["Domain"] = gatewayCert.domain
+	templateValues["SecretName"] = gatewayCert.secretName
+	templateValues["IssuerName"] = p.issuer.Name
+	templateValues["IssuerKind"] = issuerKind
+	templateValues["Version"] = version.GetModuleVersion()
+
+	cert, err := reconciliations.CreateUnstructuredResource(certManagerCertificateManifest, templateValues)
+	if err != nil {
+		return err
+	}
+
+	if err := addCertificateDNSNames(&cert, gatewayCert.additionalHosts); err != nil {
+		return err
+	}
+
+	return reconciliations.CreateOrUpdateResource(ctx, k8sClient, cert)
+}
+
+// addCertificateDNSNames adds the additional hosts of the gateway to the dnsNames of a Certificate, so they are served
+// with the same certificate.
+func addCertificateDNSNames(cert *unstructured.Unstructured, additionalHosts []string) error {
+	if len(additionalHosts) == 0 {
+		return nil
+	}
+
+	dnsNames, _, err := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	if err != nil {
+		return err
+	}
+	for _, host := range additionalHosts {
+		if !slices.Contains(dnsNames, host) {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	return unstructured.SetNestedStringSlice(cert.Object, dnsNames, "spec", "dnsNames")
+}
+
+func (certManagerProvider) delete(ctx context.Context, k8sClient client.Client, gatewayCert gatewayCertificate) error {
+	ctrl.Log.Info("Deleting cert-manager Certificate if it exists", "name", gatewayCert.name, "namespace", certificateDefaultNamespace)
+	cert := unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certManagerCertificateGVK)
+	cert.SetName(gatewayCert.name)
+	cert.SetNamespace(certificateDefaultNamespace)
+
+	if err := ignoreNotFoundOrNoMatch(k8sClient.Delete(ctx, &cert)); err != nil {
+		return fmt.Errorf("failed to delete cert-manager Certificate %s/%s: %w", certificateDefaultNamespace, gatewayCert.name, err)
+	}
+	return nil
+}
+
+func (certManagerProvider) readiness(ctx context.Context, k8sClient client.Client, gatewayCert gatewayCertificate) (certificateReadiness, error) {
+	cert := unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certManagerCertificateGVK)
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: gatewayCert.name, Namespace: certificateDefaultNamespace}, &cert); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return certificateReadiness{state: certificatePending}, nil
+		}
+		return certificateReadiness{}, err
+	}
+
+	statusConditions, _, err := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	if err != nil {
+		return certificateReadiness{}, err
+	}
+
+	readiness := certificateReadiness{state: certificatePending}
+	for _, c := range statusConditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+
+		switch {
+		case conditionType == "Ready" && status == string(metav1.ConditionTrue):
+			return certificateReadiness{state: certificateReady}, nil
+		case conditionType == "Ready":
+			readiness.message = message
+		// cert-manager reports a failed issuance in the Issuing condition and retries it with a backoff.
+		case conditionType == "Issuing" && status == string(metav1.ConditionFalse) && reason == "Failed":
+			return certificateReadiness{state: certificateFailed, message: message}, nil
+		}
+	}
+
+	return readiness, nil
+}
+
+// defaultProvider provides a self-signed certificate for clusters without a certificate management.
+type defaultProvider struct{}
+
+func (defaultProvider) name() string {
+	return defaultCertificateProvider
+}
+
+func (defaultProvider) reconcile(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error {
+	return reconcileSelfSignedCertificate(ctx, k8sClient, cert)
+}
+
+// delete removes only the CA Secret and ConfigMap, because the certificate Secret is taken over by the selected
+// provider.
+func (defaultProvider) delete(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error {
+	if err := deleteSecret(ctx, k8sClient, cert.caSecretName, certificateDefaultNamespace); err != nil {
+		return err
+	}
+	return deleteCertificateCAConfigMap(ctx, k8sClient, cert.caConfigMap)
+}
+
+func (defaultProvider) readiness(_ context.Context, _ client.Client, _ gatewayCertificate) (certificateReadiness, error) {
+	return certificateReadiness{state: certificateReady}, nil
+}
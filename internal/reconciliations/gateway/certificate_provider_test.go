@@ -0,0 +1,302 @@
+package gateway
+
+import (
+	"context"
+
+	certv1alpha1 "github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/controller"
+)
+
+var _ = Describe("Certificate provider", func() {
+	certManagerCRD := func() *v1.CustomResourceDefinition {
+		return &v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"}}
+	}
+
+	gardenerCRDs := func() []client.Object {
+		return []client.Object{
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "dnsentries.dns.gardener.cloud"}},
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert.gardener.cloud"}},
+		}
+	}
+
+	getApiGatewayWithCertificate := func(certificate v1alpha1.KymaGatewayCertificate) v1alpha1.APIGateway {
+		apiGateway := getApiGateway(true, KymaGatewayFinalizer)
+		apiGateway.Spec.KymaGateway = &v1alpha1.KymaGatewayConfig{Certificate: &certificate}
+		return apiGateway
+	}
+
+	getCertManagerCertificate := func(k8sClient client.Client) (*unstructured.Unstructured, error) {
+		cert := &unstructured.Unstructured{}
+		cert.SetGroupVersionKind(certManagerCertificateGVK)
+		err := k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertificateName, Namespace: certificateDefaultNamespace}, cert)
+		return cert, err
+	}
+
+	clusterIssuer := &v1alpha1.CertificateIssuerRef{Name: "letsencrypt"}
+
+	Context("selectCertificateProvider", func() {
+		It("should select the default provider if neither Gardener nor cert-manager is configured", func() {
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient(certManagerCRD())
+
+			provider, err := selectCertificateProvider(context.Background(), k8sClient, apiGateway, nonGardenerDomainName)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.name()).To(Equal(defaultCertificateProvider))
+		})
+
+		It("should select Gardener if it is available and the cluster has a Gardener domain", func() {
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Issuer: clusterIssuer})
+			k8sClient := createFakeClient(append(gardenerCRDs(), certManagerCRD())...)
+
+			provider, err := selectCertificateProvider(context.Background(), k8sClient, apiGateway, "some.gardener.domain")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.name()).To(Equal(GardenerCertificateProvider))
+		})
+
+		It("should select cert-manager if an issuer is configured and cert-manager is installed", func() {
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Issuer: clusterIssuer})
+			k8sClient := createFakeClient(certManagerCRD())
+
+			provider, err := selectCertificateProvider(context.Background(), k8sClient, apiGateway, nonGardenerDomainName)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.name()).To(Equal(CertManagerCertificateProvider))
+		})
+
+		It("should select the default provider if an issuer is configured but cert-manager is not installed", func() {
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Issuer: clusterIssuer})
+			k8sClient := createFakeClient()
+
+			provider, err := selectCertificateProvider(context.Background(), k8sClient, apiGateway, nonGardenerDomainName)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.name()).To(Equal(defaultCertificateProvider))
+		})
+
+		It("should select the provider configured in the APIGateway CR even if Gardener is available", func() {
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Provider: CertManagerCertificateProvider, Issuer: clusterIssuer})
+			k8sClient := createFakeClient(append(gardenerCRDs(), certManagerCRD())...)
+
+			provider, err := selectCertificateProvider(context.Background(), k8sClient, apiGateway, "some.gardener.domain")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.name()).To(Equal(CertManagerCertificateProvider))
+		})
+	})
+
+	Context("cert-manager", func() {
+		It("should create the cert-manager Certificate with the ClusterIssuer and the additional hosts", func() {
+			// given
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Issuer: clusterIssuer})
+			apiGateway.Spec.KymaGateway.AdditionalHosts = []string{"shop.example.com"}
+			k8sClient := createFakeClient(&apiGateway, certManagerCRD())
+
+			// when
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			cert, err := getCertManagerCertificate(k8sClient)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cert.Object["spec"]).To(HaveKeyWithValue("secretName", kymaGatewayCertSecretName))
+			Expect(cert.Object["spec"]).To(HaveKeyWithValue("dnsNames", ConsistOf("*.local.kyma.dev", "shop.example.com")))
+			Expect(cert.Object["spec"]).To(HaveKeyWithValue("issuerRef", Equal(map[string]interface{}{
+				"name":  "letsencrypt",
+				"kind":  "ClusterIssuer",
+				"group": "cert-manager.io",
+			})))
+
+			err = k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should use a namespaced Issuer", func() {
+			// given
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{
+				Provider: CertManagerCertificateProvider,
+				Issuer:   &v1alpha1.CertificateIssuerRef{Name: "ca-issuer", Kind: "Issuer"},
+			})
+			k8sClient := createFakeClient(&apiGateway, certManagerCRD())
+
+			// when
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			cert, err := getCertManagerCertificate(k8sClient)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cert.Object["spec"]).To(HaveKeyWithValue("issuerRef", HaveKeyWithValue("kind", "Issuer")))
+		})
+
+		It("should delete the Gardener Certificate when switching to cert-manager", func() {
+			// given
+			apiGateway := getApiGateway(true, KymaGatewayFinalizer)
+			cm := getTestShootInfo()
+			igwService := getTestIstioIngressGatewayIpBasedService()
+			k8sClient := createFakeClient(append(gardenerCRDs(), &apiGateway, &cm, &igwService, certManagerCRD())...)
+			Expect(ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertificateName, Namespace: certificateDefaultNamespace}, &certv1alpha1.Certificate{})).Should(Succeed())
+
+			// when
+			apiGateway.Spec.KymaGateway = &v1alpha1.KymaGatewayConfig{
+				Certificate: &v1alpha1.KymaGatewayCertificate{Provider: CertManagerCertificateProvider, Issuer: clusterIssuer},
+			}
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			err := k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertificateName, Namespace: certificateDefaultNamespace}, &certv1alpha1.Certificate{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			_, err = getCertManagerCertificate(k8sClient)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should delete the cert-manager Certificate and the certificate Secret when the Kyma Gateway is disabled", func() {
+			// given
+			apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Issuer: clusterIssuer})
+			k8sClient := createFakeClient(&apiGateway, certManagerCRD())
+			Expect(ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+			Expect(k8sClient.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace},
+			})).Should(Succeed())
+
+			// when
+			apiGateway.Spec.EnableKymaGateway = ptr.To(false)
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			_, err := getCertManagerCertificate(k8sClient)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	DescribeTable("Should set the Warning status for an invalid certificate configuration",
+		func(certificate v1alpha1.KymaGatewayCertificate, expectedMessage string) {
+			// given
+			apiGateway := getApiGatewayWithCertificate(certificate)
+			k8sClient := createFakeClient(&apiGateway)
+
+			// when
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.State()).To(Equal(controller.Warning))
+			Expect(status.Condition().Reason).To(Equal(conditions.KymaGatewayMisconfigured.Condition().Reason))
+			Expect(status.Condition().Message).To(ContainSubstring(expectedMessage))
+		},
+		Entry("cert-manager without issuer",
+			v1alpha1.KymaGatewayCertificate{Provider: CertManagerCertificateProvider},
+			"kymaGateway.certificate.issuer: issuer is required for the cert-manager provider"),
+		Entry("cert-manager not installed",
+			v1alpha1.KymaGatewayCertificate{Provider: CertManagerCertificateProvider, Issuer: clusterIssuer},
+			"kymaGateway.certificate.provider: CRD certificates.cert-manager.io of provider cert-manager is not installed"),
+		Entry("Gardener not installed",
+			v1alpha1.KymaGatewayCertificate{Provider: GardenerCertificateProvider},
+			"kymaGateway.certificate.provider: CRD dnsentries.dns.gardener.cloud of provider gardener is not installed"),
+		Entry("Gardener with issuer",
+			v1alpha1.KymaGatewayCertificate{Provider: GardenerCertificateProvider, Issuer: clusterIssuer},
+			"kymaGateway.certificate.issuer: issuer is only supported by the cert-manager provider"),
+	)
+
+	Context("KymaGatewayCertificateCondition", func() {
+		It("should not return a condition if the Kyma Gateway uses an own certificate Secret", func() {
+			apiGateway := getApiGateway(true)
+			apiGateway.Spec.KymaGateway = &v1alpha1.KymaGatewayConfig{CertificateSecretName: "own-certs"}
+			k8sClient := createFakeClient()
+
+			condition, err := KymaGatewayCertificateCondition(context.Background(), k8sClient, apiGateway)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(condition).To(BeNil())
+		})
+
+		It("should report the default certificate as ready", func() {
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient()
+
+			condition, err := KymaGatewayCertificateCondition(context.Background(), k8sClient, apiGateway)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(condition.Type).To(Equal(conditions.CertificateReady))
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		DescribeTable("should report the readiness of the Gardener Certificate",
+			func(state string, expectedReason string, expectedStatus metav1.ConditionStatus) {
+				// given
+				apiGateway := getApiGateway(true)
+				cm := getTestShootInfo()
+				cert := &certv1alpha1.Certificate{
+					ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayCertificateName, Namespace: certificateDefaultNamespace},
+					Status:     certv1alpha1.CertificateStatus{State: state, Message: ptr.To("issuer message")},
+				}
+				k8sClient := createFakeClient(append(gardenerCRDs(), &cm, cert)...)
+
+				// when
+				condition, err := KymaGatewayCertificateCondition(context.Background(), k8sClient, apiGateway)
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(condition.Type).To(Equal(conditions.CertificateReady))
+				Expect(condition.Reason).To(Equal(expectedReason))
+				Expect(condition.Status).To(Equal(expectedStatus))
+			},
+			Entry("ready", certv1alpha1.StateReady, "CertificateIssued", metav1.ConditionTrue),
+			Entry("pending", certv1alpha1.StatePending, "CertificatePending", metav1.ConditionFalse),
+			Entry("error", certv1alpha1.StateError, "CertificateFailed", metav1.ConditionFalse),
+		)
+
+		DescribeTable("should report the readiness of the cert-manager Certificate",
+			func(statusConditions []interface{}, expectedReason string, expectedMessage string) {
+				// given
+				apiGateway := getApiGatewayWithCertificate(v1alpha1.KymaGatewayCertificate{Issuer: clusterIssuer})
+				cert := &unstructured.Unstructured{}
+				cert.SetGroupVersionKind(certManagerCertificateGVK)
+				cert.SetName(kymaGatewayCertificateName)
+				cert.SetNamespace(certificateDefaultNamespace)
+				Expect(unstructured.SetNestedSlice(cert.Object, statusConditions, "status", "conditions")).Should(Succeed())
+				k8sClient := createFakeClient(certManagerCRD(), cert)
+
+				// when
+				condition, err := KymaGatewayCertificateCondition(context.Background(), k8sClient, apiGateway)
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(condition.Reason).To(Equal(expectedReason))
+				Expect(condition.Message).To(Equal(expectedMessage))
+			},
+			Entry("ready",
+				[]interface{}{map[string]interface{}{"type": "Ready", "status": "True", "reason": "Ready"}},
+				"CertificateIssued", "Kyma Gateway certificate is ready"),
+			Entry("being issued",
+				[]interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False", "reason": "DoesNotExist", "message": "Issuing certificate as Secret does not exist"},
+					map[string]interface{}{"type": "Issuing", "status": "True", "reason": "DoesNotExist"},
+				},
+				"CertificatePending", "Kyma Gateway certificate is being issued: Issuing certificate as Secret does not exist"),
+			Entry("failed",
+				[]interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False", "reason": "DoesNotExist"},
+					map[string]interface{}{"type": "Issuing", "status": "False", "reason": "Failed", "message": "The certificate request has failed"},
+				},
+				"CertificateFailed", "Kyma Gateway certificate could not be issued: The certificate request has failed"),
+		)
+	})
+})
@@ -1,37 +1,277 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	_ "embed"
+	"encoding/base64"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller/certificate"
 	"github.com/kyma-project/api-gateway/internal/reconciliations"
 	"github.com/kyma-project/api-gateway/internal/version"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/cert"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// selfSignedCertificateMaxAge is the validity of the self-signed certificate of a gateway.
+	selfSignedCertificateMaxAge = time.Hour * 24 * 90
+	// selfSignedCertificateRenewBefore is the time before the expiration of the self-signed certificate at which it is
+	// rotated. It must be longer than the reconciliation interval of the APIGateway CR.
+	selfSignedCertificateRenewBefore = time.Hour * 24 * 30
+	// selfSignedCAMaxAge is the validity of the CA that signs the self-signed certificate of a gateway. The CA is
+	// rotated much less often than the certificate, because clients must fetch the new CA after a rotation.
+	selfSignedCAMaxAge = time.Hour * 24 * 365 * 10
+	// selfSignedCARenewBefore is the time before the expiration of the CA at which it is rotated. It must be longer
+	// than selfSignedCertificateMaxAge, so that a certificate never outlives the CA that signed it.
+	selfSignedCARenewBefore = time.Hour * 24 * 365
+
+	// kymaGatewayCAConfigMapName is the name of the ConfigMap that exposes the CA of the self-signed certificate of the
+	// Kyma Gateway.
+	kymaGatewayCAConfigMapName      = "kyma-gateway-ca"
+	kymaGatewayCAConfigMapNamespace = "kyma-system"
+	caCertificateKey                = "ca.crt"
+)
+
 //go:embed certificate_secret.yaml
 var nonGardenerCertificateSecretManifest []byte
 
-func reconcileNonGardenerCertificateSecret(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway) error {
+//go:embed certificate_ca_secret.yaml
+var certificateCASecretManifest []byte
+
+//go:embed certificate_ca_configmap.yaml
+var certificateCAConfigMapManifest []byte
+
+// reconcileNonGardenerCertificateSecret reconciles the self-signed wildcard certificate of the Kyma Gateway that is used
+// if no certificate provider is available.
+func reconcileNonGardenerCertificateSecret(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, domain string) error {
 	isEnabled := isKymaGatewayEnabled(apiGatewayCR)
 	ctrl.Log.Info("Reconciling Certificate Secret", "KymaGatewayEnabled", isEnabled, "name", kymaGatewayCertSecretName, "namespace", certificateDefaultNamespace)
 
-	if !isEnabled || apiGatewayCR.IsInDeletion() {
-		return deleteSecret(ctx, k8sClient, kymaGatewayCertSecretName, certificateDefaultNamespace)
+	cert := kymaGatewayCertificate(apiGatewayCR, domain)
+	// The default certificate is not needed if the Kyma Gateway uses a certificate Secret provided by the user.
+	if !isEnabled || apiGatewayCR.IsInDeletion() || hasOwnCertificate(apiGatewayCR) {
+		if err := deleteCertificateCAConfigMap(ctx, k8sClient, cert.caConfigMap); err != nil {
+			return err
+		}
+		if err := deleteSecret(ctx, k8sClient, cert.caSecretName, certificateDefaultNamespace); err != nil {
+			return err
+		}
+		return deleteSecret(ctx, k8sClient, cert.secretName, certificateDefaultNamespace)
+	}
+
+	return reconcileSelfSignedCertificate(ctx, k8sClient, cert)
+}
+
+// reconcileSelfSignedCertificate reconciles the Secret of a self-signed wildcard certificate of a gateway. The
+// certificate is signed by the CA in the CA Secret of the gatewayCertificate, which is exposed in the CA ConfigMap. A new
+// certificate is generated if the certificate expires soon, doesn't cover the hosts of the gateway, or was not signed by
+// the CA.
+func reconcileSelfSignedCertificate(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) error {
+	ctrl.Log.Info("Reconciling self-signed certificate", "name", cert.secretName, "namespace", certificateDefaultNamespace, "domain", cert.domain)
+	dnsNames := selfSignedCertificateDNSNames(cert)
+
+	ca, err := reconcileSelfSignedCA(ctx, k8sClient, cert)
+	if err != nil {
+		return err
 	}
 
+	var secret v1.Secret
+	err = k8sClient.Get(ctx, client.ObjectKey{Name: cert.secretName, Namespace: certificateDefaultNamespace}, &secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get certificate secret %s/%s: %w", certificateDefaultNamespace, cert.secretName, err)
+	}
+
+	data := secret.Data
+	if reason := selfSignedCertificateRotationReason(data, dnsNames, ca[v1.TLSCertKey], time.Now()); reason != "" {
+		ctrl.Log.Info("Generating self-signed certificate", "reason", reason, "dnsNames", dnsNames)
+		certificateBytes, keyBytes, err := certificate.GenerateCertificateSignedByCA(cert.domain, nil, dnsNames[1:], selfSignedCertificateMaxAge, ca[v1.TLSCertKey], ca[v1.TLSPrivateKeyKey])
+		if err != nil {
+			return fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+		data = map[string][]byte{
+			v1.TLSCertKey:       certificateBytes,
+			v1.TLSPrivateKeyKey: keyBytes,
+			caCertificateKey:    ca[v1.TLSCertKey],
+		}
+	}
+
+	if err := createOrUpdateCertificateSecret(ctx, k8sClient, nonGardenerCertificateSecretManifest, cert.secretName, data); err != nil {
+		return err
+	}
+
+	return reconcileCertificateCAConfigMap(ctx, k8sClient, cert.caConfigMap, ca[v1.TLSCertKey])
+}
+
+// reconcileSelfSignedCA reconciles the Secret of the CA that signs the self-signed certificate of a gateway and returns
+// its data. The key of the CA is only stored in this Secret. A new CA is generated if the CA expires soon or is missing,
+// e.g. because the certificate was generated by an older version of the module.
+func reconcileSelfSignedCA(ctx context.Context, k8sClient client.Client, cert gatewayCertificate) (map[string][]byte, error) {
+	var secret v1.Secret
+	err := k8sClient.Get(ctx, client.ObjectKey{Name: cert.caSecretName, Namespace: certificateDefaultNamespace}, &secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get CA secret %s/%s: %w", certificateDefaultNamespace, cert.caSecretName, err)
+	}
+
+	data := secret.Data
+	if reason := selfSignedCARotationReason(data, time.Now()); reason != "" {
+		ctrl.Log.Info("Generating self-signed CA", "reason", reason, "name", cert.caSecretName)
+		caBytes, keyBytes, err := certificate.GenerateCA(cert.domain, selfSignedCAMaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate self-signed CA: %w", err)
+		}
+		data = map[string][]byte{
+			v1.TLSCertKey:       caBytes,
+			v1.TLSPrivateKeyKey: keyBytes,
+		}
+	}
+
+	if err := createOrUpdateCertificateSecret(ctx, k8sClient, certificateCASecretManifest, cert.caSecretName, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func createOrUpdateCertificateSecret(ctx context.Context, k8sClient client.Client, manifest []byte, name string, data map[string][]byte) error {
 	templateValues := make(map[string]string)
-	templateValues["Name"] = kymaGatewayCertSecretName
+	templateValues["Name"] = name
 	templateValues["Namespace"] = certificateDefaultNamespace
 	templateValues["Version"] = version.GetModuleVersion()
 
-	return reconciliations.ApplyResource(ctx, k8sClient, nonGardenerCertificateSecretManifest, templateValues)
+	secretResource, err := reconciliations.CreateUnstructuredResource(manifest, templateValues)
+	if err != nil {
+		return err
+	}
+	encodedData := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		encodedData[key] = base64.StdEncoding.EncodeToString(value)
+	}
+	secretResource.Object["data"] = encodedData
+	if err := reconciliations.CreateOrUpdateResource(ctx, k8sClient, secretResource); err != nil {
+		return fmt.Errorf("failed to create or update secret %s/%s: %w", certificateDefaultNamespace, name, err)
+	}
+	return nil
+}
+
+// selfSignedCertificateDNSNames returns the DNS names of the self-signed certificate. The first name is the domain,
+// which is also the common name of the certificate.
+func selfSignedCertificateDNSNames(cert gatewayCertificate) []string {
+	dnsNames := []string{cert.domain, "*." + cert.domain}
+	for _, host := range cert.additionalHosts {
+		if !slices.Contains(dnsNames, host) {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	return dnsNames
+}
+
+// selfSignedCertificateRotationReason returns why the self-signed certificate in the Secret data must be replaced by a
+// new one signed by the ca. It returns an empty string if the certificate can be kept.
+func selfSignedCertificateRotationReason(data map[string][]byte, dnsNames []string, ca []byte, now time.Time) string {
+	if len(data[v1.TLSCertKey]) == 0 || len(data[v1.TLSPrivateKeyKey]) == 0 {
+		return "certificate or key is missing"
+	}
+	if !bytes.Equal(data[caCertificateKey], ca) {
+		return "CA changed"
+	}
+
+	certificates, err := cert.ParseCertsPEM(data[v1.TLSCertKey])
+	if err != nil {
+		return fmt.Sprintf("certificate can't be parsed: %v", err)
+	}
+	roots, err := cert.NewPoolFromBytes(ca)
+	if err != nil {
+		return fmt.Sprintf("CA can't be parsed: %v", err)
+	}
+
+	_, err = certificates[0].Verify(x509.VerifyOptions{
+		CurrentTime: now.Add(selfSignedCertificateRenewBefore),
+		Roots:       roots,
+	})
+	if err != nil {
+		return fmt.Sprintf("certificate expires soon or is not signed by the CA: %v", err)
+	}
+
+	if !sameElements(certificates[0].DNSNames, dnsNames) {
+		return "hosts of the gateway changed"
+	}
+
+	return ""
+}
+
+// selfSignedCARotationReason returns why the self-signed CA in the Secret data must be replaced by a new one. It returns
+// an empty string if the CA can be kept.
+func selfSignedCARotationReason(data map[string][]byte, now time.Time) string {
+	if len(data[v1.TLSCertKey]) == 0 || len(data[v1.TLSPrivateKeyKey]) == 0 {
+		return "CA or key is missing"
+	}
+
+	if _, err := tls.X509KeyPair(data[v1.TLSCertKey], data[v1.TLSPrivateKeyKey]); err != nil {
+		return fmt.Sprintf("CA or key can't be parsed: %v", err)
+	}
+	certificates, err := cert.ParseCertsPEM(data[v1.TLSCertKey])
+	if err != nil {
+		return fmt.Sprintf("CA can't be parsed: %v", err)
+	}
+	if !certificates[0].IsCA {
+		return "certificate is not a CA"
+	}
+	if now.Add(selfSignedCARenewBefore).After(certificates[0].NotAfter) {
+		return "CA expires soon"
+	}
+
+	return ""
+}
+
+func sameElements(a, b []string) bool {
+	sortedA := slices.Sorted(slices.Values(a))
+	sortedB := slices.Sorted(slices.Values(b))
+	return slices.Equal(sortedA, sortedB)
+}
+
+func reconcileCertificateCAConfigMap(ctx context.Context, k8sClient client.Client, name types.NamespacedName, ca []byte) error {
+	ctrl.Log.Info("Reconciling CA ConfigMap", "name", name.Name, "namespace", name.Namespace)
+
+	templateValues := make(map[string]string)
+	templateValues["Name"] = name.Name
+	templateValues["Namespace"] = name.Namespace
+	templateValues["Version"] = version.GetModuleVersion()
+
+	configMap, err := reconciliations.CreateUnstructuredResource(certificateCAConfigMapManifest, templateValues)
+	if err != nil {
+		return err
+	}
+	configMap.Object["data"] = map[string]interface{}{caCertificateKey: string(ca)}
+
+	if err := reconciliations.CreateOrUpdateResource(ctx, k8sClient, configMap); err != nil {
+		return fmt.Errorf("failed to create or update ConfigMap %s: %w", name, err)
+	}
+	return nil
+}
+
+func deleteCertificateCAConfigMap(ctx context.Context, k8sClient client.Client, name types.NamespacedName) error {
+	ctrl.Log.Info("Deleting CA ConfigMap if it exists", "name", name.Name, "namespace", name.Namespace)
+	configMap := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+	}
+	if err := k8sClient.Delete(ctx, &configMap); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ConfigMap %s: %w", name, err)
+	}
+	return nil
 }
 
 func deleteSecret(ctx context.Context, k8sClient client.Client, name, namespace string) error {
@@ -8,10 +8,14 @@ import (
 	"time"
 
 	"github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/controller/certificate"
 	"github.com/kyma-project/api-gateway/internal/version"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -22,7 +26,7 @@ var _ = Describe("Certificate", func() {
 			k8sClient := createFakeClient()
 
 			// when
-			err := reconcileCertificate(context.Background(), k8sClient, "test", "test-domain.com", "test-cert-secret")
+			err := reconcileCertificate(context.Background(), k8sClient, "test", "test-domain.com", "test-cert-secret", nil)
 
 			// then
 			Expect(err).ShouldNot(HaveOccurred())
@@ -33,13 +37,29 @@ var _ = Describe("Certificate", func() {
 			Expect(*cert.Spec.CommonName).To(Equal("*.test-domain.com"))
 		})
 
+		It("should add the additional hosts to the dnsNames of the Certificate", func() {
+			// given
+			k8sClient := createFakeClient()
+
+			// when
+			err := reconcileCertificate(context.Background(), k8sClient, "test", "test-domain.com", "test-cert-secret", []string{"api.example.com", "app.example.com"})
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			cert := v1alpha1.Certificate{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: certificateDefaultNamespace}, &cert)).Should(Succeed())
+			Expect(*cert.Spec.CommonName).To(Equal("*.test-domain.com"))
+			Expect(cert.Spec.DNSNames).To(Equal([]string{"api.example.com", "app.example.com"}))
+		})
+
 		It("should configure kyma-gateway-certs and expected secret labels for Gardener path", func() {
 			// given
 			apiGateway := getApiGateway(true)
 			k8sClient := createFakeClient()
 
 			// when
-			err := reconcileKymaGatewayCertificate(context.Background(), k8sClient, apiGateway, "some.gardener.domain")
+			err := reconcileKymaGatewayCertificate(context.Background(), k8sClient, apiGateway, gardenerProvider{}, "some.gardener.domain")
 
 			// then
 			Expect(err).ShouldNot(HaveOccurred())
@@ -67,7 +87,7 @@ var _ = Describe("Certificate", func() {
 			k8sClient := createFakeClient()
 
 			// when
-			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway)
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
 
 			// then
 			Expect(err).ShouldNot(HaveOccurred())
@@ -76,6 +96,7 @@ var _ = Describe("Certificate", func() {
 			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
 			Expect(secret.Data).To(HaveKey("tls.key"))
 			Expect(secret.Data).To(HaveKey("tls.crt"))
+			Expect(secret.Data).To(HaveKey("ca.crt"))
 			Expect(secret.Labels).To(Equal(map[string]string{
 				"kyma-project.io/module":      "api-gateway",
 				"app.kubernetes.io/name":      "api-gateway-operator",
@@ -92,7 +113,7 @@ var _ = Describe("Certificate", func() {
 			k8sClient := createFakeClient()
 
 			// when
-			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway)
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
 
 			// then
 			Expect(err).ShouldNot(HaveOccurred())
@@ -104,6 +125,222 @@ var _ = Describe("Certificate", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(willExpireInOneMonth).To(BeFalse())
 		})
+
+		It("should create wildcard certificate for the domain and additional hosts signed by the CA in the ConfigMap", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			apiGateway.Spec.KymaGateway = &operatorv1alpha1.KymaGatewayConfig{AdditionalHosts: []string{"api.example.com"}}
+			k8sClient := createFakeClient()
+
+			// when
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "my.domain")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			configMap := v1.ConfigMap{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCAConfigMapName, Namespace: kymaGatewayCAConfigMapNamespace}, &configMap)).Should(Succeed())
+			Expect(configMap.Data).To(HaveKeyWithValue("ca.crt", string(secret.Data["ca.crt"])))
+			Expect(configMap.Labels).To(HaveKeyWithValue("kyma-project.io/module", "api-gateway"))
+
+			leaf := parseCertificate(secret.Data["tls.crt"])
+			Expect(leaf.DNSNames).To(ConsistOf("my.domain", "*.my.domain", "api.example.com"))
+			roots := x509.NewCertPool()
+			Expect(roots.AppendCertsFromPEM([]byte(configMap.Data["ca.crt"]))).To(BeTrue())
+			_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, DNSName: "foo.my.domain"})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should keep the certificate if it is still valid for the domain", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient()
+			Expect(reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")).Should(Succeed())
+			initial := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &initial)).Should(Succeed())
+
+			// when
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(secret.Data).To(Equal(initial.Data))
+		})
+
+		It("should rotate the certificate if the domain changed", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient()
+			Expect(reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")).Should(Succeed())
+
+			// when
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "other.domain")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(parseCertificate(secret.Data["tls.crt"]).DNSNames).To(ConsistOf("other.domain", "*.other.domain"))
+		})
+
+		It("should rotate the certificate if it expires soon", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			crt, key, ca, err := certificate.GenerateSelfSignedCertificateWithCA("local.kyma.dev", nil, []string{"*.local.kyma.dev"}, time.Hour*24*7)
+			Expect(err).ShouldNot(HaveOccurred())
+			k8sClient := createFakeClient(&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace},
+				Data:       map[string][]byte{"tls.crt": crt, "tls.key": key, "ca.crt": ca},
+			})
+
+			// when
+			err = reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(secret.Data["ca.crt"]).ToNot(Equal(ca))
+			Expect(parseCertificate(secret.Data["tls.crt"]).NotAfter).To(BeTemporally(">", time.Now().Add(selfSignedCertificateRenewBefore)))
+		})
+
+		It("should store the key of the CA only in the CA Secret", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient()
+
+			// when
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			caSecret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCASecretName, Namespace: certificateDefaultNamespace}, &caSecret)).Should(Succeed())
+			Expect(caSecret.Data).To(HaveKey("tls.key"))
+			Expect(parseCertificate(caSecret.Data["tls.crt"]).IsCA).To(BeTrue())
+			Expect(parseCertificate(caSecret.Data["tls.crt"]).NotAfter).To(BeTemporally(">", time.Now().Add(selfSignedCARenewBefore)))
+
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(secret.Data["ca.crt"]).To(Equal(caSecret.Data["tls.crt"]))
+			Expect(secret.Data["tls.key"]).ToNot(Equal(caSecret.Data["tls.key"]))
+		})
+
+		It("should keep the CA if the certificate is rotated", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient()
+			Expect(reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")).Should(Succeed())
+			caSecret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCASecretName, Namespace: certificateDefaultNamespace}, &caSecret)).Should(Succeed())
+			crt, key, err := certificate.GenerateCertificateSignedByCA("local.kyma.dev", nil, []string{"*.local.kyma.dev"}, time.Hour*24*7, caSecret.Data["tls.crt"], caSecret.Data["tls.key"])
+			Expect(err).ShouldNot(HaveOccurred())
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			secret.Data["tls.crt"] = crt
+			secret.Data["tls.key"] = key
+			Expect(k8sClient.Update(context.Background(), &secret)).Should(Succeed())
+
+			// when
+			err = reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(secret.Data["tls.crt"]).ToNot(Equal(crt))
+			Expect(secret.Data["ca.crt"]).To(Equal(caSecret.Data["tls.crt"]))
+			configMap := v1.ConfigMap{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCAConfigMapName, Namespace: kymaGatewayCAConfigMapNamespace}, &configMap)).Should(Succeed())
+			Expect(configMap.Data).To(HaveKeyWithValue("ca.crt", string(caSecret.Data["tls.crt"])))
+
+			roots := x509.NewCertPool()
+			Expect(roots.AppendCertsFromPEM(caSecret.Data["tls.crt"])).To(BeTrue())
+			_, err = parseCertificate(secret.Data["tls.crt"]).Verify(x509.VerifyOptions{Roots: roots, CurrentTime: time.Now().Add(selfSignedCertificateRenewBefore)})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should rotate the CA and the certificate if the CA expires soon", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			ca, caKey, err := certificate.GenerateCA("local.kyma.dev", selfSignedCertificateMaxAge)
+			Expect(err).ShouldNot(HaveOccurred())
+			crt, key, err := certificate.GenerateCertificateSignedByCA("local.kyma.dev", nil, []string{"*.local.kyma.dev"}, selfSignedCertificateMaxAge, ca, caKey)
+			Expect(err).ShouldNot(HaveOccurred())
+			k8sClient := createFakeClient(
+				&v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayCASecretName, Namespace: certificateDefaultNamespace},
+					Data:       map[string][]byte{"tls.crt": ca, "tls.key": caKey},
+				},
+				&v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace},
+					Data:       map[string][]byte{"tls.crt": crt, "tls.key": key, "ca.crt": ca},
+				},
+			)
+
+			// when
+			err = reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			caSecret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCASecretName, Namespace: certificateDefaultNamespace}, &caSecret)).Should(Succeed())
+			Expect(caSecret.Data["tls.crt"]).ToNot(Equal(ca))
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(secret.Data["tls.crt"]).ToNot(Equal(crt))
+			Expect(secret.Data["ca.crt"]).To(Equal(caSecret.Data["tls.crt"]))
+		})
+
+		It("should replace a certificate Secret without CA", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			crt, key, _, err := certificate.GenerateSelfSignedCertificateWithCA("local.kyma.dev", nil, []string{"*.local.kyma.dev"}, selfSignedCertificateMaxAge)
+			Expect(err).ShouldNot(HaveOccurred())
+			k8sClient := createFakeClient(&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace},
+				Data:       map[string][]byte{"tls.crt": crt, "tls.key": key},
+			})
+
+			// when
+			err = reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, apiGateway, "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			secret := v1.Secret{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+			Expect(secret.Data).To(HaveKey("ca.crt"))
+			Expect(secret.Data["tls.crt"]).ToNot(Equal(crt))
+		})
+
+		It("should delete the Secrets and the CA ConfigMap if the Kyma Gateway is disabled", func() {
+			// given
+			k8sClient := createFakeClient()
+			Expect(reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, getApiGateway(true), "local.kyma.dev")).Should(Succeed())
+
+			// when
+			err := reconcileNonGardenerCertificateSecret(context.Background(), k8sClient, getApiGateway(false), "local.kyma.dev")
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+
+			err = k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &v1.Secret{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCASecretName, Namespace: certificateDefaultNamespace}, &v1.Secret{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCAConfigMapName, Namespace: kymaGatewayCAConfigMapNamespace}, &v1.ConfigMap{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
 	})
 })
 
@@ -121,3 +358,11 @@ func certificateExpireInOneMonth(certPEM string) (bool, error) {
 	plusOneMonth := time.Now().AddDate(0, 1, 0)
 	return plusOneMonth.After(cert.NotAfter), nil
 }
+
+func parseCertificate(certPEM []byte) *x509.Certificate {
+	block, _ := pem.Decode(certPEM)
+	Expect(block).ToNot(BeNil())
+	cert, err := x509.ParseCertificate(block.Bytes)
+	Expect(err).ShouldNot(HaveOccurred())
+	return cert
+}
@@ -2,15 +2,13 @@ package gateway
 
 import (
 	"context"
-	_ "embed"
 	"fmt"
-	"strings"
 
-	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
-	"github.com/kyma-project/api-gateway/internal/reconciliations"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/dependencies"
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
 	"github.com/kyma-project/api-gateway/internal/version"
-	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,114 +21,148 @@ const (
 	kymaGatewayDnsEntryNamespace = "kyma-system"
 )
 
-//go:embed dns_entry.yaml
-var dnsEntryManifest []byte
+// selectDNSProvider returns the DNS provider configured in the APIGateway CR. If no provider is configured, Gardener
+// is used if it is available and the cluster has a Gardener domain, and otherwise external-dns is used if it is
+// installed. It returns nil if no DNS provider can be used.
+func selectDNSProvider(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, domain string) (dns.Provider, error) {
+	return selectGatewayDNSProvider(ctx, k8sClient, kymaGatewayConfig(apiGatewayCR).DNS, domain)
+}
+
+// selectGatewayDNSProvider returns the DNS provider of the DNS configuration of a gateway like selectDNSProvider.
+func selectGatewayDNSProvider(ctx context.Context, k8sClient client.Client, config *v1alpha1.KymaGatewayDNS, domain string) (dns.Provider, error) {
+	if config != nil && config.Provider != "" {
+		return dns.ProviderByName(config.Provider)
+	}
+
+	if _, err := dependencies.Gardener().AreAvailable(ctx, k8sClient); err == nil && domain != nonGardenerDomainName {
+		return dns.ProviderByName(dns.GardenerProvider)
+	} else if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if _, err := dependencies.ExternalDNS().AreAvailable(ctx, k8sClient); err == nil {
+		return dns.ProviderByName(dns.ExternalDNSProvider)
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// kymaGatewayDNSNames returns the DNS names of the Kyma Gateway record. The record contains the wildcard host of the
+// domain unless the cluster has no Gardener domain. Providers that support records with multiple names also publish
+// the additional hosts of the Kyma Gateway.
+func kymaGatewayDNSNames(apiGatewayCR v1alpha1.APIGateway, provider dns.Provider, domain string) []string {
+	var names []string
+	if domain != nonGardenerDomainName {
+		names = append(names, "*."+domain)
+	}
+	if provider.SupportsMultipleNames() {
+		names = append(names, kymaGatewayConfig(apiGatewayCR).AdditionalHosts...)
+	}
+	return names
+}
 
-func reconcileKymaGatewayDnsEntry(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, domain string) error {
+func reconcileKymaGatewayDnsEntry(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway, provider dns.Provider, domain string) error {
 	name := kymaGatewayDnsEntryName
 	namespace := kymaGatewayDnsEntryNamespace
 
 	isEnabled := isKymaGatewayEnabled(apiGatewayCR)
 	ctrl.Log.Info("Reconciling DNS entry", "KymaGatewayEnabled", isEnabled, "name", name, "namespace", namespace)
 
-	if !isEnabled || apiGatewayCR.IsInDeletion() {
-		return deleteDnsEntry(ctx, k8sClient, name, namespace)
+	if !isEnabled || apiGatewayCR.IsInDeletion() || provider == nil {
+		return dns.DeleteAll(ctx, k8sClient, name, namespace, nil)
 	}
 
-	istioIngressIps, serviceType, err := fetchIstioIngressGatewayIps(ctx, k8sClient)
-	if err != nil {
-		return fmt.Errorf("failed to fetch Istio ingress gateway IP: %v", err)
+	dnsNames := kymaGatewayDNSNames(apiGatewayCR, provider, domain)
+	if len(dnsNames) == 0 {
+		return dns.DeleteAll(ctx, k8sClient, name, namespace, nil)
 	}
 
-	return reconcileDnsEntry(ctx, k8sClient, name, namespace, domain, istioIngressIps, serviceType)
+	return reconcileGatewayDNSRecord(ctx, k8sClient, types.NamespacedName{Name: name, Namespace: namespace}, provider, dnsNames, dns.DefaultIngressGatewayService)
 }
 
-const ipStackAnnotation = "dns.gardener.cloud/ip-stack"
-
-func reconcileDnsEntry(ctx context.Context, k8sClient client.Client, name, namespace, domain string, ingressGatewayIps []string, ipStackType string) error {
-	templateValues := make(map[string]string)
-	templateValues["Name"] = name
-	templateValues["Namespace"] = namespace
-	templateValues["Domain"] = domain
-	templateValues["IngressGatewayServiceIps"] = fmt.Sprintf("[%s]", strings.Join(ingressGatewayIps, ","))
-	templateValues["Version"] = version.GetModuleVersion()
+// reconcileGatewayDNSRecord reconciles the DNS record of a gateway with the given provider and deletes the records of
+// the other providers. The record points the DNS names to the load balancer of the Service of the Istio ingress gateway.
+func reconcileGatewayDNSRecord(ctx context.Context, k8sClient client.Client, record types.NamespacedName, provider dns.Provider, dnsNames []string, ingressService types.NamespacedName) error {
+	if err := dns.DeleteAll(ctx, k8sClient, record.Name, record.Namespace, provider); err != nil {
+		return err
+	}
 
-	if ipStackType == ipStackTypeDualStack || ipStackType == ipStackTypeIPv6 {
-		return reconciliations.ApplyResource(ctx, k8sClient, dnsEntryManifest, templateValues,
-			reconciliations.WithApplyAnnotations(map[string]string{ipStackAnnotation: ipStackType}))
+	targets, ipStackType, err := dns.ServiceTargets(ctx, k8sClient, ingressService)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Istio ingress gateway IP: %v", err)
 	}
 
-	return reconciliations.ApplyResource(ctx, k8sClient, dnsEntryManifest, templateValues)
+	return provider.Reconcile(ctx, k8sClient, dns.Record{
+		Name:        record.Name,
+		Namespace:   record.Namespace,
+		DNSNames:    dnsNames,
+		Targets:     targets,
+		IPStackType: ipStackType,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":      "api-gateway-operator",
+			"app.kubernetes.io/instance":  "api-gateway-operator-default",
+			"app.kubernetes.io/version":   version.GetModuleVersion(),
+			"app.kubernetes.io/component": "operator",
+			"app.kubernetes.io/part-of":   "api-gateway",
+		},
+	})
 }
 
-func deleteDnsEntry(ctx context.Context, k8sClient client.Client, name, namespace string) error {
-	ctrl.Log.Info("Deleting DNSEntry if it exists", "name", name, "namespace", namespace)
-	d := dnsv1alpha1.DNSEntry{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
+// KymaGatewayDNSEntryCondition returns the DNSEntryReady condition that reports the readiness of the DNS record of the
+// Kyma Gateway. It returns nil if the Kyma Gateway is disabled or no DNS record is created for it.
+func KymaGatewayDNSEntryCondition(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway) (*metav1.Condition, error) {
+	if !isKymaGatewayEnabled(apiGatewayCR) || apiGatewayCR.IsInDeletion() {
+		return nil, nil
 	}
-	err := k8sClient.Delete(ctx, &d)
 
-	if err != nil && !k8serrors.IsNotFound(err) {
-		return fmt.Errorf("failed to delete DNSEntry %s/%s: %v", namespace, name, err)
+	domain, err := kymaGatewayDomain(ctx, k8sClient)
+	if err != nil {
+		return nil, err
 	}
-
-	if k8serrors.IsNotFound(err) {
-		ctrl.Log.Info("Skipped deletion of DNSEntry as it wasn't present", "name", name, "namespace", namespace)
-	} else {
-		ctrl.Log.Info("Successfully deleted DNSEntry", "name", name, "namespace", namespace)
+	provider, err := selectDNSProvider(ctx, k8sClient, apiGatewayCR, domain)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil
-}
-
-const (
-	ipStackTypeIPv4      = "ipv4"
-	ipStackTypeIPv6      = "ipv6"
-	ipStackTypeDualStack = "dual-stack"
-)
-
-// fetchIstioIngressGatewayIps returns the external IPs and hostnames of the istio-ingressgateway service.
-// The second return value indicates the type of the Service (IPv4, IPv6 or DualStack) based on IPFamilies field of the Service spec.
-// In case the IPFamilies field is not set, it defaults to IPv4.
-func fetchIstioIngressGatewayIps(ctx context.Context, k8sClient client.Client) ([]string, string, error) {
-	istioIngressGatewayNamespaceName := types.NamespacedName{
-		Name:      "istio-ingressgateway",
-		Namespace: "istio-system",
+	if provider == nil || len(kymaGatewayDNSNames(apiGatewayCR, provider, domain)) == 0 {
+		return nil, nil
 	}
 
-	svc := corev1.Service{}
-	if err := k8sClient.Get(ctx, istioIngressGatewayNamespaceName, &svc); err != nil {
-		return nil, "", err
+	status, err := provider.Status(ctx, k8sClient, kymaGatewayDnsEntryName, kymaGatewayDnsEntryNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of the Kyma Gateway DNS record of provider %s: %w", provider.Name(), err)
 	}
 
-	stackType := ipStackTypeIPv4
-	if len(svc.Spec.IPFamilies) == 2 {
-		stackType = ipStackTypeDualStack
-	} else if len(svc.Spec.IPFamilies) == 1 && svc.Spec.IPFamilies[0] == corev1.IPv6Protocol {
-		stackType = ipStackTypeIPv6
+	reasonMessage := conditions.DNSEntryCreated
+	switch status.State {
+	case dns.Pending:
+		reasonMessage = conditions.DNSEntryPending
+	case dns.Failed:
+		reasonMessage = conditions.DNSEntryFailed
 	}
-
-	if len(svc.Status.LoadBalancer.Ingress) == 0 {
-		return nil, stackType, fmt.Errorf("no ingress exists for %s", istioIngressGatewayNamespaceName.String())
+	if status.Message != "" {
+		reasonMessage = reasonMessage.AdditionalMessage(": " + status.Message)
 	}
+	return reasonMessage.ConditionWithType(conditions.DNSEntryReady), nil
+}
 
-	var targets []string
-	for _, ingress := range svc.Status.LoadBalancer.Ingress {
-		if ingress.IP != "" {
-			targets = append(targets, ingress.IP)
-		}
-		if ingress.Hostname != "" {
-			targets = append(targets, ingress.Hostname)
-		}
+// validateDNSConfig validates the dns section of a gateway configuration. The field is the path of the gateway
+// configuration in the APIGateway CR, e.g. kymaGateway.
+func validateDNSConfig(ctx context.Context, k8sClient client.Client, field string, config v1alpha1.KymaGatewayDNS) ([]error, error) {
+	if config.Provider == "" {
+		return nil, nil
 	}
 
-	ctrl.Log.Info("Found istio ingress gateway IP addresses", "targets", targets, "stackType", stackType)
-	if len(targets) > 0 {
-		return targets, stackType, nil
+	providerDependencies, err := dns.ProviderDependencies(config.Provider)
+	if err != nil {
+		return []error{fmt.Errorf("%s.dns.provider: %w", field, err)}, nil
 	}
-
-	return nil, stackType, fmt.Errorf("no ingress targets found for %s", istioIngressGatewayNamespaceName.String())
+	if name, err := providerDependencies.AreAvailable(ctx, k8sClient); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check dependencies of DNS provider %s: %w", config.Provider, err)
+		}
+		return []error{fmt.Errorf("%s.dns.provider: CRD %s of provider %s is not installed", field, name, config.Provider)}, nil
+	}
+	return nil, nil
 }
@@ -2,302 +2,242 @@ package gateway
 
 import (
 	"context"
-	"testing"
 
-	certv1alpha1 "github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
 	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
-	"github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
-	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"istio.io/client-go/pkg/apis/networking/v1alpha3"
-	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
-	corev1 "k8s.io/api/core/v1"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
-)
 
-func createFakeClientForDnsTests(t *testing.T, objects ...client.Object) client.Client {
-	t.Helper()
-	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
-	require.NoError(t, corev1.AddToScheme(scheme.Scheme))
-	require.NoError(t, v1alpha3.AddToScheme(scheme.Scheme))
-	require.NoError(t, v1beta1.AddToScheme(scheme.Scheme))
-	require.NoError(t, dnsv1alpha1.AddToScheme(scheme.Scheme))
-	require.NoError(t, certv1alpha1.AddToScheme(scheme.Scheme))
-	require.NoError(t, networkingv1beta1.AddToScheme(scheme.Scheme))
-	require.NoError(t, apiextensionsv1.AddToScheme(scheme.Scheme))
-	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
-}
-
-const (
-	disclaimerKey   = "apigateways.operator.kyma-project.io/managed-by-disclaimer"
-	disclaimerValue = "DO NOT EDIT - This resource is managed by Kyma.\nAny modifications are discarded and the resource is reverted to the original state."
-
-	gardenerClassKey   = "dns.gardener.cloud/class"
-	gardenerClassValue = "garden"
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
 )
 
-func TestReconcileDnsEntry(t *testing.T) {
-	tests := []struct {
-		name                      string
-		targets                   []string
-		ipStackType               string
-		wantTargets               []string
-		wantAdditionalAnnotations map[string]string // additional annotations expected beyond Gardener class and disclaimer
-	}{
-		{
-			name:        "single IPv4 address - no ip-stack annotation",
-			targets:     []string{"10.0.0.1"},
-			ipStackType: ipStackTypeIPv4,
-			wantTargets: []string{"10.0.0.1"},
-		},
-		{
-			name:        "multiple IPv4 addresses - no ip-stack annotation",
-			targets:     []string{"10.0.0.1", "10.0.0.2"},
-			ipStackType: ipStackTypeIPv4,
-			wantTargets: []string{"10.0.0.1", "10.0.0.2"},
-		},
-		{
-			name:        "hostname target (DNS-based LB) - no ip-stack annotation",
-			targets:     []string{"some.host.name"},
-			ipStackType: ipStackTypeIPv4,
-			wantTargets: []string{"some.host.name"},
-		},
-		{
-			name:                      "IPv6 address - ip-stack annotation set to ipv6",
-			targets:                   []string{"2001:db8::1"},
-			ipStackType:               ipStackTypeIPv6,
-			wantTargets:               []string{"2001:db8::1"},
-			wantAdditionalAnnotations: map[string]string{ipStackAnnotation: ipStackTypeIPv6},
-		},
-		{
-			name:                      "dual-stack addresses - ip-stack annotation set to dual-stack",
-			targets:                   []string{"10.0.0.1", "2001:db8::1"},
-			ipStackType:               ipStackTypeDualStack,
-			wantTargets:               []string{"10.0.0.1", "2001:db8::1"},
-			wantAdditionalAnnotations: map[string]string{ipStackAnnotation: ipStackTypeDualStack},
-		},
+var _ = Describe("DNS provider", func() {
+	externalDNSCRD := func() *v1.CustomResourceDefinition {
+		return &v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "dnsendpoints.externaldns.k8s.io"}}
 	}
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			k8sClient := createFakeClientForDnsTests(t)
+	gardenerCRDs := func() []client.Object {
+		return []client.Object{
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "dnsentries.dns.gardener.cloud"}},
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert.gardener.cloud"}},
+		}
+	}
 
-			err := reconcileDnsEntry(context.Background(), k8sClient, "test", "test-ns", "test-domain.com", tc.targets, tc.ipStackType)
-			require.NoError(t, err)
+	getApiGatewayWithDNSProvider := func(provider string) v1alpha1.APIGateway {
+		apiGateway := getApiGateway(true, KymaGatewayFinalizer)
+		apiGateway.Spec.KymaGateway = &v1alpha1.KymaGatewayConfig{DNS: &v1alpha1.KymaGatewayDNS{Provider: provider}}
+		return apiGateway
+	}
 
-			created := dnsv1alpha1.DNSEntry{}
-			require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &created))
+	getDNSEndpoint := func(k8sClient client.Client) (*unstructured.Unstructured, error) {
+		endpoint := &unstructured.Unstructured{}
+		endpoint.SetGroupVersionKind(dns.DNSEndpointGVK)
+		err := k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayDnsEntryName, Namespace: kymaGatewayDnsEntryNamespace}, endpoint)
+		return endpoint, err
+	}
 
-			assert.Equal(t, "*.test-domain.com", created.Spec.DNSName)
-			assert.ElementsMatch(t, tc.wantTargets, created.Spec.Targets)
-			assert.Equal(t, "garden", created.Annotations["dns.gardener.cloud/class"])
+	Context("selectDNSProvider", func() {
+		It("should not select a provider if neither Gardener nor external-dns is installed", func() {
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient()
 
-			if tc.wantAdditionalAnnotations == nil {
-				tc.wantAdditionalAnnotations = map[string]string{}
-			}
-			tc.wantAdditionalAnnotations[disclaimerKey] = disclaimerValue
-			tc.wantAdditionalAnnotations[gardenerClassKey] = gardenerClassValue
+			provider, err := selectDNSProvider(context.Background(), k8sClient, apiGateway, "some.gardener.domain")
 
-			assert.Equal(t, tc.wantAdditionalAnnotations, created.Annotations)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider).To(BeNil())
+		})
+
+		It("should select Gardener if it is available and the cluster has a Gardener domain", func() {
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient(append(gardenerCRDs(), externalDNSCRD())...)
+
+			provider, err := selectDNSProvider(context.Background(), k8sClient, apiGateway, "some.gardener.domain")
 
-			if tc.ipStackType == ipStackTypeIPv4 {
-				assert.NotContains(t, created.Annotations, ipStackAnnotation, "IPv4 entries must not carry the ip-stack annotation")
-			}
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.Name()).To(Equal(dns.GardenerProvider))
 		})
-	}
-}
-
-func TestReconcileDnsEntry_ReappliesAnnotations(t *testing.T) {
-	k8sClient := createFakeClientForDnsTests(t)
-
-	require.NoError(t, reconcileDnsEntry(context.Background(), k8sClient, "test", "test-ns", "test-domain.com", []string{"10.0.0.1"}, ipStackTypeIPv4))
-
-	// Simulate a manual edit that strips all annotations.
-	dnsEntry := dnsv1alpha1.DNSEntry{}
-	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &dnsEntry))
-	dnsEntry.Annotations = nil
-	require.NoError(t, k8sClient.Update(context.Background(), &dnsEntry))
-
-	// Reconcile again — annotations must be restored.
-	require.NoError(t, reconcileDnsEntry(context.Background(), k8sClient, "test", "test-ns", "test-domain.com", []string{"10.0.0.1"}, ipStackTypeIPv4))
-
-	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "test", Namespace: "test-ns"}, &dnsEntry))
-	assert.Equal(t, "garden", dnsEntry.Annotations["dns.gardener.cloud/class"])
-}
-
-func TestFetchIstioIngressGatewayIp(t *testing.T) {
-	tests := []struct {
-		name            string
-		service         corev1.Service
-		wantAddresses   []string
-		wantStackType   string
-		wantErrContains string
-	}{
-		{
-			name: "single IPv4 LoadBalancer IP",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{{IP: testIstioIngressGatewayLoadBalancerIp}},
-					},
-				},
-			},
-			wantAddresses: []string{testIstioIngressGatewayLoadBalancerIp},
-			wantStackType: ipStackTypeIPv4,
-		},
-		{
-			name: "multiple IPv4 LoadBalancer IPs",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{
-							{IP: "172.0.0.1"},
-							{IP: "172.0.0.2"},
-						},
-					},
-				},
-			},
-			wantAddresses: []string{"172.0.0.1", "172.0.0.2"},
-			wantStackType: ipStackTypeIPv4,
-		},
-		{
-			name: "IPv6 LoadBalancer IP",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{{IP: "2001:db8::1"}},
-					},
-				},
-			},
-			wantAddresses: []string{"2001:db8::1"},
-			wantStackType: ipStackTypeIPv6,
-		},
-		{
-			name: "dual-stack LoadBalancer IPs",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{
-							{IP: "172.0.0.1"},
-							{IP: "2001:db8::1"},
-						},
-					},
-				},
-			},
-			wantAddresses: []string{"172.0.0.1", "2001:db8::1"},
-			wantStackType: ipStackTypeDualStack,
-		},
-		{
-			name: "DNS-based hostname LoadBalancer (IPv4)",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{{Hostname: "some.host.name"}},
-					},
-				},
-			},
-			wantAddresses: []string{"some.host.name"},
-			wantStackType: ipStackTypeIPv4,
-		},
-		{
-			name: "ingress entry with both IP and hostname - both appended as targets",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{{IP: "172.0.0.1", Hostname: "some.host.name"}},
-					},
-				},
-			},
-			wantAddresses: []string{"172.0.0.1", "some.host.name"},
-			wantStackType: ipStackTypeIPv4,
-		},
-		{
-			name: "multiple ingress entries each with IP and hostname",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Spec: corev1.ServiceSpec{
-					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
-				},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{
-							{IP: "172.0.0.1", Hostname: "host1.example.com"},
-							{IP: "2001:db8::1", Hostname: "host2.example.com"},
-						},
-					},
-				},
-			},
-			wantAddresses: []string{"172.0.0.1", "host1.example.com", "2001:db8::1", "host2.example.com"},
-			wantStackType: ipStackTypeDualStack,
-		},
-		{
-			name: "no ingress entries returns error",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-			},
-			wantStackType:   ipStackTypeIPv4,
-			wantErrContains: "no ingress exists for",
-		},
-		{
-			name: "ingress entry with neither IP nor hostname returns error",
-			service: corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"},
-				Status: corev1.ServiceStatus{
-					LoadBalancer: corev1.LoadBalancerStatus{
-						Ingress: []corev1.LoadBalancerIngress{{}},
-					},
-				},
-			},
-			wantStackType:   ipStackTypeIPv4,
-			wantErrContains: "no ingress targets found for",
-		},
-	}
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			k8sClient := createFakeClientForDnsTests(t, &tc.service)
+		It("should select external-dns if the cluster has no Gardener domain", func() {
+			apiGateway := getApiGateway(true)
+			k8sClient := createFakeClient(append(gardenerCRDs(), externalDNSCRD())...)
 
-			addresses, stackType, err := fetchIstioIngressGatewayIps(context.Background(), k8sClient)
+			provider, err := selectDNSProvider(context.Background(), k8sClient, apiGateway, nonGardenerDomainName)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.Name()).To(Equal(dns.ExternalDNSProvider))
+		})
 
-			assert.Equal(t, tc.wantStackType, stackType)
+		It("should select the provider configured in the APIGateway CR even if Gardener is available", func() {
+			apiGateway := getApiGatewayWithDNSProvider(dns.ExternalDNSProvider)
+			k8sClient := createFakeClient(append(gardenerCRDs(), externalDNSCRD())...)
 
-			if tc.wantErrContains != "" {
-				require.Error(t, err)
-				assert.ErrorContains(t, err, tc.wantErrContains)
-				assert.Nil(t, addresses)
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, tc.wantAddresses, addresses)
-			}
+			provider, err := selectDNSProvider(context.Background(), k8sClient, apiGateway, "some.gardener.domain")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(provider.Name()).To(Equal(dns.ExternalDNSProvider))
 		})
-	}
-}
+	})
+
+	Context("external-dns", func() {
+		It("should create the DNSEndpoint with the domain and the additional hosts", func() {
+			// given
+			apiGateway := getApiGatewayWithDNSProvider(dns.ExternalDNSProvider)
+			apiGateway.Spec.KymaGateway.AdditionalHosts = []string{"shop.example.com"}
+			cm := getTestShootInfo()
+			igwService := getTestIstioIngressGatewayIpBasedService()
+			k8sClient := createFakeClient(&apiGateway, &cm, &igwService, externalDNSCRD())
+
+			// when
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			endpoint, err := getDNSEndpoint(k8sClient)
+			Expect(err).ShouldNot(HaveOccurred())
+			endpoints, _, err := unstructured.NestedSlice(endpoint.Object, "spec", "endpoints")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(endpoints).To(ConsistOf(
+				And(HaveKeyWithValue("dnsName", "*.some.gardener.domain"), HaveKeyWithValue("recordType", "A"),
+					HaveKeyWithValue("targets", ConsistOf(testIstioIngressGatewayLoadBalancerIp))),
+				And(HaveKeyWithValue("dnsName", "shop.example.com"), HaveKeyWithValue("recordType", "A"),
+					HaveKeyWithValue("targets", ConsistOf(testIstioIngressGatewayLoadBalancerIp))),
+			))
+			Expect(endpoint.GetLabels()).To(HaveKeyWithValue("kyma-project.io/module", "api-gateway"))
+		})
+
+		It("should not create a DNSEndpoint if the cluster has no Gardener domain and no additional hosts", func() {
+			// given
+			apiGateway := getApiGateway(true)
+			igwService := getTestIstioIngressGatewayIpBasedService()
+			k8sClient := createFakeClient(&apiGateway, &igwService, externalDNSCRD())
+
+			// when
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			_, err := getDNSEndpoint(k8sClient)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should delete the Gardener DNSEntry when switching to external-dns", func() {
+			// given
+			apiGateway := getApiGateway(true, KymaGatewayFinalizer)
+			cm := getTestShootInfo()
+			igwService := getTestIstioIngressGatewayIpBasedService()
+			k8sClient := createFakeClient(append(gardenerCRDs(), &apiGateway, &cm, &igwService, externalDNSCRD())...)
+			Expect(ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayDnsEntryName, Namespace: kymaGatewayDnsEntryNamespace}, &dnsv1alpha1.DNSEntry{})).Should(Succeed())
+
+			// when
+			apiGateway.Spec.KymaGateway = &v1alpha1.KymaGatewayConfig{DNS: &v1alpha1.KymaGatewayDNS{Provider: dns.ExternalDNSProvider}}
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			err := k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayDnsEntryName, Namespace: kymaGatewayDnsEntryNamespace}, &dnsv1alpha1.DNSEntry{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			_, err = getDNSEndpoint(k8sClient)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should delete the DNSEndpoint when the Kyma Gateway is disabled", func() {
+			// given
+			apiGateway := getApiGatewayWithDNSProvider(dns.ExternalDNSProvider)
+			cm := getTestShootInfo()
+			igwService := getTestIstioIngressGatewayIpBasedService()
+			k8sClient := createFakeClient(&apiGateway, &cm, &igwService, externalDNSCRD())
+			Expect(ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+
+			// when
+			apiGateway.Spec.EnableKymaGateway = ptr.To(false)
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.IsReady()).To(BeTrue())
+			_, err := getDNSEndpoint(k8sClient)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	It("Should set the Warning status if the configured DNS provider is not installed", func() {
+		// given
+		apiGateway := getApiGatewayWithDNSProvider(dns.ExternalDNSProvider)
+		k8sClient := createFakeClient(&apiGateway)
+
+		// when
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.State()).To(Equal(controller.Warning))
+		Expect(status.Condition().Reason).To(Equal(conditions.KymaGatewayMisconfigured.Condition().Reason))
+		Expect(status.Condition().Message).To(ContainSubstring("kymaGateway.dns.provider: CRD dnsendpoints.externaldns.k8s.io of provider external-dns is not installed"))
+	})
+
+	Context("KymaGatewayDNSEntryCondition", func() {
+		It("should not return a condition if no DNS provider is installed", func() {
+			apiGateway := getApiGateway(true)
+			cm := getTestShootInfo()
+			k8sClient := createFakeClient(&cm)
+
+			condition, err := KymaGatewayDNSEntryCondition(context.Background(), k8sClient, apiGateway)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(condition).To(BeNil())
+		})
+
+		DescribeTable("should report the readiness of the Gardener DNSEntry",
+			func(state string, expectedReason string, expectedStatus metav1.ConditionStatus) {
+				// given
+				apiGateway := getApiGateway(true)
+				cm := getTestShootInfo()
+				entry := &dnsv1alpha1.DNSEntry{
+					ObjectMeta: metav1.ObjectMeta{Name: kymaGatewayDnsEntryName, Namespace: kymaGatewayDnsEntryNamespace},
+					Status:     dnsv1alpha1.DNSEntryStatus{State: state, Message: ptr.To("provider message")},
+				}
+				k8sClient := createFakeClient(append(gardenerCRDs(), &cm, entry)...)
+
+				// when
+				condition, err := KymaGatewayDNSEntryCondition(context.Background(), k8sClient, apiGateway)
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(condition.Type).To(Equal(conditions.DNSEntryReady))
+				Expect(condition.Reason).To(Equal(expectedReason))
+				Expect(condition.Status).To(Equal(expectedStatus))
+			},
+			Entry("ready", dnsv1alpha1.STATE_READY, "DNSEntryCreated", metav1.ConditionTrue),
+			Entry("pending", dnsv1alpha1.STATE_PENDING, "DNSEntryPending", metav1.ConditionFalse),
+			Entry("error", dnsv1alpha1.STATE_ERROR, "DNSEntryFailed", metav1.ConditionFalse),
+		)
+
+		It("should report the DNSEndpoint as pending until it is processed by external-dns", func() {
+			// given
+			apiGateway := getApiGatewayWithDNSProvider(dns.ExternalDNSProvider)
+			cm := getTestShootInfo()
+			endpoint := &unstructured.Unstructured{}
+			endpoint.SetGroupVersionKind(dns.DNSEndpointGVK)
+			endpoint.SetName(kymaGatewayDnsEntryName)
+			endpoint.SetNamespace(kymaGatewayDnsEntryNamespace)
+			endpoint.SetGeneration(2)
+			Expect(unstructured.SetNestedField(endpoint.Object, int64(1), "status", "observedGeneration")).Should(Succeed())
+			k8sClient := createFakeClient(&cm, externalDNSCRD(), endpoint)
+
+			// when
+			condition, err := KymaGatewayDNSEntryCondition(context.Background(), k8sClient, apiGateway)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(condition.Reason).To(Equal("DNSEntryPending"))
+			Expect(condition.Message).To(Equal("Kyma Gateway DNS record is being created: DNSEndpoint is not yet processed by external-dns"))
+		})
+	})
+})
@@ -9,7 +9,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-const KymaGatewayFinalizer string = "gateways.operator.kyma-project.io/kyma-gateway"
+const (
+	KymaGatewayFinalizer string = "gateways.operator.kyma-project.io/kyma-gateway"
+	// ManagedGatewaysFinalizer blocks the deletion of the APIGateway CR until the gateways of spec.gateways are deleted.
+	ManagedGatewaysFinalizer string = "gateways.operator.kyma-project.io/managed-gateways"
+)
 
 func hasKymaGatewayFinalizer(apiGatewayCR v1alpha1.APIGateway) bool {
 	return controllerutil.ContainsFinalizer(&apiGatewayCR, KymaGatewayFinalizer)
@@ -26,3 +30,19 @@ func removeKymaGatewayFinalizer(ctx context.Context, k8sClient client.Client, ap
 	controllerutil.RemoveFinalizer(apiGatewayCR, KymaGatewayFinalizer)
 	return k8sClient.Update(ctx, apiGatewayCR)
 }
+
+func hasManagedGatewaysFinalizer(apiGatewayCR v1alpha1.APIGateway) bool {
+	return controllerutil.ContainsFinalizer(&apiGatewayCR, ManagedGatewaysFinalizer)
+}
+
+func addManagedGatewaysFinalizer(ctx context.Context, k8sClient client.Client, apiGatewayCR *v1alpha1.APIGateway) error {
+	ctrl.Log.Info("Adding finalizer", "finalizer", ManagedGatewaysFinalizer)
+	controllerutil.AddFinalizer(apiGatewayCR, ManagedGatewaysFinalizer)
+	return k8sClient.Update(ctx, apiGatewayCR)
+}
+
+func removeManagedGatewaysFinalizer(ctx context.Context, k8sClient client.Client, apiGatewayCR *v1alpha1.APIGateway) error {
+	ctrl.Log.Info("Removing finalizer", "finalizer", ManagedGatewaysFinalizer)
+	controllerutil.RemoveFinalizer(apiGatewayCR, ManagedGatewaysFinalizer)
+	return k8sClient.Update(ctx, apiGatewayCR)
+}
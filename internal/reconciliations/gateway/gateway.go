@@ -7,9 +7,9 @@ import (
 	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kyma-project/api-gateway/internal/conditions"
-	"github.com/kyma-project/api-gateway/internal/dependencies"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -26,6 +26,12 @@ const (
 )
 
 var checkDefaultGatewayReference = func(ctx context.Context, c client.Client, res resources.Resource) bool {
+	return referencesGateway(ctx, c, res, KymaGatewayFullName)
+}
+
+// referencesGateway returns whether the APIRule or VirtualService uses the Istio Gateway with the given
+// namespace/name.
+func referencesGateway(ctx context.Context, c client.Client, res resources.Resource, gatewayFullName string) bool {
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(res.GVK)
 
@@ -39,12 +45,12 @@ var checkDefaultGatewayReference = func(ctx context.Context, c client.Client, re
 	}
 
 	if res.GVK.Kind == "APIRule" && u.Object["spec"] != nil {
-		return u.Object["spec"].(map[string]interface{})["gateway"] == KymaGatewayFullName
+		return u.Object["spec"].(map[string]interface{})["gateway"] == gatewayFullName
 	} else if res.GVK.Kind == "VirtualService" && u.Object["spec"] != nil {
 		gateways := u.Object["spec"].(map[string]interface{})["gateways"]
 		if gateways != nil {
 			for _, gateway := range gateways.([]interface{}) {
-				if gateway == KymaGatewayFullName {
+				if gateway == gatewayFullName {
 					return true
 				}
 			}
@@ -96,6 +102,16 @@ func ReconcileKymaGateway(ctx context.Context, k8sClient client.Client, apiGatew
 		}
 	}
 
+	if isKymaGatewayEnabled(*apiGatewayCR) && !apiGatewayCR.IsInDeletion() {
+		if err := validateKymaGatewayConfig(ctx, k8sClient, kymaGatewayConfig(*apiGatewayCR)); err != nil {
+			if isInvalidKymaGatewayConfig(err) {
+				return controller.WarningStatus(err, "Kyma Gateway configuration is invalid: "+err.Error(),
+					conditions.KymaGatewayMisconfigured.AdditionalMessage(": "+err.Error()).Condition())
+			}
+			return controller.ErrorStatus(err, "Error during Kyma Gateway configuration validation", conditions.KymaGatewayReconcileFailed.Condition())
+		}
+	}
+
 	if err := reconcile(ctx, k8sClient, *apiGatewayCR); err != nil {
 		return controller.ErrorStatus(err, "Error during Kyma Gateway reconciliation", conditions.KymaGatewayReconcileFailed.Condition())
 	}
@@ -111,26 +127,26 @@ func ReconcileKymaGateway(ctx context.Context, k8sClient client.Client, apiGatew
 }
 
 func reconcile(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway) error {
-	domain, err := reconciliations.GetGardenerDomain(ctx, k8sClient)
-	if err != nil && !k8serrors.IsNotFound(err) {
+	domain, err := kymaGatewayDomain(ctx, k8sClient)
+	if err != nil {
 		return err
 	}
-	if domain == "" {
-		domain = nonGardenerDomainName
+	dnsProvider, err := selectDNSProvider(ctx, k8sClient, apiGatewayCR, domain)
+	if err != nil {
+		return err
+	}
+	if err := reconcileKymaGatewayDnsEntry(ctx, k8sClient, apiGatewayCR, dnsProvider, domain); err != nil {
+		return err
 	}
-	if _, err := dependencies.Gardener().AreAvailable(ctx, k8sClient); err == nil && domain != nonGardenerDomainName {
-		if err := reconcileKymaGatewayDnsEntry(ctx, k8sClient, apiGatewayCR, domain); err != nil {
-			return err
-		}
 
-		if err := reconcileKymaGatewayCertificate(ctx, k8sClient, apiGatewayCR, domain); err != nil {
-			return err
-		}
-	} else {
-		if err := reconcileNonGardenerCertificateSecret(ctx, k8sClient, apiGatewayCR); err != nil {
-			return err
-		}
+	provider, err := selectCertificateProvider(ctx, k8sClient, apiGatewayCR, domain)
+	if err != nil {
+		return err
+	}
+	if err := reconcileKymaGatewayCertificate(ctx, k8sClient, apiGatewayCR, provider, domain); err != nil {
+		return err
 	}
+
 	if err := reconcileKymaGatewayVirtualService(ctx, k8sClient, apiGatewayCR, domain); err != nil {
 		return err
 	}
@@ -140,3 +156,40 @@ func reconcile(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alph
 	}
 	return reconcileKymaGateway(ctx, k8sClient, apiGatewayCR, domain)
 }
+
+// kymaGatewayDomain returns the Gardener domain of the cluster or the default domain if the cluster has none.
+func kymaGatewayDomain(ctx context.Context, k8sClient client.Client) (string, error) {
+	domain, err := reconciliations.GetGardenerDomain(ctx, k8sClient)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+	if domain == "" {
+		domain = nonGardenerDomainName
+	}
+	return domain, nil
+}
+
+// KymaGatewayCertificateCondition returns the CertificateReady condition that reports the readiness of the certificate
+// issued for the Kyma Gateway. It returns nil if the Kyma Gateway is disabled or uses a certificate Secret provided by
+// the user.
+func KymaGatewayCertificateCondition(ctx context.Context, k8sClient client.Client, apiGatewayCR v1alpha1.APIGateway) (*metav1.Condition, error) {
+	if !isKymaGatewayEnabled(apiGatewayCR) || apiGatewayCR.IsInDeletion() || hasOwnCertificate(apiGatewayCR) {
+		return nil, nil
+	}
+
+	domain, err := kymaGatewayDomain(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := selectCertificateProvider(ctx, k8sClient, apiGatewayCR, domain)
+	if err != nil {
+		return nil, err
+	}
+	readiness, err := provider.readiness(ctx, k8sClient, kymaGatewayCertificate(apiGatewayCR, domain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get readiness of the Kyma Gateway certificate of provider %s: %w", provider.name(), err)
+	}
+
+	condition := readiness.condition()
+	return &condition, nil
+}
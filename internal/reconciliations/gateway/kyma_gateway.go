@@ -32,7 +32,7 @@ func reconcileKymaGateway(ctx context.Context, k8sClient client.Client, apiGatew
 	templateValues["Name"] = KymaGatewayName
 	templateValues["Namespace"] = KymaGatewayNamespace
 	templateValues["Domain"] = domain
-	templateValues["CertificateSecretName"] = kymaGatewayCertSecretName
+	templateValues["CertificateSecretName"] = kymaGatewayCertificateSecretName(apiGatewayCR)
 	templateValues["Version"] = version.GetModuleVersion()
 
 	resource, err := reconciliations.CreateUnstructuredResource(kymaGatewayManifest, templateValues)
@@ -40,6 +40,10 @@ func reconcileKymaGateway(ctx context.Context, k8sClient client.Client, apiGatew
 		return err
 	}
 
+	if err := applyKymaGatewayConfig(&resource, kymaGatewayConfig(apiGatewayCR)); err != nil {
+		return fmt.Errorf("failed to apply the kymaGateway configuration: %w", err)
+	}
+
 	if !isEnabled || apiGatewayCR.IsInDeletion() {
 		return deleteKymaGateway(ctx, k8sClient, resource)
 	}
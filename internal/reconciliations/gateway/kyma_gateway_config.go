@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tlsProtocolVersions are the TLS protocol versions supported by Istio in ascending order.
+var tlsProtocolVersions = []string{"TLSV1_0", "TLSV1_1", "TLSV1_2", "TLSV1_3"}
+
+// invalidKymaGatewayConfigError is returned if the kymaGateway section of the APIGateway CR is invalid.
+type invalidKymaGatewayConfigError struct {
+	err error
+}
+
+func (e invalidKymaGatewayConfigError) Error() string {
+	return e.err.Error()
+}
+
+func (e invalidKymaGatewayConfigError) Unwrap() error {
+	return e.err
+}
+
+func isInvalidKymaGatewayConfig(err error) bool {
+	var invalid invalidKymaGatewayConfigError
+	return errors.As(err, &invalid)
+}
+
+func kymaGatewayConfig(apiGatewayCR v1alpha1.APIGateway) v1alpha1.KymaGatewayConfig {
+	if apiGatewayCR.Spec.KymaGateway == nil {
+		return v1alpha1.KymaGatewayConfig{}
+	}
+	return *apiGatewayCR.Spec.KymaGateway
+}
+
+// hasOwnCertificate returns whether the Kyma Gateway uses a certificate Secret that is provided by the user instead of
+// the certificate created by the module.
+func hasOwnCertificate(apiGatewayCR v1alpha1.APIGateway) bool {
+	return kymaGatewayConfig(apiGatewayCR).CertificateSecretName != ""
+}
+
+func kymaGatewayCertificateSecretName(apiGatewayCR v1alpha1.APIGateway) string {
+	if hasOwnCertificate(apiGatewayCR) {
+		return kymaGatewayConfig(apiGatewayCR).CertificateSecretName
+	}
+	return kymaGatewayCertSecretName
+}
+
+// validateKymaGatewayConfig validates the kymaGateway section of the APIGateway CR. It returns an
+// invalidKymaGatewayConfigError containing all problems if the configuration is invalid.
+func validateKymaGatewayConfig(ctx context.Context, k8sClient client.Client, config v1alpha1.KymaGatewayConfig) error {
+	return validateGatewayConfig(ctx, k8sClient, "kymaGateway", config)
+}
+
+// validateGatewayConfig validates the configuration of a gateway like validateKymaGatewayConfig. The field is the path
+// of the configuration in the APIGateway CR that prefixes the reported problems.
+func validateGatewayConfig(ctx context.Context, k8sClient client.Client, field string, config v1alpha1.KymaGatewayConfig, certificateSecretKeys ...string) error {
+	var errs []error
+
+	if config.TLS != nil {
+		minVersion := slices.Index(tlsProtocolVersions, config.TLS.MinProtocolVersion)
+		maxVersion := slices.Index(tlsProtocolVersions, config.TLS.MaxProtocolVersion)
+		if config.TLS.MinProtocolVersion != "" && minVersion < 0 {
+			errs = append(errs, fmt.Errorf("%s.tls.minProtocolVersion: unsupported TLS version %q", field, config.TLS.MinProtocolVersion))
+		}
+		if config.TLS.MaxProtocolVersion != "" && maxVersion < 0 {
+			errs = append(errs, fmt.Errorf("%s.tls.maxProtocolVersion: unsupported TLS version %q", field, config.TLS.MaxProtocolVersion))
+		}
+		if minVersion >= 0 && maxVersion >= 0 && minVersion > maxVersion {
+			errs = append(errs, fmt.Errorf("%s.tls: minProtocolVersion %s is higher than maxProtocolVersion %s",
+				field, config.TLS.MinProtocolVersion, config.TLS.MaxProtocolVersion))
+		}
+		for i, cipherSuite := range config.TLS.CipherSuites {
+			if strings.TrimSpace(cipherSuite) == "" {
+				errs = append(errs, fmt.Errorf("%s.tls.cipherSuites[%d]: value is empty", field, i))
+			}
+		}
+	}
+
+	for i, host := range config.AdditionalHosts {
+		var problems []string
+		if strings.HasPrefix(host, "*.") {
+			problems = validation.IsWildcardDNS1123Subdomain(host)
+		} else {
+			problems = validation.IsDNS1123Subdomain(host)
+		}
+		if len(problems) > 0 {
+			errs = append(errs, fmt.Errorf("%s.additionalHosts[%d]: invalid host %q: %s", field, i, host, strings.Join(problems, ", ")))
+		}
+	}
+
+	for key, value := range config.Selector {
+		if problems := validation.IsQualifiedName(key); len(problems) > 0 {
+			errs = append(errs, fmt.Errorf("%s.selector: invalid label key %q: %s", field, key, strings.Join(problems, ", ")))
+		}
+		if problems := validation.IsValidLabelValue(value); len(problems) > 0 {
+			errs = append(errs, fmt.Errorf("%s.selector: invalid label value %q of key %q: %s", field, value, key, strings.Join(problems, ", ")))
+		}
+	}
+
+	if config.CertificateSecretName != "" {
+		if err := validateCertificateSecret(ctx, k8sClient, field, config.CertificateSecretName, certificateSecretKeys...); err != nil {
+			if !isInvalidKymaGatewayConfig(err) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	// The certificate section is ignored if the Kyma Gateway uses a certificate Secret provided by the user.
+	if config.Certificate != nil && config.CertificateSecretName == "" {
+		certificateErrs, err := validateCertificateConfig(ctx, k8sClient, field, *config.Certificate)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, certificateErrs...)
+	}
+
+	if config.DNS != nil {
+		dnsErrs, err := validateDNSConfig(ctx, k8sClient, field, *config.DNS)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, dnsErrs...)
+	}
+
+	if len(errs) > 0 {
+		return invalidKymaGatewayConfigError{err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+// validateCertificateSecret validates that the certificate Secret provided by the user exists and contains a TLS
+// certificate and the additional keys. The Secret must not be the one managed by the module, because that Secret is
+// deleted when the Kyma Gateway is disabled.
+func validateCertificateSecret(ctx context.Context, k8sClient client.Client, field, name string, additionalKeys ...string) error {
+	if name == kymaGatewayCertSecretName {
+		return invalidKymaGatewayConfigError{err: fmt.Errorf("%s.certificateSecretName: Secret %s is managed by the module", field, name)}
+	}
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: certificateDefaultNamespace, Name: name}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return invalidKymaGatewayConfigError{err: fmt.Errorf("%s.certificateSecretName: Secret %s/%s not found", field, certificateDefaultNamespace, name)}
+		}
+		return fmt.Errorf("failed to get certificate Secret %s/%s: %w", certificateDefaultNamespace, name, err)
+	}
+
+	for _, key := range append([]string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}, additionalKeys...) {
+		if len(secret.Data[key]) == 0 {
+			return invalidKymaGatewayConfigError{err: fmt.Errorf("%s.certificateSecretName: Secret %s/%s has no %s", field, certificateDefaultNamespace, name, key)}
+		}
+	}
+	return nil
+}
+
+// applyKymaGatewayConfig applies the kymaGateway section of the APIGateway CR to the Kyma Gateway rendered from the
+// manifest.
+func applyKymaGatewayConfig(kymaGateway *unstructured.Unstructured, config v1alpha1.KymaGatewayConfig) error {
+	if len(config.Selector) > 0 {
+		selector := make(map[string]interface{}, len(config.Selector))
+		for key, value := range config.Selector {
+			selector[key] = value
+		}
+		if err := unstructured.SetNestedMap(kymaGateway.Object, selector, "spec", "selector"); err != nil {
+			return err
+		}
+	}
+
+	servers, _, err := unstructured.NestedSlice(kymaGateway.Object, "spec", "servers")
+	if err != nil {
+		return err
+	}
+	for _, s := range servers {
+		server, ok := s.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected server %v in Kyma Gateway manifest", s)
+		}
+
+		hosts, _, err := unstructured.NestedStringSlice(server, "hosts")
+		if err != nil {
+			return err
+		}
+		for _, host := range config.AdditionalHosts {
+			if !slices.Contains(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+		if err := unstructured.SetNestedStringSlice(server, hosts, "hosts"); err != nil {
+			return err
+		}
+
+		if protocol, _, _ := unstructured.NestedString(server, "port", "protocol"); protocol != "HTTPS" || config.TLS == nil {
+			continue
+		}
+		if config.TLS.MinProtocolVersion != "" {
+			if err := unstructured.SetNestedField(server, config.TLS.MinProtocolVersion, "tls", "minProtocolVersion"); err != nil {
+				return err
+			}
+		}
+		if config.TLS.MaxProtocolVersion != "" {
+			if err := unstructured.SetNestedField(server, config.TLS.MaxProtocolVersion, "tls", "maxProtocolVersion"); err != nil {
+				return err
+			}
+		}
+		if len(config.TLS.CipherSuites) > 0 {
+			if err := unstructured.SetNestedStringSlice(server, config.TLS.CipherSuites, "tls", "cipherSuites"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return unstructured.SetNestedSlice(kymaGateway.Object, servers, "spec", "servers")
+}
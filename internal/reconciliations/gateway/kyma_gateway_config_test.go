@@ -0,0 +1,200 @@
+package gateway
+
+import (
+	"context"
+
+	certv1alpha1 "github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	istioapiv1alpha3 "istio.io/api/networking/v1alpha3"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/controller"
+)
+
+var _ = Describe("Kyma Gateway configuration", func() {
+	getApiGatewayWithConfig := func(config v1alpha1.KymaGatewayConfig) v1alpha1.APIGateway {
+		apiGateway := getApiGateway(true, KymaGatewayFinalizer)
+		apiGateway.Spec.KymaGateway = &config
+		return apiGateway
+	}
+
+	getCertificateSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: certificateDefaultNamespace},
+			Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		}
+	}
+
+	getKymaGateway := func(k8sClient client.Client) *v1alpha3.Gateway {
+		gateway := &v1alpha3.Gateway{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: KymaGatewayName, Namespace: KymaGatewayNamespace}, gateway)).Should(Succeed())
+		return gateway
+	}
+
+	It("Should create the Kyma Gateway with the default configuration if the kymaGateway section is not set", func() {
+		// given
+		apiGateway := getApiGateway(true)
+		k8sClient := createFakeClient(&apiGateway)
+
+		// when
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		gateway := getKymaGateway(k8sClient)
+		Expect(gateway.Spec.Selector).To(Equal(map[string]string{"app": "istio-ingressgateway", "istio": "ingressgateway"}))
+		Expect(gateway.Spec.Servers[0].Tls.CredentialName).To(Equal(kymaGatewayCertSecretName))
+		Expect(gateway.Spec.Servers[0].Tls.MinProtocolVersion).To(Equal(istioapiv1alpha3.ServerTLSSettings_TLS_AUTO))
+	})
+
+	It("Should apply the TLS settings, additional hosts and selector to the Kyma Gateway", func() {
+		// given
+		apiGateway := getApiGatewayWithConfig(v1alpha1.KymaGatewayConfig{
+			TLS: &v1alpha1.KymaGatewayTLS{
+				MinProtocolVersion: "TLSV1_2",
+				MaxProtocolVersion: "TLSV1_3",
+				CipherSuites:       []string{"ECDHE-RSA-AES256-GCM-SHA384"},
+			},
+			AdditionalHosts: []string{"shop.example.com", "*.apps.example.com"},
+			Selector:        map[string]string{"istio": "custom-ingressgateway"},
+		})
+		k8sClient := createFakeClient(&apiGateway)
+
+		// when
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		gateway := getKymaGateway(k8sClient)
+		Expect(gateway.Spec.Selector).To(Equal(map[string]string{"istio": "custom-ingressgateway"}))
+		Expect(gateway.Spec.Servers).To(HaveLen(2))
+		for _, server := range gateway.Spec.Servers {
+			Expect(server.Hosts).To(Equal([]string{"*.local.kyma.dev", "shop.example.com", "*.apps.example.com"}))
+		}
+
+		httpsTLS := gateway.Spec.Servers[0].Tls
+		Expect(httpsTLS.Mode).To(Equal(istioapiv1alpha3.ServerTLSSettings_SIMPLE))
+		Expect(httpsTLS.MinProtocolVersion).To(Equal(istioapiv1alpha3.ServerTLSSettings_TLSV1_2))
+		Expect(httpsTLS.MaxProtocolVersion).To(Equal(istioapiv1alpha3.ServerTLSSettings_TLSV1_3))
+		Expect(httpsTLS.CipherSuites).To(Equal([]string{"ECDHE-RSA-AES256-GCM-SHA384"}))
+		Expect(gateway.Spec.Servers[1].Tls.HttpsRedirect).To(BeTrue())
+		Expect(gateway.Spec.Servers[1].Tls.CipherSuites).To(BeEmpty())
+	})
+
+	It("Should revert the Kyma Gateway to the default configuration when the kymaGateway section is removed", func() {
+		// given
+		apiGateway := getApiGatewayWithConfig(v1alpha1.KymaGatewayConfig{
+			TLS:             &v1alpha1.KymaGatewayTLS{MinProtocolVersion: "TLSV1_2"},
+			AdditionalHosts: []string{"shop.example.com"},
+		})
+		k8sClient := createFakeClient(&apiGateway)
+		Expect(ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+
+		// when
+		apiGateway.Spec.KymaGateway = nil
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		gateway := getKymaGateway(k8sClient)
+		Expect(gateway.Spec.Servers[0].Hosts).To(Equal([]string{"*.local.kyma.dev"}))
+		Expect(gateway.Spec.Servers[0].Tls.MinProtocolVersion).To(Equal(istioapiv1alpha3.ServerTLSSettings_TLS_AUTO))
+	})
+
+	It("Should use the own certificate Secret and not create the default certificate Secret", func() {
+		// given
+		apiGateway := getApiGatewayWithConfig(v1alpha1.KymaGatewayConfig{CertificateSecretName: "own-certs"})
+		k8sClient := createFakeClient(&apiGateway, getCertificateSecret("own-certs"))
+
+		// when
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		Expect(getKymaGateway(k8sClient).Spec.Servers[0].Tls.CredentialName).To(Equal("own-certs"))
+		err := k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertSecretName, Namespace: certificateDefaultNamespace}, &corev1.Secret{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should not create the Gardener Certificate if an own certificate Secret is used", func() {
+		// given
+		apiGateway := getApiGatewayWithConfig(v1alpha1.KymaGatewayConfig{CertificateSecretName: "own-certs"})
+		cm := getTestShootInfo()
+		igwService := getTestIstioIngressGatewayIpBasedService()
+		k8sClient := createFakeClient(&apiGateway, &cm, &igwService, getCertificateSecret("own-certs"),
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "dnsentries.dns.gardener.cloud"}},
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert.gardener.cloud"}},
+		)
+
+		// when
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		Expect(getKymaGateway(k8sClient).Spec.Servers[0].Tls.CredentialName).To(Equal("own-certs"))
+		err := k8sClient.Get(context.Background(), client.ObjectKey{Name: kymaGatewayCertificateName, Namespace: certificateDefaultNamespace}, &certv1alpha1.Certificate{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	DescribeTable("Should set the Warning status and not apply an invalid configuration",
+		func(config v1alpha1.KymaGatewayConfig, expectedMessage string) {
+			// given
+			apiGateway := getApiGatewayWithConfig(config)
+			k8sClient := createFakeClient(&apiGateway, getCertificateSecret("no-key"))
+
+			// when
+			status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+			// then
+			Expect(status.State()).To(Equal(controller.Warning))
+			Expect(status.Condition().Reason).To(Equal(conditions.KymaGatewayMisconfigured.Condition().Reason))
+			Expect(status.Condition().Message).To(ContainSubstring(expectedMessage))
+			err := k8sClient.Get(context.Background(), client.ObjectKey{Name: KymaGatewayName, Namespace: KymaGatewayNamespace}, &v1alpha3.Gateway{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		},
+		Entry("min TLS version higher than max TLS version",
+			v1alpha1.KymaGatewayConfig{TLS: &v1alpha1.KymaGatewayTLS{MinProtocolVersion: "TLSV1_3", MaxProtocolVersion: "TLSV1_2"}},
+			"kymaGateway.tls: minProtocolVersion TLSV1_3 is higher than maxProtocolVersion TLSV1_2"),
+		Entry("unsupported TLS version",
+			v1alpha1.KymaGatewayConfig{TLS: &v1alpha1.KymaGatewayTLS{MinProtocolVersion: "SSLV3"}},
+			"kymaGateway.tls.minProtocolVersion"),
+		Entry("empty cipher suite",
+			v1alpha1.KymaGatewayConfig{TLS: &v1alpha1.KymaGatewayTLS{CipherSuites: []string{" "}}},
+			"kymaGateway.tls.cipherSuites[0]"),
+		Entry("invalid additional host",
+			v1alpha1.KymaGatewayConfig{AdditionalHosts: []string{"Invalid_Host"}},
+			"kymaGateway.additionalHosts[0]"),
+		Entry("invalid selector",
+			v1alpha1.KymaGatewayConfig{Selector: map[string]string{"istio": "invalid value"}},
+			"kymaGateway.selector"),
+		Entry("missing certificate Secret",
+			v1alpha1.KymaGatewayConfig{CertificateSecretName: "missing"},
+			"Secret istio-system/missing not found"),
+		Entry("certificate Secret managed by the module",
+			v1alpha1.KymaGatewayConfig{CertificateSecretName: kymaGatewayCertSecretName},
+			"Secret kyma-gateway-certs is managed by the module"),
+	)
+
+	It("Should report a certificate Secret without TLS key as invalid", func() {
+		// given
+		secret := getCertificateSecret("no-key")
+		delete(secret.Data, "tls.key")
+		apiGateway := getApiGatewayWithConfig(v1alpha1.KymaGatewayConfig{CertificateSecretName: "no-key"})
+		k8sClient := createFakeClient(&apiGateway, secret)
+
+		// when
+		status := ReconcileKymaGateway(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.State()).To(Equal(controller.Warning))
+		Expect(status.Condition().Message).To(ContainSubstring("Secret istio-system/no-key has no tls.key"))
+	})
+})
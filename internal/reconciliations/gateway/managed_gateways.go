@@ -0,0 +1,333 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	"github.com/kyma-project/api-gateway/internal/reconciliations"
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
+	"github.com/kyma-project/api-gateway/internal/resources"
+	"github.com/kyma-project/api-gateway/internal/version"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// managedGatewayLabel marks the Istio Gateways that are created for spec.gateways of the APIGateway CR, so that the
+	// Gateways removed from the list can be found and deleted.
+	managedGatewayLabel = "apigateways.operator.kyma-project.io/managed-gateway"
+
+	tlsModeMutual = "MUTUAL"
+)
+
+var istioGatewayGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"}
+
+// ReconcileManagedGateways reconciles the additional Istio Gateways of spec.gateways together with their certificates
+// and DNS records. Gateways that were removed from the list are deleted unless APIRules or VirtualServices still use
+// them. It adds a finalizer to the APIGateway CR that is removed once all managed Gateways are deleted.
+// Returns a Status object with the result of the reconciliation.
+func ReconcileManagedGateways(ctx context.Context, k8sClient client.Client, apiGatewayCR *v1alpha1.APIGateway, apiGatewayResourceListPath string) controller.Status {
+	gateways := apiGatewayCR.Spec.Gateways
+	if apiGatewayCR.IsInDeletion() {
+		gateways = nil
+	}
+	ctrl.Log.Info("Reconcile managed gateways", "count", len(gateways))
+
+	if len(gateways) > 0 && !hasManagedGatewaysFinalizer(*apiGatewayCR) {
+		if err := addManagedGatewaysFinalizer(ctx, k8sClient, apiGatewayCR); err != nil {
+			return controller.ErrorStatus(err, "Failed to add finalizer during Gateways reconciliation", conditions.GatewaysReconcileFailed.Condition())
+		}
+	}
+
+	if !hasManagedGatewaysFinalizer(*apiGatewayCR) {
+		ctrl.Log.Info("There is no managed gateways finalizer, skipping reconciliation")
+		return controller.ReadyStatus(conditions.GatewaysReconcileSucceeded.Condition())
+	}
+
+	if err := validateManagedGateways(ctx, k8sClient, gateways); err != nil {
+		if isInvalidKymaGatewayConfig(err) {
+			return controller.WarningStatus(err, "Gateways configuration is invalid: "+err.Error(),
+				conditions.GatewaysMisconfigured.AdditionalMessage(": "+err.Error()).Condition())
+		}
+		return controller.ErrorStatus(err, "Error during Gateways configuration validation", conditions.GatewaysReconcileFailed.Condition())
+	}
+
+	for _, gateway := range gateways {
+		if err := reconcileManagedGateway(ctx, k8sClient, gateway); err != nil {
+			return controller.ErrorStatus(err, fmt.Sprintf("Error during reconciliation of Gateway %s/%s", gateway.Namespace, gateway.Name),
+				conditions.GatewaysReconcileFailed.Condition())
+		}
+	}
+
+	blockedGateways, err := deleteObsoleteManagedGateways(ctx, k8sClient, gateways, apiGatewayResourceListPath)
+	if err != nil {
+		return controller.ErrorStatus(err, "Error during deletion of Gateways", conditions.GatewaysReconcileFailed.Condition())
+	}
+	if len(blockedGateways) > 0 {
+		return controller.WarningStatus(fmt.Errorf("could not delete %d Gateway(s) since there are custom resources present that block their deletion", len(blockedGateways)),
+			"There are custom resources that block the deletion of Gateways. Please take a look at kyma-system/api-gateway-controller-manager logs to see more information about the warning",
+			conditions.GatewaysDeletionBlocked.AdditionalMessage(": "+strings.Join(blockedGateways, ", ")).Condition())
+	}
+
+	if len(gateways) == 0 {
+		if err := removeManagedGatewaysFinalizer(ctx, k8sClient, apiGatewayCR); err != nil {
+			return controller.ErrorStatus(err, "Failed to remove finalizer during Gateways reconciliation", conditions.GatewaysReconcileFailed.Condition())
+		}
+	}
+
+	return controller.ReadyStatus(conditions.GatewaysReconcileSucceeded.Condition())
+}
+
+// managedGatewayConfig returns the configuration of the managed gateway that is shared with the Kyma Gateway.
+func managedGatewayConfig(gateway v1alpha1.ManagedGateway) v1alpha1.KymaGatewayConfig {
+	return v1alpha1.KymaGatewayConfig{
+		Selector:              gateway.Selector,
+		CertificateSecretName: gateway.CertificateSecretName,
+		Certificate:           gateway.Certificate,
+		DNS:                   gateway.DNS,
+	}
+}
+
+// managedGatewayCertificate returns the certificate of the managed gateway. The Certificate CR and the Secret are in
+// the istio-system namespace, so their names contain the namespace of the gateway.
+func managedGatewayCertificate(gateway v1alpha1.ManagedGateway) gatewayCertificate {
+	prefix := gateway.Namespace + "." + gateway.Name
+	return gatewayCertificate{
+		name:         prefix + "-tls-cert",
+		secretName:   prefix + "-certs",
+		caSecretName: prefix + "-ca",
+		caConfigMap:  types.NamespacedName{Name: gateway.Name + "-ca", Namespace: gateway.Namespace},
+		domain:       gateway.Domain,
+	}
+}
+
+func managedGatewayIngressService(gateway v1alpha1.ManagedGateway) types.NamespacedName {
+	if gateway.IngressService == nil {
+		return dns.DefaultIngressGatewayService
+	}
+	return types.NamespacedName{Name: gateway.IngressService.Name, Namespace: gateway.IngressService.Namespace}
+}
+
+// validateManagedGateways validates spec.gateways of the APIGateway CR. It returns an invalidKymaGatewayConfigError
+// containing all problems if the configuration is invalid.
+func validateManagedGateways(ctx context.Context, k8sClient client.Client, gateways []v1alpha1.ManagedGateway) error {
+	var errs []error
+	declared := make(map[types.NamespacedName]bool, len(gateways))
+
+	for i, gateway := range gateways {
+		field := fmt.Sprintf("gateways[%d]", i)
+		name := types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}
+
+		if name.Name == KymaGatewayName && name.Namespace == KymaGatewayNamespace {
+			errs = append(errs, fmt.Errorf("%s: Gateway %s is the Kyma Gateway", field, name))
+		}
+		if declared[name] {
+			errs = append(errs, fmt.Errorf("%s: Gateway %s is declared more than once", field, name))
+		}
+		declared[name] = true
+
+		var namespace corev1.Namespace
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: gateway.Namespace}, &namespace); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get namespace %s: %w", gateway.Namespace, err)
+			}
+			errs = append(errs, fmt.Errorf("%s.namespace: Namespace %s not found", field, gateway.Namespace))
+		}
+
+		var certificateSecretKeys []string
+		if gateway.TLSMode == tlsModeMutual {
+			if gateway.CertificateSecretName == "" {
+				errs = append(errs, fmt.Errorf("%s.certificateSecretName: Secret is required for TLS mode %s", field, tlsModeMutual))
+			}
+			certificateSecretKeys = append(certificateSecretKeys, caCertificateKey)
+		}
+
+		if err := validateGatewayConfig(ctx, k8sClient, field, managedGatewayConfig(gateway), certificateSecretKeys...); err != nil {
+			if !isInvalidKymaGatewayConfig(err) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return invalidKymaGatewayConfigError{err: errors.Join(errs...)}
+	}
+	return nil
+}
+
+func reconcileManagedGateway(ctx context.Context, k8sClient client.Client, gateway v1alpha1.ManagedGateway) error {
+	ctrl.Log.Info("Reconciling managed gateway", "name", gateway.Name, "namespace", gateway.Namespace, "domain", gateway.Domain)
+
+	cert := managedGatewayCertificate(gateway)
+	// The certificate is not needed if the gateway uses a certificate Secret provided by the user.
+	if gateway.CertificateSecretName != "" {
+		if err := deleteGatewayCertificate(ctx, k8sClient, cert); err != nil {
+			return err
+		}
+	} else {
+		provider, err := selectGatewayCertificateProvider(ctx, k8sClient, gateway.Certificate, gateway.Domain)
+		if err != nil {
+			return err
+		}
+		if err := reconcileGatewayCertificate(ctx, k8sClient, cert, provider); err != nil {
+			return err
+		}
+	}
+
+	dnsProvider, err := selectGatewayDNSProvider(ctx, k8sClient, gateway.DNS, gateway.Domain)
+	if err != nil {
+		return err
+	}
+	if dnsProvider == nil {
+		if err := dns.DeleteAll(ctx, k8sClient, gateway.Name, gateway.Namespace, nil); err != nil {
+			return err
+		}
+	} else {
+		record := types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}
+		if err := reconcileGatewayDNSRecord(ctx, k8sClient, record, dnsProvider, []string{"*." + gateway.Domain}, managedGatewayIngressService(gateway)); err != nil {
+			return err
+		}
+	}
+
+	resource, err := managedGatewayResource(gateway)
+	if err != nil {
+		return err
+	}
+	return reconciliations.CreateOrUpdateResource(ctx, k8sClient, resource)
+}
+
+// managedGatewayResource renders the Istio Gateway of the managed gateway from the Kyma Gateway manifest.
+func managedGatewayResource(gateway v1alpha1.ManagedGateway) (unstructured.Unstructured, error) {
+	certificateSecretName := gateway.CertificateSecretName
+	if certificateSecretName == "" {
+		certificateSecretName = managedGatewayCertificate(gateway).secretName
+	}
+
+	templateValues := make(map[string]string)
+	templateValues["Name"] = gateway.Name
+	templateValues["Namespace"] = gateway.Namespace
+	templateValues["Domain"] = gateway.Domain
+	templateValues["CertificateSecretName"] = certificateSecretName
+	templateValues["Version"] = version.GetModuleVersion()
+
+	resource, err := reconciliations.CreateUnstructuredResource(kymaGatewayManifest, templateValues)
+	if err != nil {
+		return unstructured.Unstructured{}, err
+	}
+
+	if err := applyKymaGatewayConfig(&resource, managedGatewayConfig(gateway)); err != nil {
+		return unstructured.Unstructured{}, fmt.Errorf("failed to apply the configuration of Gateway %s/%s: %w", gateway.Namespace, gateway.Name, err)
+	}
+	if gateway.TLSMode != "" {
+		if err := setHTTPSServerTLSMode(&resource, gateway.TLSMode); err != nil {
+			return unstructured.Unstructured{}, err
+		}
+	}
+
+	labels := resource.GetLabels()
+	labels[managedGatewayLabel] = "true"
+	resource.SetLabels(labels)
+
+	return resource, nil
+}
+
+func setHTTPSServerTLSMode(gateway *unstructured.Unstructured, mode string) error {
+	servers, _, err := unstructured.NestedSlice(gateway.Object, "spec", "servers")
+	if err != nil {
+		return err
+	}
+	for _, s := range servers {
+		server, ok := s.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected server %v in Gateway manifest", s)
+		}
+		if protocol, _, _ := unstructured.NestedString(server, "port", "protocol"); protocol != "HTTPS" {
+			continue
+		}
+		if err := unstructured.SetNestedField(server, mode, "tls", "mode"); err != nil {
+			return err
+		}
+	}
+	return unstructured.SetNestedSlice(gateway.Object, servers, "spec", "servers")
+}
+
+// deleteObsoleteManagedGateways deletes the managed Istio Gateways that are not in the given list together with their
+// certificates and DNS records. Gateways that are used by APIRules or VirtualServices are not deleted and are returned
+// as namespace/name.
+func deleteObsoleteManagedGateways(ctx context.Context, k8sClient client.Client, gateways []v1alpha1.ManagedGateway, apiGatewayResourceListPath string) ([]string, error) {
+	var existing unstructured.UnstructuredList
+	existing.SetGroupVersionKind(istioGatewayGVK)
+	if err := k8sClient.List(ctx, &existing, client.MatchingLabels{managedGatewayLabel: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list managed gateways: %w", err)
+	}
+
+	declared := make(map[types.NamespacedName]bool, len(gateways))
+	for _, gateway := range gateways {
+		declared[types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}] = true
+	}
+
+	var resourceFinder *resources.ResourcesFinder
+	var blockedGateways []string
+	for _, gateway := range existing.Items {
+		name := types.NamespacedName{Name: gateway.GetName(), Namespace: gateway.GetNamespace()}
+		if declared[name] {
+			continue
+		}
+
+		if resourceFinder == nil {
+			var err error
+			resourceFinder, err = resources.NewResourcesFinderFromConfigYaml(ctx, k8sClient, ctrl.Log, apiGatewayResourceListPath)
+			if err != nil {
+				return nil, fmt.Errorf("could not read customer resources finder configuration: %w", err)
+			}
+		}
+
+		clientResources, err := resourceFinder.FindUserCreatedResources(func(ctx context.Context, c client.Client, res resources.Resource) bool {
+			return referencesGateway(ctx, c, res, name.String())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get customer resources from the cluster: %w", err)
+		}
+		if len(clientResources) > 0 {
+			for _, res := range clientResources {
+				ctrl.Log.Info("Custom resource is blocking Gateway deletion", "gateway", name.String(), "gvk", res.GVK.String(), "namespace", res.Namespace, "name", res.Name)
+			}
+			blockedGateways = append(blockedGateways, name.String())
+			continue
+		}
+
+		if err := deleteManagedGateway(ctx, k8sClient, gateway); err != nil {
+			return nil, err
+		}
+	}
+
+	return blockedGateways, nil
+}
+
+func deleteManagedGateway(ctx context.Context, k8sClient client.Client, gateway unstructured.Unstructured) error {
+	ctrl.Log.Info("Deleting managed gateway", "name", gateway.GetName(), "namespace", gateway.GetNamespace())
+
+	cert := managedGatewayCertificate(v1alpha1.ManagedGateway{Name: gateway.GetName(), Namespace: gateway.GetNamespace()})
+	if err := deleteGatewayCertificate(ctx, k8sClient, cert); err != nil {
+		return err
+	}
+	if err := dns.DeleteAll(ctx, k8sClient, gateway.GetName(), gateway.GetNamespace(), nil); err != nil {
+		return err
+	}
+
+	if err := k8sClient.Delete(ctx, &gateway); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Gateway %s/%s: %w", gateway.GetNamespace(), gateway.GetName(), err)
+	}
+	return nil
+}
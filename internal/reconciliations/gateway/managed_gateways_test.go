@@ -0,0 +1,223 @@
+package gateway
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	"github.com/kyma-project/api-gateway/internal/conditions"
+	"github.com/kyma-project/api-gateway/internal/controller"
+	"github.com/kyma-project/api-gateway/internal/reconciliations/dns"
+)
+
+var _ = Describe("Managed gateways reconciliation", func() {
+	partnerNamespace := func() *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "partners"}}
+	}
+
+	ownCertificateSecret := func(keys ...string) *corev1.Secret {
+		data := map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}
+		for _, key := range keys {
+			data[key] = []byte(key)
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "partner-certs", Namespace: certificateDefaultNamespace},
+			Data:       data,
+		}
+	}
+
+	partnerGateway := func() v1alpha1.ManagedGateway {
+		return v1alpha1.ManagedGateway{
+			Name:                  "partner-gateway",
+			Namespace:             "partners",
+			Domain:                "partner.example.com",
+			CertificateSecretName: "partner-certs",
+		}
+	}
+
+	getApiGatewayWithGateways := func(gateways ...v1alpha1.ManagedGateway) v1alpha1.APIGateway {
+		apiGateway := getApiGateway(false)
+		apiGateway.Spec.Gateways = gateways
+		return apiGateway
+	}
+
+	getGateway := func(k8sClient client.Client, name, namespace string) (*v1alpha3.Gateway, error) {
+		gateway := &v1alpha3.Gateway{}
+		err := k8sClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, gateway)
+		return gateway, err
+	}
+
+	It("should create the Gateway with its configuration and add the finalizer", func() {
+		// given
+		gateway := partnerGateway()
+		gateway.Selector = map[string]string{"app": "private-ingressgateway"}
+		gateway.TLSMode = "MUTUAL"
+		apiGateway := getApiGatewayWithGateways(gateway)
+		k8sClient := createFakeClient(&apiGateway, partnerNamespace(), ownCertificateSecret("ca.crt"))
+
+		// when
+		status := ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		Expect(status.Condition().Reason).To(Equal(conditions.GatewaysReconcileSucceeded.Condition().Reason))
+
+		created, err := getGateway(k8sClient, "partner-gateway", "partners")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(created.Labels).To(HaveKeyWithValue(managedGatewayLabel, "true"))
+		Expect(created.Labels).To(HaveKeyWithValue("kyma-project.io/module", "api-gateway"))
+		Expect(created.Spec.Selector).To(Equal(map[string]string{"app": "private-ingressgateway"}))
+		Expect(created.Spec.Servers).To(HaveLen(2))
+		for _, server := range created.Spec.Servers {
+			Expect(server.Hosts).To(ConsistOf("*.partner.example.com"))
+		}
+		Expect(created.Spec.Servers[0].Tls.Mode.String()).To(Equal("MUTUAL"))
+		Expect(created.Spec.Servers[0].Tls.CredentialName).To(Equal("partner-certs"))
+
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&apiGateway), &apiGateway)).Should(Succeed())
+		Expect(apiGateway.GetFinalizers()).To(ContainElement(ManagedGatewaysFinalizer))
+	})
+
+	It("should create a self-signed certificate and a DNS record for the Gateway", func() {
+		// given
+		gateway := partnerGateway()
+		gateway.CertificateSecretName = ""
+		gateway.DNS = &v1alpha1.KymaGatewayDNS{Provider: dns.ExternalDNSProvider}
+		gateway.IngressService = &v1alpha1.IngressServiceRef{Name: "private-ingressgateway", Namespace: "istio-system"}
+		apiGateway := getApiGatewayWithGateways(gateway)
+		ingressService := getTestIstioIngressGatewayIpBasedService()
+		ingressService.Name = "private-ingressgateway"
+		ingressService.Status.LoadBalancer.Ingress[0].IP = "10.0.0.1"
+		k8sClient := createFakeClient(&apiGateway, partnerNamespace(), &ingressService,
+			&v1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "dnsendpoints.externaldns.k8s.io"}})
+
+		// when
+		status := ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+
+		created, err := getGateway(k8sClient, "partner-gateway", "partners")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(created.Spec.Servers[0].Tls.CredentialName).To(Equal("partners.partner-gateway-certs"))
+		Expect(created.Spec.Servers[0].Tls.Mode.String()).To(Equal("SIMPLE"))
+
+		secret := corev1.Secret{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "partners.partner-gateway-certs", Namespace: certificateDefaultNamespace}, &secret)).Should(Succeed())
+		Expect(parseCertificate(secret.Data["tls.crt"]).DNSNames).To(ConsistOf("partner.example.com", "*.partner.example.com"))
+		configMap := corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "partner-gateway-ca", Namespace: "partners"}, &configMap)).Should(Succeed())
+		Expect(configMap.Data).To(HaveKeyWithValue("ca.crt", string(secret.Data["ca.crt"])))
+
+		endpoint := &unstructured.Unstructured{}
+		endpoint.SetGroupVersionKind(dns.DNSEndpointGVK)
+		Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: "partner-gateway", Namespace: "partners"}, endpoint)).Should(Succeed())
+		endpoints, _, err := unstructured.NestedSlice(endpoint.Object, "spec", "endpoints")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(endpoints).To(ConsistOf(
+			And(HaveKeyWithValue("dnsName", "*.partner.example.com"), HaveKeyWithValue("targets", ConsistOf("10.0.0.1"))),
+		))
+	})
+
+	It("should set the Warning status and not create Gateways if the configuration is invalid", func() {
+		// given
+		mutual := partnerGateway()
+		mutual.TLSMode = "MUTUAL"
+		kymaGateway := partnerGateway()
+		kymaGateway.Name = KymaGatewayName
+		kymaGateway.Namespace = KymaGatewayNamespace
+		missingNamespace := partnerGateway()
+		missingNamespace.Namespace = "unknown"
+		apiGateway := getApiGatewayWithGateways(mutual, mutual, kymaGateway, missingNamespace)
+		k8sClient := createFakeClient(&apiGateway, partnerNamespace(), ownCertificateSecret(),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: KymaGatewayNamespace}})
+
+		// when
+		status := ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.State()).To(Equal(controller.Warning))
+		Expect(status.Condition().Reason).To(Equal(conditions.GatewaysMisconfigured.Condition().Reason))
+		Expect(status.Condition().Message).To(ContainSubstring("gateways[0].certificateSecretName: Secret istio-system/partner-certs has no ca.crt"))
+		Expect(status.Condition().Message).To(ContainSubstring("gateways[1]: Gateway partners/partner-gateway is declared more than once"))
+		Expect(status.Condition().Message).To(ContainSubstring("gateways[2]: Gateway kyma-system/kyma-gateway is the Kyma Gateway"))
+		Expect(status.Condition().Message).To(ContainSubstring("gateways[3].namespace: Namespace unknown not found"))
+
+		_, err := getGateway(k8sClient, "partner-gateway", "partners")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should delete a Gateway that was removed from the list and remove the finalizer", func() {
+		// given
+		gateway := partnerGateway()
+		gateway.CertificateSecretName = ""
+		apiGateway := getApiGatewayWithGateways(gateway)
+		k8sClient := createFakeClient(&apiGateway, partnerNamespace())
+		Expect(ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+		apiGateway.Spec.Gateways = nil
+
+		// when
+		status := ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+
+		_, err := getGateway(k8sClient, "partner-gateway", "partners")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		err = k8sClient.Get(context.Background(), client.ObjectKey{Name: "partners.partner-gateway-certs", Namespace: certificateDefaultNamespace}, &corev1.Secret{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		err = k8sClient.Get(context.Background(), client.ObjectKey{Name: "partner-gateway-ca", Namespace: "partners"}, &corev1.ConfigMap{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&apiGateway), &apiGateway)).Should(Succeed())
+		Expect(apiGateway.GetFinalizers()).ToNot(ContainElement(ManagedGatewaysFinalizer))
+	})
+
+	It("should not delete a Gateway that is used by a VirtualService", func() {
+		// given
+		apiGateway := getApiGatewayWithGateways(partnerGateway())
+		vs := getVirtualService("partners/partner-gateway")
+		k8sClient := createFakeClient(&apiGateway, partnerNamespace(), ownCertificateSecret(), &vs)
+		Expect(ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath).IsReady()).To(BeTrue())
+		apiGateway.Spec.Gateways = nil
+
+		// when
+		status := ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.State()).To(Equal(controller.Warning))
+		Expect(status.Condition().Reason).To(Equal(conditions.GatewaysDeletionBlocked.Condition().Reason))
+		Expect(status.Condition().Message).To(Equal("Gateways deletion blocked because of the existing custom resources: partners/partner-gateway"))
+
+		_, err := getGateway(k8sClient, "partner-gateway", "partners")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&apiGateway), &apiGateway)).Should(Succeed())
+		Expect(apiGateway.GetFinalizers()).To(ContainElement(ManagedGatewaysFinalizer))
+	})
+
+	It("should not touch Istio Gateways that are not managed", func() {
+		// given
+		apiGateway := getApiGatewayWithGateways()
+		userGateway := v1alpha3.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "user-gateway", Namespace: "partners"}}
+		k8sClient := createFakeClient(&apiGateway, partnerNamespace(), &userGateway)
+
+		// when
+		status := ReconcileManagedGateways(context.Background(), k8sClient, &apiGateway, resourceListPath)
+
+		// then
+		Expect(status.IsReady()).To(BeTrue())
+		_, err := getGateway(k8sClient, "user-gateway", "partners")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&apiGateway), &apiGateway)).Should(Succeed())
+		Expect(apiGateway.GetFinalizers()).ToNot(ContainElement(ManagedGatewaysFinalizer))
+	})
+})
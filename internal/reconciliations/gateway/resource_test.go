@@ -28,14 +28,12 @@ var _ = Describe("Resource", func() {
 			templateValues["Domain"] = "test-domain.com"
 			templateValues["SecretName"] = "cert-secret"
 			templateValues["Version"] = "1.0.0"
-			templateValues["IngressGatewayServiceIps"] = "[1.1.1.1]"
 			templateValues["CertificateSecretName"] = "test"
 			templateValues["Gateway"] = "test-gateway"
 
 			resources := []unstructuredManifest{
 				{schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}, nonGardenerCertificateSecretManifest},
 				{schema.GroupVersionKind{Group: "cert.gardener.cloud", Version: "v1alpha1", Kind: "Certificate"}, certificateManifest},
-				{schema.GroupVersionKind{Group: "dns.gardener.cloud", Version: "v1alpha1", Kind: "DNSEntry"}, dnsEntryManifest},
 				{schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"}, kymaGatewayManifest},
 				{schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"}, virtualServiceManifest},
 			}
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+// clusterScopedKinds are the kinds of NewScheme that are cluster-scoped and can be used as cluster state.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}:                                                true,
+	{Kind: "Node"}:                                                     true,
+	{Kind: "PersistentVolume"}:                                         true,
+	{Group: rbacv1.GroupName, Kind: "ClusterRole"}:                     true,
+	{Group: rbacv1.GroupName, Kind: "ClusterRoleBinding"}:              true,
+	{Group: hostpolicyv1alpha1.GroupVersion.Group, Kind: "HostPolicy"}: true,
+}
+
+// isNamespaced returns whether the kind of obj is namespaced.
+func isNamespaced(obj client.Object, scheme *runtime.Scheme) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return false, err
+	}
+	return !clusterScopedKinds[gvk.GroupKind()], nil
+}
+
+// NewScheme returns the scheme containing all kinds that can be read and rendered.
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv2alpha1.AddToScheme(scheme))
+	utilruntime.Must(gatewayv2.AddToScheme(scheme))
+	utilruntime.Must(externalv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(hostpolicyv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(apirulepolicyv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(networkingv1beta1.AddToScheme(scheme))
+	utilruntime.Must(securityv1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+// Decode reads all YAML or JSON documents from r. Every document must contain a kind known by NewScheme.
+func Decode(r io.Reader) ([]client.Object, error) {
+	deserializer := serializer.NewCodecFactory(NewScheme()).UniversalDeserializer()
+	decoder := k8syaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	var objs []client.Object
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw.Raw), []byte("null")) {
+			continue
+		}
+
+		obj, gvk, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		clientObj, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("%s is not supported", gvk)
+		}
+		objs = append(objs, clientObj)
+	}
+}
+
+// WriteManifests writes the rendered objects of all results to w as a multi-document YAML.
+func WriteManifests(w io.Writer, results []Result) error {
+	scheme := NewScheme()
+	for _, result := range results {
+		for _, obj := range result.Objects {
+			gvk, err := apiutil.GVKForObject(obj, scheme)
+			if err != nil {
+				return err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+			out, err := yaml.Marshal(obj)
+			if err != nil {
+				return err
+			}
+
+			if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteFailures writes the validation failures of all results to w, one failure per line.
+func WriteFailures(w io.Writer, results []Result) error {
+	for _, result := range results {
+		for _, failure := range result.Failures {
+			if _, err := fmt.Fprintf(w, "APIRule %s: %s: %s\n", result.APIRule, failure.AttributePath, failure.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+// Package render renders the Istio subresources of APIRules without access to a cluster.
+// The manifests given as input are loaded into a fake client, so the same validation and
+// creators that run in the APIRule controller are used to produce the output.
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	istionetworkingv1beta1 "istio.io/api/networking/v1beta1"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/processing"
+	v2alpha1Processing "github.com/kyma-project/api-gateway/internal/processing/processors/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	"github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
+)
+
+const defaultNamespace = "default"
+
+// Result holds the outcome of rendering a single APIRule.
+type Result struct {
+	APIRule types.NamespacedName
+	// Failures contains the validation failures of the APIRule. If there are failures, no Objects are rendered.
+	Failures []validation.Failure
+	// Objects contains the subresources that the APIRule controller would create for the APIRule.
+	Objects []client.Object
+}
+
+func (r Result) HasFailures() bool {
+	return len(r.Failures) > 0
+}
+
+// Render validates the APIRules contained in objs and renders their subresources. All other objects in objs
+// (for example Gateways, ExternalGateways, Services or the Istio ConfigMap) are used as the cluster state.
+// APIRules are rendered in the given order and the subresources of earlier APIRules are visible to the
+// validation of later ones, so host conflicts between the given APIRules are reported.
+func Render(ctx context.Context, objs []client.Object) ([]Result, error) {
+	var apiRules []*gatewayv2alpha1.APIRule
+	var clusterObjects []client.Object
+	scheme := NewScheme()
+
+	for _, obj := range objs {
+		namespaced, err := isNamespaced(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		if namespaced && obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+		}
+
+		switch o := obj.(type) {
+		case *gatewayv2.APIRule:
+			apiRule := &gatewayv2alpha1.APIRule{}
+			if err := o.ConvertTo(apiRule); err != nil {
+				return nil, fmt.Errorf("converting APIRule %s/%s to v2alpha1: %w", o.Namespace, o.Name, err)
+			}
+			apiRules = append(apiRules, apiRule)
+		case *gatewayv2alpha1.APIRule:
+			apiRules = append(apiRules, o)
+		case *gatewayv1beta1.APIRule:
+			return nil, fmt.Errorf("APIRule %s/%s uses version v1beta1 which is no longer supported, use version v2 instead", o.Namespace, o.Name)
+		default:
+			clusterObjects = append(clusterObjects, obj)
+		}
+	}
+
+	clusterObjects = append(clusterObjects, externalGatewayGateways(clusterObjects)...)
+	for _, apiRule := range apiRules {
+		clusterObjects = append(clusterObjects, apiRule)
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterObjects...).
+		WithIndex(&gatewayv2alpha1.APIRule{}, v2alpha1.HostsIndexField, v2alpha1.IndexHosts).
+		Build()
+
+	results := make([]Result, 0, len(apiRules))
+	for _, apiRule := range apiRules {
+		result, err := renderAPIRule(ctx, k8sClient, apiRule)
+		if err != nil {
+			return nil, fmt.Errorf("rendering APIRule %s/%s: %w", apiRule.Namespace, apiRule.Name, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func renderAPIRule(ctx context.Context, k8sClient client.Client, apiRule *gatewayv2alpha1.APIRule) (Result, error) {
+	result := Result{
+		APIRule: types.NamespacedName{Namespace: apiRule.Namespace, Name: apiRule.Name},
+	}
+
+	gateway, failures, err := discoverGateway(ctx, k8sClient, apiRule)
+	if err != nil {
+		return result, err
+	}
+	if len(failures) > 0 {
+		result.Failures = failures
+		return result, nil
+	}
+
+	apiRuleV1beta1 := gatewayv1beta1.APIRule{}
+	if err := apiRuleV1beta1.ConvertFrom(apiRule); err != nil {
+		return result, fmt.Errorf("converting to v1beta1: %w", err)
+	}
+
+	log := logr.Discard()
+	validator := v2alpha1.NewAPIRuleValidator(apiRule)
+	cmd := v2alpha1Processing.NewReconciliation(apiRule, &apiRuleV1beta1, gateway, validator, processing.ReconciliationConfig{}, &log, false, k8sClient)
+
+	failures, err = cmd.Validate(ctx, k8sClient)
+	if err != nil {
+		return result, fmt.Errorf("validating: %w", err)
+	}
+	if len(failures) > 0 {
+		result.Failures = failures
+		return result, nil
+	}
+
+	for _, processor := range cmd.GetProcessors() {
+		changes, err := processor.EvaluateReconciliation(ctx, k8sClient)
+		if err != nil {
+			return result, err
+		}
+
+		var objs []client.Object
+		for _, change := range changes {
+			if change == nil || change.Obj == nil {
+				continue
+			}
+			objs = append(objs, change.Obj.DeepCopyObject().(client.Object))
+			// Created objects are stored in the fake client, so APIRules that are rendered afterwards are
+			// validated against them in the same way as they would be in the cluster.
+			if err := k8sClient.Create(ctx, change.Obj); err != nil {
+				return result, err
+			}
+		}
+
+		// Some processors return their changes in map iteration order, so the objects are sorted to get a stable output.
+		if err := sortObjects(objs); err != nil {
+			return result, err
+		}
+		result.Objects = append(result.Objects, objs...)
+	}
+
+	return result, nil
+}
+
+func sortObjects(objs []client.Object) error {
+	keys := make(map[client.Object]string, len(objs))
+	for _, obj := range objs {
+		key, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		keys[obj] = string(key)
+	}
+
+	sort.SliceStable(objs, func(i, j int) bool {
+		return keys[objs[i]] < keys[objs[j]]
+	})
+	return nil
+}
+
+// discoverGateway resolves the Istio Gateway referenced by the APIRule in the same way as the APIRule controller does.
+func discoverGateway(ctx context.Context, k8sClient client.Client, apiRule *gatewayv2alpha1.APIRule) (*networkingv1beta1.Gateway, []validation.Failure, error) {
+	if (apiRule.Spec.Gateway == nil) == (apiRule.Spec.ExternalGateway == nil) {
+		return nil, []validation.Failure{{
+			AttributePath: "spec",
+			Message:       "Either gateway or externalGateway must be specified",
+		}}, nil
+	}
+
+	if apiRule.Spec.Gateway != nil {
+		parts := strings.Split(*apiRule.Spec.Gateway, "/")
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("expected Gateway %s to be in the namespace/name format", *apiRule.Spec.Gateway)
+		}
+
+		var gateway networkingv1beta1.Gateway
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, &gateway); err != nil {
+			return nil, []validation.Failure{{
+				AttributePath: "spec.gateway",
+				Message:       "Could not get specified Gateway",
+			}}, nil
+		}
+		return &gateway, nil, nil
+	}
+
+	externalGatewayNN := types.NamespacedName{Namespace: apiRule.Namespace, Name: *apiRule.Spec.ExternalGateway}
+	if parts := strings.Split(*apiRule.Spec.ExternalGateway, "/"); len(parts) == 2 {
+		externalGatewayNN = types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	}
+
+	var externalGateway externalv1alpha1.ExternalGateway
+	if err := k8sClient.Get(ctx, externalGatewayNN, &externalGateway); err != nil {
+		return nil, []validation.Failure{{
+			AttributePath: "spec.externalGateway",
+			Message:       "Could not get specified ExternalGateway",
+		}}, nil
+	}
+
+	var gateway networkingv1beta1.Gateway
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: externalGateway.Namespace, Name: externalGateway.GatewayName()}, &gateway); err != nil {
+		return nil, nil, err
+	}
+	return &gateway, nil, nil
+}
+
+// externalGatewayGateways returns a stand-in for the Istio Gateway generated by the ExternalGateway controller for every
+// ExternalGateway in objs whose Gateway is not part of objs.
+func externalGatewayGateways(objs []client.Object) []client.Object {
+	existing := make(map[types.NamespacedName]bool)
+	for _, obj := range objs {
+		if gw, ok := obj.(*networkingv1beta1.Gateway); ok {
+			existing[types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}] = true
+		}
+	}
+
+	var gateways []client.Object
+	for _, obj := range objs {
+		externalGateway, ok := obj.(*externalv1alpha1.ExternalGateway)
+		if !ok {
+			continue
+		}
+
+		nn := types.NamespacedName{Namespace: externalGateway.Namespace, Name: externalGateway.GatewayName()}
+		if existing[nn] {
+			continue
+		}
+
+		gateways = append(gateways, &networkingv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+			},
+			Spec: istionetworkingv1beta1.Gateway{
+				Selector: map[string]string{"istio": "ingressgateway"},
+				Servers: []*istionetworkingv1beta1.Server{
+					{Hosts: []string{externalGateway.Spec.ExternalDomain}},
+				},
+			},
+		})
+	}
+
+	return gateways
+}
@@ -0,0 +1,224 @@
+package render_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	securityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kyma-project/api-gateway/internal/render"
+)
+
+const (
+	gatewayManifest = `
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+  name: kyma-gateway
+  namespace: kyma-system
+spec:
+  selector:
+    istio: ingressgateway
+  servers:
+  - hosts: ["*.local.kyma.dev"]
+    port: {number: 443, name: https, protocol: HTTPS}
+`
+	serviceManifest = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: httpbin
+  namespace: test
+spec:
+  selector:
+    app: httpbin
+  ports:
+  - port: 8000
+`
+	apiRuleManifest = `
+apiVersion: gateway.kyma-project.io/v2
+kind: APIRule
+metadata:
+  name: httpbin
+  namespace: test
+spec:
+  hosts: [httpbin]
+  gateway: kyma-system/kyma-gateway
+  service:
+    name: httpbin
+    port: 8000
+  rules:
+  - path: /headers
+    methods: [GET]
+    jwt:
+      authentications:
+      - issuer: https://example.com
+        jwksUri: https://example.com/.well-known/jwks.json
+  - path: /anything
+    methods: [GET]
+    noAuth: true
+`
+)
+
+func manifests(docs ...string) string {
+	return strings.Join(docs, "\n---\n")
+}
+
+var _ = Describe("Render", func() {
+	It("should render VirtualService, AuthorizationPolicies and RequestAuthentication for a valid APIRule", func() {
+		// given
+		objs, err := render.Decode(strings.NewReader(manifests(apiRuleManifest, gatewayManifest, serviceManifest)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(3))
+
+		// when
+		results, err := render.Render(context.Background(), objs)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].HasFailures()).To(BeFalse())
+		Expect(results[0].APIRule.String()).To(Equal("test/httpbin"))
+
+		var virtualServices, authorizationPolicies, requestAuthentications int
+		for _, obj := range results[0].Objects {
+			Expect(obj.GetNamespace()).To(Equal("test"))
+			switch o := obj.(type) {
+			case *networkingv1beta1.VirtualService:
+				virtualServices++
+				Expect(o.Spec.Hosts).To(ConsistOf("httpbin.local.kyma.dev"))
+				Expect(o.Spec.Gateways).To(ConsistOf("kyma-system/kyma-gateway"))
+			case *securityv1beta1.AuthorizationPolicy:
+				authorizationPolicies++
+			case *securityv1beta1.RequestAuthentication:
+				requestAuthentications++
+			}
+		}
+		Expect(virtualServices).To(Equal(1))
+		Expect(authorizationPolicies).To(Equal(2))
+		Expect(requestAuthentications).To(Equal(1))
+	})
+
+	It("should write the rendered objects as YAML documents", func() {
+		// given
+		objs, err := render.Decode(strings.NewReader(manifests(apiRuleManifest, gatewayManifest, serviceManifest)))
+		Expect(err).NotTo(HaveOccurred())
+		results, err := render.Render(context.Background(), objs)
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		var out bytes.Buffer
+		err = render.WriteManifests(&out, results)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(out.String(), "---\n")).To(Equal(4))
+		Expect(out.String()).To(ContainSubstring("kind: VirtualService"))
+		Expect(out.String()).To(ContainSubstring("apiVersion: security.istio.io/v1beta1"))
+	})
+
+	It("should report a failure when the referenced Gateway is missing", func() {
+		// given
+		objs, err := render.Decode(strings.NewReader(manifests(apiRuleManifest, serviceManifest)))
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		results, err := render.Render(context.Background(), objs)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].HasFailures()).To(BeTrue())
+		Expect(results[0].Objects).To(BeEmpty())
+
+		var out bytes.Buffer
+		Expect(render.WriteFailures(&out, results)).To(Succeed())
+		Expect(out.String()).To(Equal("APIRule test/httpbin: spec.gateway: Could not get specified Gateway\n"))
+	})
+
+	It("should report validation failures of the APIRule validator", func() {
+		// given
+		invalid := strings.Replace(apiRuleManifest, "path: /anything", "path: /{**}/{**}", 1)
+		objs, err := render.Decode(strings.NewReader(manifests(invalid, gatewayManifest, serviceManifest)))
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		results, err := render.Render(context.Background(), objs)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].HasFailures()).To(BeTrue())
+		Expect(results[0].Failures[0].AttributePath).To(Equal(".spec.rules[1].path"))
+		Expect(results[0].Objects).To(BeEmpty())
+	})
+
+	It("should report a host conflict between two APIRules", func() {
+		// given
+		second := strings.Replace(apiRuleManifest, "name: httpbin\n  namespace: test", "name: other\n  namespace: test", 1)
+		objs, err := render.Decode(strings.NewReader(manifests(apiRuleManifest, second, gatewayManifest, serviceManifest)))
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		results, err := render.Render(context.Background(), objs)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].HasFailures()).To(BeFalse())
+		Expect(results[1].HasFailures()).To(BeTrue())
+		Expect(results[1].Failures[0].Message).To(Equal("Host is occupied by another Virtual Service"))
+	})
+
+	It("should not set a namespace on cluster-scoped objects", func() {
+		// given
+		hostPolicy := `
+apiVersion: gateway.kyma-project.io/v1alpha1
+kind: HostPolicy
+metadata:
+  name: tenant-hosts
+spec:
+  hosts: ["*.local.kyma.dev"]
+  allowedNamespaces: [other]
+`
+		objs, err := render.Decode(strings.NewReader(manifests(apiRuleManifest, gatewayManifest, serviceManifest, hostPolicy)))
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		results, err := render.Render(context.Background(), objs)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs[3].GetNamespace()).To(BeEmpty())
+		Expect(results[0].HasFailures()).To(BeTrue())
+		Expect(results[0].Failures[0].Message).To(ContainSubstring("tenant-hosts"))
+	})
+
+	It("should reject APIRules in version v1beta1", func() {
+		// given
+		v1beta1 := `
+apiVersion: gateway.kyma-project.io/v1beta1
+kind: APIRule
+metadata:
+  name: old
+spec:
+  host: old.local.kyma.dev
+`
+		objs, err := render.Decode(strings.NewReader(v1beta1))
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		_, err = render.Render(context.Background(), objs)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("version v1beta1 which is no longer supported")))
+	})
+
+	It("should fail to decode unknown kinds", func() {
+		_, err := render.Decode(strings.NewReader("apiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: x\n"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,31 @@
+package networkpolicy
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/subresources"
+)
+
+var grv = schema.GroupVersionKind{
+	Group:   "networking.k8s.io",
+	Kind:    "NetworkPolicy",
+	Version: "v1",
+}
+
+// Repository provides methods to retrieve and delete NetworkPolicy resources by owner labels
+type Repository interface {
+	// GetAll retrieves all NetworkPolicy resources that match either legacy owner labels or new owner labels
+	GetAll(ctx context.Context, labeler processing.Labeler) ([]*networkingv1.NetworkPolicy, error)
+	// DeleteAll deletes all NetworkPolicy resources that match either legacy owner labels or new owner labels
+	DeleteAll(ctx context.Context, labeler processing.Labeler) error
+}
+
+// NewRepository creates a new instance of the NetworkPolicy repository
+func NewRepository(client client.Client) Repository {
+	return subresources.NewRepository[*networkingv1.NetworkPolicy](client, grv)
+}
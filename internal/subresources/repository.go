@@ -2,6 +2,8 @@ package subresources
 
 import (
 	"context"
+
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -10,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kyma-project/api-gateway/internal/processing"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 )
 
 type IRepository[T client.Object] interface {
@@ -32,7 +35,13 @@ func NewRepository[T client.Object](c client.Client, grv schema.GroupVersionKind
 
 // GetAll retrieves all AccessRule resources with both legacy and new owner labels,
 // combining them into a single deduplicated list
-func (r *Repository[T]) GetAll(ctx context.Context, labeler processing.Labeler) ([]T, error) {
+func (r *Repository[T]) GetAll(ctx context.Context, labeler processing.Labeler) (resources []T, err error) {
+	ctx, span := tracing.Start(ctx, "subresources.Repository.GetAll", attribute.String("object.kind", r.groupVersionKind.Kind))
+	defer func() {
+		span.SetAttributes(attribute.Int("objects", len(resources)))
+		tracing.End(span, err)
+	}()
+
 	legacyOwnerLabels := processing.GetLegacyOwnerLabelsFromLabeler(labeler)
 	newOwnerLabels := processing.GetOwnerLabels(labeler).Labels()
 	legacyList := unstructured.UnstructuredList{}
@@ -81,7 +90,10 @@ func (r *Repository[T]) GetAll(ctx context.Context, labeler processing.Labeler)
 }
 
 // DeleteAll retrieves and deletes all AccessRule resources with both legacy and new owner labels
-func (r *Repository[T]) DeleteAll(ctx context.Context, labeler processing.Labeler) error {
+func (r *Repository[T]) DeleteAll(ctx context.Context, labeler processing.Labeler) (err error) {
+	ctx, span := tracing.Start(ctx, "subresources.Repository.DeleteAll", attribute.String("object.kind", r.groupVersionKind.Kind))
+	defer func() { tracing.End(span, err) }()
+
 	resources, err := r.GetAll(ctx, labeler)
 	if err != nil {
 		return err
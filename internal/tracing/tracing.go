@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kyma-project/api-gateway/internal/version"
+)
+
+const (
+	tracerName         = "github.com/kyma-project/api-gateway"
+	DefaultServiceName = "api-gateway-manager"
+
+	APIRuleNameKey      = attribute.Key("apirule.name")
+	APIRuleNamespaceKey = attribute.Key("apirule.namespace")
+
+	tracesPath = "/v1/traces"
+)
+
+// endpointEnvVars configure the endpoint of the exporter if no endpoint is set in the Config.
+var endpointEnvVars = []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"}
+
+// Config configures the export of the spans. Tracing is disabled if no endpoint is set, neither in the Config nor with
+// the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables. The other
+// OTEL_EXPORTER_OTLP_* environment variables, e.g. for headers, compression or certificates, are always applied.
+type Config struct {
+	// Endpoint is the address of the OTLP/HTTP receiver, e.g. "otel-collector.kyma-system:4318" or
+	// "https://otel-collector.kyma-system:4318". It takes precedence over the endpoint environment variables.
+	Endpoint string
+	// Insecure uses HTTP instead of HTTPS if the endpoint has no scheme.
+	Insecure bool
+	// SampleRatio is the fraction of reconciliations that are traced. Spans of sampled parents are always sampled.
+	SampleRatio float64
+	// ServiceName is reported as service.name of the spans. It defaults to DefaultServiceName.
+	ServiceName string
+}
+
+// Setup registers a global tracer provider that exports the spans with OTLP to the configured endpoint. If tracing
+// is disabled, the global no-op provider is kept, so all spans are discarded without overhead. The returned function
+// flushes the pending spans and must be called before the process exits.
+func Setup(ctx context.Context, cfg Config, log logr.Logger) (func(context.Context) error, error) {
+	if !endpointConfigured(cfg) {
+		log.Info("Tracing is disabled, no OTLP endpoint configured")
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("sample ratio must be between 0 and 1, got %f", cfg.SampleRatio)
+	}
+
+	opts, err := exporterOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version.GetModuleVersion()),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	log.Info("Tracing is enabled", "endpoint", cfg.Endpoint, "sampleRatio", cfg.SampleRatio)
+
+	return provider.Shutdown, nil
+}
+
+func endpointConfigured(cfg Config) bool {
+	if cfg.Endpoint != "" {
+		return true
+	}
+	for _, env := range endpointEnvVars {
+		if os.Getenv(env) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// exporterOptions returns the options for the endpoint of the Config. Without an endpoint in the Config, the exporter
+// reads the endpoint from the environment variables.
+func exporterOptions(cfg Config) ([]otlptracehttp.Option, error) {
+	if cfg.Endpoint == "" {
+		return nil, nil
+	}
+
+	if !strings.Contains(cfg.Endpoint, "://") {
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint), otlptracehttp.WithURLPath(tracesPath)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return opts, nil
+	}
+
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP endpoint %s: %w", cfg.Endpoint, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %s: host is missing", cfg.Endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+	return []otlptracehttp.Option{otlptracehttp.WithEndpointURL(u.String())}, nil
+}
+
+// Start starts a span as child of the span in the context. The span must be ended by the caller.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error on the span, if there is one, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// APIRuleAttributes returns the attributes that identify the reconciled APIRule.
+func APIRuleAttributes(name, namespace string) []attribute.KeyValue {
+	return []attribute.KeyValue{APIRuleNameKey.String(name), APIRuleNamespaceKey.String(namespace)}
+}
@@ -0,0 +1,198 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a stand-in for an OTLP collector that stores the received spans.
+type collector struct {
+	// path is the path the spans are expected at. It defaults to tracesPath.
+	path  string
+	mu    sync.Mutex
+	spans []*tracepb.Span
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := c.path
+	if path == "" {
+		path = tracesPath
+	}
+	if r.URL.Path != path || r.Header.Get("Content-Type") != "application/x-protobuf" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var data tracepb.TracesData
+	if err := proto.Unmarshal(body, &data); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range data.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+}
+
+func (c *collector) received() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spans
+}
+
+func resetTracerProvider(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+}
+
+func TestSetup_ExportsSpansToCollector(t *testing.T) {
+	resetTracerProvider(t)
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	shutdown, err := Setup(context.Background(), Config{Endpoint: server.URL, SampleRatio: 1}, logr.Discard())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, parent := Start(context.Background(), "APIRuleReconciler.Reconcile", APIRuleAttributes("test-apirule", "default")...)
+	_, child := Start(ctx, "processing.Validate")
+	End(child, nil)
+	End(parent, nil)
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error on shutdown: %v", err)
+	}
+
+	spans := c.received()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	byName := map[string]*tracepb.Span{}
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+	reconcile, validate := byName["APIRuleReconciler.Reconcile"], byName["processing.Validate"]
+	if reconcile == nil || validate == nil {
+		t.Fatalf("expected spans for reconcile and validation, got %v", byName)
+	}
+	if string(validate.ParentSpanId) != string(reconcile.SpanId) {
+		t.Error("expected the validation span to be a child of the reconcile span")
+	}
+
+	attrs := map[string]string{}
+	for _, a := range reconcile.Attributes {
+		attrs[a.Key] = a.Value.GetStringValue()
+	}
+	if attrs[string(APIRuleNameKey)] != "test-apirule" || attrs[string(APIRuleNamespaceKey)] != "default" {
+		t.Errorf("expected APIRule attributes, got %v", attrs)
+	}
+}
+
+func TestSetup_DisabledWithoutEndpoint(t *testing.T) {
+	resetTracerProvider(t)
+
+	shutdown, err := Setup(context.Background(), Config{}, logr.Discard())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = shutdown(context.Background()) }()
+
+	_, span := Start(context.Background(), "APIRuleReconciler.Reconcile")
+	defer span.End()
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Error("expected a no-op span if tracing is disabled")
+	}
+}
+
+func TestSetup_InvalidSampleRatio(t *testing.T) {
+	resetTracerProvider(t)
+
+	if _, err := Setup(context.Background(), Config{Endpoint: "localhost:4318", SampleRatio: 1.5}, logr.Discard()); err == nil {
+		t.Error("expected error for invalid sample ratio")
+	}
+}
+
+func TestSetup_ExportsSpansToEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint func(serverURL string) string
+		insecure bool
+		path     string
+	}{
+		{"insecure host and port", func(u string) string { return strings.TrimPrefix(u, "http://") }, true, tracesPath},
+		{"URL without path", func(u string) string { return u + "/" }, false, tracesPath},
+		{"URL with path", func(u string) string { return u + "/otlp/v1/traces" }, false, "/otlp/v1/traces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetTracerProvider(t)
+			c := &collector{path: tt.path}
+			server := httptest.NewServer(c)
+			defer server.Close()
+
+			shutdown, err := Setup(context.Background(), Config{Endpoint: tt.endpoint(server.URL), Insecure: tt.insecure, SampleRatio: 1}, logr.Discard())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, span := Start(context.Background(), "APIRuleReconciler.Reconcile")
+			End(span, nil)
+			if err := shutdown(context.Background()); err != nil {
+				t.Fatalf("unexpected error on shutdown: %v", err)
+			}
+
+			if len(c.received()) != 1 {
+				t.Errorf("expected 1 span at %s, got %d", tt.path, len(c.received()))
+			}
+		})
+	}
+}
+
+func TestSetup_EndpointFromEnvironment(t *testing.T) {
+	resetTracerProvider(t)
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+
+	shutdown, err := Setup(context.Background(), Config{SampleRatio: 1}, logr.Discard())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, span := Start(context.Background(), "APIRuleReconciler.Reconcile")
+	End(span, nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error on shutdown: %v", err)
+	}
+
+	if len(c.received()) != 1 {
+		t.Errorf("expected 1 span, got %d", len(c.received()))
+	}
+}
+
+func TestSetup_InvalidEndpoint(t *testing.T) {
+	resetTracerProvider(t)
+
+	if _, err := Setup(context.Background(), Config{Endpoint: "https://", SampleRatio: 1}, logr.Discard()); err == nil {
+		t.Error("expected error for endpoint without host")
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	apiv1beta1 "istio.io/api/type/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/api-gateway/internal/tracing"
 )
 
 const istioSidecarContainerName = "istio-proxy"
@@ -30,8 +33,11 @@ func (v *InjectionValidator) Validate(attributePath string, selector *apiv1beta1
 		return problems, nil
 	}
 
+	ctx, span := tracing.Start(v.Ctx, "validation.InjectionValidator.ListPods", attribute.String("workload.namespace", workloadNamespace))
 	var podList corev1.PodList
-	err = v.Client.List(v.Ctx, &podList, client.InNamespace(workloadNamespace), client.MatchingLabels(selector.MatchLabels))
+	err = v.Client.List(ctx, &podList, client.InNamespace(workloadNamespace), client.MatchingLabels(selector.MatchLabels))
+	span.SetAttributes(attribute.Int("pods", len(podList.Items)))
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
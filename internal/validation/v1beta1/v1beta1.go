@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/appengine/log"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +15,7 @@ import (
 	"github.com/kyma-project/api-gateway/internal/helpers"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/processing/default_domain"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 	"github.com/kyma-project/api-gateway/internal/validation"
 
 	apiv1beta1 "istio.io/api/type/v1beta1"
@@ -47,8 +49,12 @@ type rulesValidator interface {
 }
 
 // Validate performs APIRule validation
-func (v *APIRuleValidator) Validate(ctx context.Context, client client.Client, vsList networkingv1beta1.VirtualServiceList, _ networkingv1beta1.GatewayList) []validation.Failure {
-	var failures []validation.Failure
+func (v *APIRuleValidator) Validate(ctx context.Context, client client.Client, vsList networkingv1beta1.VirtualServiceList, _ networkingv1beta1.GatewayList) (failures []validation.Failure) {
+	ctx, span := tracing.Start(ctx, "v1beta1.APIRuleValidator.Validate", tracing.APIRuleAttributes(v.ApiRule.Name, v.ApiRule.Namespace)...)
+	defer func() {
+		span.SetAttributes(attribute.Int("validation.failures", len(failures)))
+		span.End()
+	}()
 
 	//Validate service on path level if it is created
 	if v.ApiRule.Spec.Service != nil {
@@ -0,0 +1,91 @@
+package v2alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+func listAPIRulePolicies(ctx context.Context, k8sClient client.Client, namespace string) ([]apirulepolicyv1alpha1.APIRulePolicy, error) {
+	var apiRulePolicyList apirulepolicyv1alpha1.APIRulePolicyList
+	if err := k8sClient.List(ctx, &apiRulePolicyList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return apiRulePolicyList.Items, nil
+}
+
+// validateAPIRulePolicies validates the rules of the APIRule against all APIRulePolicies in its namespace.
+func validateAPIRulePolicies(parentAttributePath string, policies []apirulepolicyv1alpha1.APIRulePolicy, apiRule *gatewayv2alpha1.APIRule) []validation.Failure {
+	var failures []validation.Failure
+	for i, rule := range apiRule.Spec.Rules {
+		ruleAttributePath := fmt.Sprintf("%s.rules[%d]", parentAttributePath, i)
+		for _, policy := range policies {
+			failures = append(failures, validateRuleAgainstPolicy(ruleAttributePath, policy, rule)...)
+		}
+	}
+	return failures
+}
+
+func validateRuleAgainstPolicy(ruleAttributePath string, policy apirulepolicyv1alpha1.APIRulePolicy, rule gatewayv2alpha1.Rule) []validation.Failure {
+	var failures []validation.Failure
+
+	if policy.Spec.ForbidNoAuth && rule.NoAuth != nil && *rule.NoAuth {
+		failures = append(failures, validation.Failure{
+			AttributePath: ruleAttributePath + ".noAuth",
+			Message:       fmt.Sprintf(`noAuth is forbidden by APIRulePolicy "%s"`, policy.Name),
+		})
+	}
+
+	jwtAttributePath, jwtConfig := ruleJwtConfig(ruleAttributePath, rule)
+	if jwtConfig != nil {
+		for j, authentication := range jwtConfig.Authentications {
+			if authentication != nil && !policy.AllowsIssuer(authentication.Issuer) {
+				failures = append(failures, validation.Failure{
+					AttributePath: fmt.Sprintf("%s.authentications[%d].issuer", jwtAttributePath, j),
+					Message:       fmt.Sprintf(`Issuer %s is not allowed by APIRulePolicy "%s"`, authentication.Issuer, policy.Name),
+				})
+			}
+		}
+	}
+
+	if policy.Spec.RequireScopesOnMutatingMethods && !hasRequiredScopes(jwtConfig) {
+		for _, method := range rule.Methods {
+			if slices.Contains(apirulepolicyv1alpha1.MutatingMethods, string(method)) {
+				failures = append(failures, validation.Failure{
+					AttributePath: jwtAttributePath + ".authorizations",
+					Message:       fmt.Sprintf(`At least one authorization with requiredScopes is required for method %s by APIRulePolicy "%s"`, method, policy.Name),
+				})
+				break
+			}
+		}
+	}
+
+	return failures
+}
+
+// ruleJwtConfig returns the JWT configuration of the rule and its attribute path. The path is also returned if the rule
+// has no JWT configuration, so failures about missing configuration point to the expected location.
+func ruleJwtConfig(ruleAttributePath string, rule gatewayv2alpha1.Rule) (string, *gatewayv2alpha1.JwtConfig) {
+	if rule.ExtAuth != nil {
+		return ruleAttributePath + ".extAuth.restrictions", rule.ExtAuth.Restrictions
+	}
+	return ruleAttributePath + ".jwt", rule.Jwt
+}
+
+func hasRequiredScopes(jwtConfig *gatewayv2alpha1.JwtConfig) bool {
+	if jwtConfig == nil {
+		return false
+	}
+	for _, authorization := range jwtConfig.Authorizations {
+		if authorization != nil && len(authorization.RequiredScopes) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package v2alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
+	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+var _ = Describe("Validate APIRule policies", func() {
+	getPolicy := func(spec apirulepolicyv1alpha1.APIRulePolicySpec) apirulepolicyv1alpha1.APIRulePolicy {
+		return apirulepolicyv1alpha1.APIRulePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "production"},
+			Spec:       spec,
+		}
+	}
+
+	getAPIRule := func(rules ...v2alpha1.Rule) *v2alpha1.APIRule {
+		return &v2alpha1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-name", Namespace: "production"},
+			Spec:       v2alpha1.APIRuleSpec{Rules: rules},
+		}
+	}
+
+	jwtRule := func(issuer string, scopes []string, methods ...v2alpha1.HttpMethod) v2alpha1.Rule {
+		rule := v2alpha1.Rule{
+			Path:    "/orders",
+			Methods: methods,
+			Jwt: &v2alpha1.JwtConfig{
+				Authentications: []*v2alpha1.JwtAuthentication{{Issuer: issuer, JwksUri: issuer + "/keys"}},
+			},
+		}
+		if scopes != nil {
+			rule.Jwt.Authorizations = []*v2alpha1.JwtAuthorization{{RequiredScopes: scopes}}
+		}
+		return rule
+	}
+
+	It("Should fail for noAuth rules if noAuth is forbidden", func() {
+		//given
+		policy := getPolicy(apirulepolicyv1alpha1.APIRulePolicySpec{ForbidNoAuth: true})
+		apiRule := getAPIRule(
+			jwtRule("https://issuer.example.com", nil, "GET"),
+			v2alpha1.Rule{Path: "/health", Methods: []v2alpha1.HttpMethod{"GET"}, NoAuth: ptr.To(true)},
+		)
+
+		//when
+		problems := validateAPIRulePolicies(".spec", []apirulepolicyv1alpha1.APIRulePolicy{policy}, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].noAuth"))
+		Expect(problems[0].Message).To(Equal(`noAuth is forbidden by APIRulePolicy "production"`))
+	})
+
+	It("Should fail for issuers that are not allowed", func() {
+		//given
+		policy := getPolicy(apirulepolicyv1alpha1.APIRulePolicySpec{AllowedJwtIssuers: []string{"https://issuer.example.com"}})
+		apiRule := getAPIRule(
+			jwtRule("https://issuer.example.com", nil, "GET"),
+			jwtRule("https://other.example.com", nil, "GET"),
+		)
+
+		//when
+		problems := validateAPIRulePolicies(".spec", []apirulepolicyv1alpha1.APIRulePolicy{policy}, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].jwt.authentications[0].issuer"))
+		Expect(problems[0].Message).To(Equal(`Issuer https://other.example.com is not allowed by APIRulePolicy "production"`))
+	})
+
+	It("Should validate the issuers of external authorization restrictions", func() {
+		//given
+		policy := getPolicy(apirulepolicyv1alpha1.APIRulePolicySpec{AllowedJwtIssuers: []string{"https://issuer.example.com"}})
+		apiRule := getAPIRule(v2alpha1.Rule{
+			Path:    "/orders",
+			Methods: []v2alpha1.HttpMethod{"GET"},
+			ExtAuth: &v2alpha1.ExtAuth{
+				ExternalAuthorizers: []string{"oauth2-proxy"},
+				Restrictions: &v2alpha1.JwtConfig{
+					Authentications: []*v2alpha1.JwtAuthentication{{Issuer: "https://other.example.com"}},
+				},
+			},
+		})
+
+		//when
+		problems := validateAPIRulePolicies(".spec", []apirulepolicyv1alpha1.APIRulePolicy{policy}, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].extAuth.restrictions.authentications[0].issuer"))
+	})
+
+	It("Should fail for mutating methods without required scopes", func() {
+		//given
+		policy := getPolicy(apirulepolicyv1alpha1.APIRulePolicySpec{RequireScopesOnMutatingMethods: true})
+		apiRule := getAPIRule(
+			jwtRule("https://issuer.example.com", nil, "GET"),
+			jwtRule("https://issuer.example.com", []string{"write"}, "POST"),
+			jwtRule("https://issuer.example.com", nil, "GET", "DELETE"),
+		)
+
+		//when
+		problems := validateAPIRulePolicies(".spec", []apirulepolicyv1alpha1.APIRulePolicy{policy}, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[2].jwt.authorizations"))
+		Expect(problems[0].Message).To(Equal(`At least one authorization with requiredScopes is required for method DELETE by APIRulePolicy "production"`))
+	})
+
+	It("Should report violations of all policies", func() {
+		//given
+		policies := []apirulepolicyv1alpha1.APIRulePolicy{
+			getPolicy(apirulepolicyv1alpha1.APIRulePolicySpec{ForbidNoAuth: true}),
+			getPolicy(apirulepolicyv1alpha1.APIRulePolicySpec{RequireScopesOnMutatingMethods: true}),
+		}
+		policies[1].Name = "scopes"
+		apiRule := getAPIRule(v2alpha1.Rule{Path: "/orders", Methods: []v2alpha1.HttpMethod{"PUT"}, NoAuth: ptr.To(true)})
+
+		//when
+		problems := validateAPIRulePolicies(".spec", policies, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].noAuth"))
+		Expect(problems[1].AttributePath).To(Equal(".spec.rules[0].jwt.authorizations"))
+		Expect(problems[1].Message).To(ContainSubstring(`APIRulePolicy "scopes"`))
+	})
+
+	It("Should succeed without policies", func() {
+		//given
+		apiRule := getAPIRule(v2alpha1.Rule{Path: "/orders", Methods: []v2alpha1.HttpMethod{"POST"}, NoAuth: ptr.To(true)})
+
+		//when
+		problems := validateAPIRulePolicies(".spec", nil, apiRule)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+})
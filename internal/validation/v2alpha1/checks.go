@@ -0,0 +1,69 @@
+package v2alpha1
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Check identifies a group of validations performed by the APIRuleValidator.
+type Check string
+
+const (
+	// CheckRules validates the structure of the rules, for example that a service is defined and the path is valid.
+	CheckRules Check = "rules"
+	// CheckPathConflicts validates that the paths of the rules don't conflict with each other.
+	CheckPathConflicts Check = "pathConflicts"
+	// CheckHostPathConflicts validates that the paths of the rules don't conflict with the paths of APIRules created earlier
+	// for the same host. It depends on the APIRules in the cluster.
+	CheckHostPathConflicts Check = "hostPathConflicts"
+	// CheckJwt validates the JWT authentications and authorizations of the rules.
+	CheckJwt Check = "jwt"
+	// CheckSidecarInjection validates that the workloads exposed by the rules have an Istio sidecar injected.
+	// It depends on the Pods running in the cluster.
+	CheckSidecarInjection Check = "sidecarInjection"
+	// CheckExtAuthProviders validates that the external authorizers used by the rules are configured in Istio.
+	// It depends on the Istio mesh configuration in the cluster.
+	CheckExtAuthProviders Check = "extAuthProviders"
+	// CheckHosts validates the hosts and that they are not occupied by other VirtualServices.
+	// It depends on the Gateways and VirtualServices in the cluster.
+	CheckHosts Check = "hosts"
+	// CheckHostPolicies validates that the namespace and Gateway of the APIRule are allowed to expose the hosts by the
+	// HostPolicies in the cluster.
+	CheckHostPolicies Check = "hostPolicies"
+	// CheckGateway validates that the referenced Gateway or ExternalGateway exists.
+	// It depends on the Gateways and ExternalGateways in the cluster.
+	CheckGateway Check = "gateway"
+	// CheckAPIRulePolicies validates the rules against the APIRulePolicies in the namespace of the APIRule.
+	// It depends on the APIRulePolicies in the cluster.
+	CheckAPIRulePolicies Check = "apiRulePolicies"
+)
+
+// AllChecks contains all checks performed by the APIRuleValidator.
+var AllChecks = []Check{
+	CheckRules,
+	CheckPathConflicts,
+	CheckHostPathConflicts,
+	CheckJwt,
+	CheckSidecarInjection,
+	CheckExtAuthProviders,
+	CheckHosts,
+	CheckHostPolicies,
+	CheckGateway,
+	CheckAPIRulePolicies,
+}
+
+// ParseCheck returns the Check with the given name.
+func ParseCheck(name string) (Check, error) {
+	c := Check(name)
+	if !slices.Contains(AllChecks, c) {
+		return "", fmt.Errorf("unknown APIRule validation check %q, supported checks are %v", name, AllChecks)
+	}
+	return c, nil
+}
+
+// checkSet is the set of checks that are performed during validation. A nil checkSet contains all checks.
+type checkSet []Check
+
+func (s checkSet) has(c Check) bool {
+	return s == nil || slices.Contains(s, c)
+}
@@ -3,6 +3,7 @@ package v2alpha1
 import (
 	"context"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 	"github.com/kyma-project/api-gateway/internal/validation"
 	"go.yaml.in/yaml/v3"
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +26,9 @@ type meshData struct {
 
 func validateExtAuthProviders(ctx context.Context, k8sClient client.Client, parentAttributePath string,
 	rule gatewayv2alpha1.Rule) (problems []validation.Failure, err error) {
+	ctx, span := tracing.Start(ctx, "v2alpha1.validateExtAuthProviders")
+	defer span.End()
+
 	istioConfigMap, err := getIstioConfigMap(ctx, k8sClient)
 	if err != nil {
 		return []validation.Failure{
@@ -47,7 +51,10 @@ func validateExtAuthProviders(ctx context.Context, k8sClient client.Client, pare
 	}
 
 	var mesh meshData
-	if err := yaml.Unmarshal([]byte(data), &mesh); err != nil {
+	_, parseSpan := tracing.Start(ctx, "v2alpha1.parseIstioMeshConfig")
+	err = yaml.Unmarshal([]byte(data), &mesh)
+	tracing.End(parseSpan, err)
+	if err != nil {
 		problems = append(problems, validation.Failure{
 			AttributePath: parentAttributePath + ".extAuth.externalAuthorizers",
 			Message:       "Failed to unmarshal mesh data",
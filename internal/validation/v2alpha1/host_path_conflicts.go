@@ -0,0 +1,208 @@
+package v2alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv2 "github.com/kyma-project/api-gateway/apis/gateway/v2"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/helpers"
+	"github.com/kyma-project/api-gateway/internal/path/segment_trie"
+	"github.com/kyma-project/api-gateway/internal/path/token"
+	"github.com/kyma-project/api-gateway/internal/processing/default_domain"
+	"github.com/kyma-project/api-gateway/internal/validation"
+)
+
+// HostsIndexField is the name of the field index on the hosts of APIRules. The index must be registered
+// with IndexHosts in the cache used as HostIndexReader of the APIRuleValidator.
+const HostsIndexField = "spec.hosts"
+
+// IndexHosts returns the hosts of an APIRule as they are defined in the spec, so short host names are indexed
+// without the domain of the Gateway.
+func IndexHosts(obj client.Object) []string {
+	apiRule, ok := obj.(*gatewayv2alpha1.APIRule)
+	if !ok {
+		return nil
+	}
+
+	var hosts []string
+	for _, host := range apiRule.Spec.Hosts {
+		if host != nil {
+			hosts = append(hosts, string(*host))
+		}
+	}
+	return hosts
+}
+
+const hostPathConflictTemplate = "Path %s with method %s conflicts with paths of APIRules %s on host %s"
+
+// validateHostPathConflicts validates that the paths of the rules don't conflict with the paths of other APIRules exposing
+// the same host. Istio merges the routes of VirtualServices with the same host in an undefined order, so the APIRule
+// that was created later is considered invalid.
+func validateHostPathConflicts(ctx context.Context, k8sClient client.Reader, parentAttributePath string, gwList networkingv1beta1.GatewayList, apiRule *gatewayv2alpha1.APIRule) ([]validation.Failure, error) {
+	hosts := resolveHosts(apiRule, gwList)
+	if len(hosts) == 0 {
+		return nil, nil
+	}
+
+	others, err := listAPIRulesWithHosts(ctx, k8sClient, hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []validation.Failure
+	for i, rule := range apiRule.Spec.Rules {
+		if !isVerifiablePath(apiRule, rule.Path) {
+			continue
+		}
+		for _, method := range ruleMethods(rule) {
+			conflictsByHost := make(map[string][]string)
+			for _, other := range others {
+				if other.Namespace == apiRule.Namespace && other.Name == apiRule.Name {
+					continue
+				}
+				if !other.DeletionTimestamp.IsZero() || !createdBefore(other, apiRule) {
+					continue
+				}
+
+				otherHosts := resolveHosts(other, gwList)
+				for _, host := range hosts {
+					if !slices.Contains(otherHosts, host) {
+						continue
+					}
+					if hasConflictingRule(rule.Path, method, other) {
+						conflictsByHost[host] = append(conflictsByHost[host], fmt.Sprintf("%s/%s", other.Namespace, other.Name))
+					}
+				}
+			}
+
+			for _, host := range hosts {
+				if names := conflictsByHost[host]; len(names) > 0 {
+					slices.Sort(names)
+					failures = append(failures, validation.Failure{
+						AttributePath: fmt.Sprintf("%s.rules[%d]", parentAttributePath, i),
+						Message:       fmt.Sprintf(hostPathConflictTemplate, rule.Path, method, strings.Join(slices.Compact(names), ", "), host),
+					})
+				}
+			}
+		}
+	}
+
+	return failures, nil
+}
+
+// resolveHosts returns the hosts of the APIRule with short host names extended by the domain of the Gateway.
+func resolveHosts(apiRule *gatewayv2alpha1.APIRule, gwList networkingv1beta1.GatewayList) []string {
+	var hosts []string
+	for _, host := range IndexHosts(apiRule) {
+		if helpers.IsShortHostName(host) && apiRule.Spec.Gateway != nil {
+			gatewayDomain := getGatewayDomain(findGateway(*apiRule.Spec.Gateway, gwList))
+			if gatewayDomain == "" {
+				continue
+			}
+			host = default_domain.GetHostWithDomain(host, gatewayDomain)
+		}
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// listAPIRulesWithHosts returns the APIRules that define one of the given hosts either as FQDN or as short host name.
+func listAPIRulesWithHosts(ctx context.Context, k8sClient client.Reader, hosts []string) ([]*gatewayv2alpha1.APIRule, error) {
+	var keys []string
+	for _, host := range hosts {
+		keys = append(keys, host)
+		if shortHost, _, found := strings.Cut(host, "."); found && !slices.Contains(keys, shortHost) {
+			keys = append(keys, shortHost)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var apiRules []*gatewayv2alpha1.APIRule
+	for _, key := range keys {
+		var apiRuleList gatewayv2alpha1.APIRuleList
+		if err := k8sClient.List(ctx, &apiRuleList, client.MatchingFields{HostsIndexField: key}); err != nil {
+			return nil, err
+		}
+		for i := range apiRuleList.Items {
+			item := &apiRuleList.Items[i]
+			id := item.Namespace + "/" + item.Name
+			if !seen[id] {
+				seen[id] = true
+				apiRules = append(apiRules, item)
+			}
+		}
+	}
+	return apiRules, nil
+}
+
+// createdBefore returns true if a was created before b. APIRules that are not yet created, for example during
+// admission, are considered the newest. APIRules with the same creation timestamp are ordered by namespace and name.
+func createdBefore(a, b *gatewayv2alpha1.APIRule) bool {
+	aCreated, bCreated := a.CreationTimestamp, b.CreationTimestamp
+	switch {
+	case aCreated.IsZero() && !bCreated.IsZero():
+		return false
+	case !aCreated.IsZero() && bCreated.IsZero():
+		return true
+	case !aCreated.Equal(&bCreated):
+		return aCreated.Before(&bCreated)
+	}
+	return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+}
+
+func ruleMethods(rule gatewayv2alpha1.Rule) []gatewayv2alpha1.HttpMethod {
+	if len(rule.Methods) == 0 {
+		return []gatewayv2alpha1.HttpMethod{"NO_METHODS"}
+	}
+	return rule.Methods
+}
+
+func hasConflictingRule(path string, method gatewayv2alpha1.HttpMethod, other *gatewayv2alpha1.APIRule) bool {
+	for _, rule := range other.Spec.Rules {
+		if !isVerifiablePath(other, rule.Path) {
+			continue
+		}
+		if slices.Contains(ruleMethods(rule), method) && pathsConflict(path, rule.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVerifiablePath returns false for paths of APIRules created with version v1beta1 that contain regular expression
+// metacharacters. Such paths are matched as regular expressions and can't be compared with the segment trie, so
+// conflicts with them aren't detected.
+func isVerifiablePath(apiRule *gatewayv2alpha1.APIRule, path string) bool {
+	if apiRule.Annotations[gatewayv2.OriginalVersionAnnotation] != "v1beta1" {
+		return true
+	}
+	return regexp.QuoteMeta(path) == path
+}
+
+// pathsConflict checks the collision in both directions, because the order of rules from different APIRules
+// is not defined.
+func pathsConflict(a, b string) bool {
+	return insertCollides(a, b) || insertCollides(b, a)
+}
+
+func insertCollides(existing, path string) bool {
+	trie := segment_trie.New()
+	_ = trie.InsertAndCheckCollisions(token.TokenizePath(normalizeRulePath(existing)))
+	return trie.InsertAndCheckCollisions(token.TokenizePath(normalizeRulePath(path))) != nil
+}
+
+func normalizeRulePath(path string) string {
+	if path == "/*" {
+		return "/{**}"
+	}
+	return path
+}
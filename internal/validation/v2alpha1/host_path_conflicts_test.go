@@ -0,0 +1,200 @@
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"istio.io/api/networking/v1beta1"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+)
+
+func getHostPathConflictsAPIRule(name, namespace string, created time.Time, host string, rules ...v2alpha1.Rule) *v2alpha1.APIRule {
+	h := v2alpha1.Host(host)
+	return &v2alpha1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: v2alpha1.APIRuleSpec{
+			Hosts:   []*v2alpha1.Host{&h},
+			Gateway: ptr.To("kyma-system/kyma-gateway"),
+			Rules:   rules,
+		},
+	}
+}
+
+func getHostPathConflictsRule(path string, methods ...v2alpha1.HttpMethod) v2alpha1.Rule {
+	return v2alpha1.Rule{Path: path, Methods: methods, NoAuth: ptr.To(true)}
+}
+
+var _ = Describe("Validate host path conflicts", func() {
+	earlier := time.Now().Add(-time.Hour)
+	later := time.Now()
+
+	gwList := networkingv1beta1.GatewayList{
+		Items: []*networkingv1beta1.Gateway{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "kyma-gateway", Namespace: "kyma-system"},
+				Spec: v1beta1.Gateway{
+					Servers: []*v1beta1.Server{{Hosts: []string{"*.example.com"}}},
+				},
+			},
+		},
+	}
+
+	It("should fail when the path conflicts with the path of an earlier APIRule on the same host", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/orders", "GET", "POST"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0]"))
+		Expect(problems[0].Message).To(Equal("Path /api/orders with method GET conflicts with paths of APIRules ns-a/existing on host app.example.com"))
+	})
+
+	It("should not fail for the APIRule that was created first", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/orders", "GET"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, existing)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("should fail for an APIRule that is not created yet", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/orders", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", time.Time{}, "app.example.com", getHostPathConflictsRule("/api/{*}", "GET"))
+		k8sClient := createFakeClient(existing)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(ContainSubstring("ns-a/existing"))
+	})
+
+	It("should detect the conflict when one APIRule uses a short host name", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app", getHostPathConflictsRule("/", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/", "GET"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(Equal("Path / with method GET conflicts with paths of APIRules ns-a/existing on host app.example.com"))
+	})
+
+	It("should not compare regular expression paths of APIRules created with version v1beta1", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/.*", "GET"))
+		existing.Annotations = map[string]string{"gateway.kyma-project.io/original-version": "v1beta1"}
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("should detect the conflict with a literal path of an APIRule created with version v1beta1", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/orders", "GET"))
+		existing.Annotations = map[string]string{"gateway.kyma-project.io/original-version": "v1beta1"}
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(ContainSubstring("ns-a/existing"))
+	})
+
+	It("should not fail when the paths use different methods", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/orders", "POST"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("should not fail when the APIRules expose different hosts", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "other.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/orders", "GET"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("should not fail when the paths don't overlap", func() {
+		// given
+		existing := getHostPathConflictsAPIRule("existing", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/orders", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/users/{*}", "GET"))
+		k8sClient := createFakeClient(existing, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("should list all conflicting APIRules", func() {
+		// given
+		first := getHostPathConflictsAPIRule("first", "ns-a", earlier, "app.example.com", getHostPathConflictsRule("/api/orders", "GET"))
+		second := getHostPathConflictsAPIRule("second", "ns-c", earlier, "app.example.com", getHostPathConflictsRule("/api/{**}", "GET"))
+		apiRule := getHostPathConflictsAPIRule("new", "ns-b", later, "app.example.com", getHostPathConflictsRule("/api/{*}", "GET"))
+		k8sClient := createFakeClient(first, second, apiRule)
+
+		// when
+		problems, err := validateHostPathConflicts(context.Background(), k8sClient, ".spec", gwList, apiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(ContainSubstring("ns-a/first, ns-c/second"))
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	gatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	"github.com/kyma-project/api-gateway/internal/helpers"
@@ -15,19 +16,33 @@ import (
 )
 
 func validateHosts(parentAttributePath string, vsList networkingv1beta1.VirtualServiceList, gwList networkingv1beta1.GatewayList, apiRule *gatewayv2alpha1.APIRule) []validation.Failure {
+	return validateHostsWithChecks(parentAttributePath, vsList, gwList, nil, apiRule, nil)
+}
+
+func validateHostsWithChecks(parentAttributePath string, vsList networkingv1beta1.VirtualServiceList, gwList networkingv1beta1.GatewayList, hostPolicies []hostpolicyv1alpha1.HostPolicy, apiRule *gatewayv2alpha1.APIRule, checks checkSet) []validation.Failure {
 	var failures []validation.Failure
 	hostsAttributePath := parentAttributePath + ".hosts"
 
 	hosts := apiRule.Spec.Hosts
 	if len(hosts) == 0 {
-		failures = append(failures, validation.Failure{
-			AttributePath: hostsAttributePath,
-			Message:       "No hosts defined",
-		})
+		if checks.has(CheckHosts) {
+			failures = append(failures, validation.Failure{
+				AttributePath: hostsAttributePath,
+				Message:       "No hosts defined",
+			})
+		}
 		return failures
 	}
 
 	for hostIndex, host := range hosts {
+		if checks.has(CheckHostPolicies) && len(hostPolicies) > 0 {
+			hostAttributePath := fmt.Sprintf("%s[%d]", hostsAttributePath, hostIndex)
+			failures = append(failures, validateHostPolicies(hostAttributePath, string(*host), gwList, hostPolicies, apiRule)...)
+		}
+		if !checks.has(CheckHosts) {
+			continue
+		}
+
 		gatewayDomain := ""
 		if helpers.IsShortHostName(string(*host)) {
 			gateway := findGateway(*apiRule.Spec.Gateway, gwList)
@@ -67,6 +82,56 @@ func validateHosts(parentAttributePath string, vsList networkingv1beta1.VirtualS
 	return failures
 }
 
+// validateHostPolicies validates that the APIRule is allowed to expose the host by all HostPolicies matching the host.
+func validateHostPolicies(hostAttributePath string, host string, gwList networkingv1beta1.GatewayList, hostPolicies []hostpolicyv1alpha1.HostPolicy, apiRule *gatewayv2alpha1.APIRule) []validation.Failure {
+	if helpers.IsShortHostName(host) {
+		if apiRule.Spec.Gateway == nil {
+			return nil
+		}
+		gatewayDomain := getGatewayDomain(findGateway(*apiRule.Spec.Gateway, gwList))
+		if gatewayDomain == "" {
+			return nil
+		}
+		host = default_domain.GetHostWithDomain(host, gatewayDomain)
+	}
+
+	gateway := gatewayReference(apiRule)
+
+	var failures []validation.Failure
+	for _, policy := range hostPolicies {
+		if !policy.MatchesHost(host) {
+			continue
+		}
+		if !policy.AllowsNamespace(apiRule.Namespace) {
+			failures = append(failures, validation.Failure{
+				AttributePath: hostAttributePath,
+				Message:       fmt.Sprintf(`Host %s is not allowed in namespace "%s" by HostPolicy "%s"`, host, apiRule.Namespace, policy.Name),
+			})
+		}
+		if !policy.AllowsGateway(gateway) {
+			failures = append(failures, validation.Failure{
+				AttributePath: hostAttributePath,
+				Message:       fmt.Sprintf(`Host %s is not allowed on Gateway "%s" by HostPolicy "%s"`, host, gateway, policy.Name),
+			})
+		}
+	}
+	return failures
+}
+
+// gatewayReference returns the Gateway or ExternalGateway of the APIRule in the namespace/name format.
+func gatewayReference(apiRule *gatewayv2alpha1.APIRule) string {
+	if apiRule.Spec.Gateway != nil {
+		return *apiRule.Spec.Gateway
+	}
+	if apiRule.Spec.ExternalGateway != nil {
+		if strings.Contains(*apiRule.Spec.ExternalGateway, "/") {
+			return *apiRule.Spec.ExternalGateway
+		}
+		return apiRule.Namespace + "/" + *apiRule.Spec.ExternalGateway
+	}
+	return ""
+}
+
 func getGatewayDomain(gateway *networkingv1beta1.Gateway) string {
 	if gateway != nil {
 		for _, server := range gateway.Spec.Servers {
@@ -8,6 +8,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	"github.com/kyma-project/api-gateway/internal/processing"
 	"github.com/kyma-project/api-gateway/internal/validation"
@@ -345,3 +346,136 @@ var _ = Describe("Validate hosts", func() {
 		Expect(problems[0].Message).To(Equal("Host is occupied by another Virtual Service"))
 	})
 })
+
+var _ = Describe("Validate host policies", func() {
+	gwList := networkingv1beta1.GatewayList{
+		Items: []*networkingv1beta1.Gateway{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "kyma-gateway", Namespace: "kyma-system"},
+				Spec: v1beta1.Gateway{
+					Servers: []*v1beta1.Server{{Hosts: []string{"*.example.com"}}},
+				},
+			},
+		},
+	}
+
+	paymentsPolicy := hostpolicyv1alpha1.HostPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments"},
+		Spec: hostpolicyv1alpha1.HostPolicySpec{
+			Hosts:             []string{"payments.example.com"},
+			AllowedNamespaces: []string{"payments"},
+			AllowedGateways:   []string{"kyma-system/kyma-gateway"},
+		},
+	}
+
+	getAPIRule := func(namespace, gateway string, host string) *v2alpha1.APIRule {
+		return &v2alpha1.APIRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-name", Namespace: namespace},
+			Spec: v2alpha1.APIRuleSpec{
+				Gateway: ptr.To(gateway),
+				Hosts:   []*v2alpha1.Host{ptr.To(v2alpha1.Host(host))},
+			},
+		}
+	}
+
+	It("Should succeed if the namespace and Gateway are allowed by the policy", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("payments", "kyma-system/kyma-gateway", "payments.example.com"), nil)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should fail with the name of the policy if the namespace is not allowed", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("team-a", "kyma-system/kyma-gateway", "payments.example.com"), nil)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.hosts[0]"))
+		Expect(problems[0].Message).To(Equal(`Host payments.example.com is not allowed in namespace "team-a" by HostPolicy "payments"`))
+	})
+
+	It("Should fail if the Gateway is not allowed", func() {
+		//given
+		gwList := networkingv1beta1.GatewayList{Items: append([]*networkingv1beta1.Gateway{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom-gateway", Namespace: "payments"},
+				Spec: v1beta1.Gateway{
+					Servers: []*v1beta1.Server{{Hosts: []string{"*.example.com"}}},
+				},
+			},
+		}, gwList.Items...)}
+
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("payments", "payments/custom-gateway", "payments"), nil)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(Equal(`Host payments.example.com is not allowed on Gateway "payments/custom-gateway" by HostPolicy "payments"`))
+	})
+
+	It("Should apply the policy to short host names", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("team-a", "kyma-system/kyma-gateway", "payments"), nil)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(ContainSubstring(`by HostPolicy "payments"`))
+	})
+
+	It("Should succeed if no policy matches the host", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("team-a", "kyma-system/kyma-gateway", "orders.example.com"), nil)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should apply the policy to a wildcard host covering the host of the policy", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("team-a", "kyma-system/kyma-gateway", "*.example.com"), checkSet{CheckHostPolicies})
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].Message).To(Equal(`Host *.example.com is not allowed in namespace "team-a" by HostPolicy "payments"`))
+	})
+
+	It("Should apply a wildcard policy to wildcard hosts overlapping in both directions", func() {
+		//given
+		wildcardPolicy := hostpolicyv1alpha1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments-subdomains"},
+			Spec: hostpolicyv1alpha1.HostPolicySpec{
+				Hosts:             []string{"*.payments.example.com"},
+				AllowedNamespaces: []string{"payments"},
+			},
+		}
+
+		for _, host := range []string{"*.example.com", "*.payments.example.com", "*.api.payments.example.com"} {
+			//when
+			problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{wildcardPolicy}, getAPIRule("team-a", "kyma-system/kyma-gateway", host), checkSet{CheckHostPolicies})
+
+			//then
+			Expect(problems).To(HaveLen(1), host)
+			Expect(problems[0].Message).To(ContainSubstring(`by HostPolicy "payments-subdomains"`))
+		}
+	})
+
+	It("Should not apply the policy to a wildcard host of another domain", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("team-a", "kyma-system/kyma-gateway", "*.orders.example.com"), checkSet{CheckHostPolicies})
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("Should only validate the policies if only the host policies check is performed", func() {
+		//when
+		problems := validateHostsWithChecks(".spec", networkingv1beta1.VirtualServiceList{}, gwList, []hostpolicyv1alpha1.HostPolicy{paymentsPolicy}, getAPIRule("team-a", "kyma-system/missing", "payments.example.com"), checkSet{CheckHostPolicies})
+
+		//then
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].Message).To(ContainSubstring("not allowed in namespace"))
+		Expect(problems[1].Message).To(ContainSubstring("not allowed on Gateway"))
+	})
+})
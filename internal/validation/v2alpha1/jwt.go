@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"slices"
 	"strings"
 
 	"github.com/kyma-project/api-gateway/internal/validation"
@@ -204,3 +205,23 @@ func validateJwtIssuer(issuer string) error {
 
 	return nil
 }
+
+// validateAllowedJwtIssuers validates that the JWT authentications of all rules use one of the allowed issuers.
+func validateAllowedJwtIssuers(parentAttributePath string, allowedIssuers []string, apiRule *gatewayv2alpha1.APIRule) []validation.Failure {
+	var failures []validation.Failure
+	for i, rule := range apiRule.Spec.Rules {
+		jwtAttributePath, jwtConfig := ruleJwtConfig(fmt.Sprintf("%s.rules[%d]", parentAttributePath, i), rule)
+		if jwtConfig == nil {
+			continue
+		}
+		for j, authentication := range jwtConfig.Authentications {
+			if authentication != nil && !slices.Contains(allowedIssuers, authentication.Issuer) {
+				failures = append(failures, validation.Failure{
+					AttributePath: fmt.Sprintf("%s.authentications[%d].issuer", jwtAttributePath, j),
+					Message:       fmt.Sprintf("Issuer %s is not allowed by the APIGateway CR", authentication.Issuer),
+				})
+			}
+		}
+	}
+	return failures
+}
@@ -205,3 +205,56 @@ var _ = Describe("validateJwtAuthenticationEquality", func() {
 		Expect(problems).To(HaveLen(0))
 	})
 })
+
+var _ = Describe("validateAllowedJwtIssuers", func() {
+	jwtRule := func(issuers ...string) gatewayv2alpha1.Rule {
+		config := &gatewayv2alpha1.JwtConfig{}
+		for _, issuer := range issuers {
+			config.Authentications = append(config.Authentications, &gatewayv2alpha1.JwtAuthentication{Issuer: issuer, JwksUri: issuer + "/keys"})
+		}
+		return gatewayv2alpha1.Rule{Path: "/orders", Jwt: config}
+	}
+
+	It("should fail for issuers that are not allowed", func() {
+		//given
+		apiRule := &gatewayv2alpha1.APIRule{Spec: gatewayv2alpha1.APIRuleSpec{Rules: []gatewayv2alpha1.Rule{
+			jwtRule("https://allowed.example.com"),
+			jwtRule("https://allowed.example.com", "https://other.example.com"),
+		}}}
+
+		//when
+		problems := validateAllowedJwtIssuers(".spec", []string{"https://allowed.example.com"}, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[1].jwt.authentications[1].issuer"))
+		Expect(problems[0].Message).To(Equal("Issuer https://other.example.com is not allowed by the APIGateway CR"))
+	})
+
+	It("should validate the issuers of external authorization restrictions", func() {
+		//given
+		rule := gatewayv2alpha1.Rule{Path: "/orders", ExtAuth: &gatewayv2alpha1.ExtAuth{
+			ExternalAuthorizers: []string{"authorizer"},
+			Restrictions:        jwtRule("https://other.example.com").Jwt,
+		}}
+		apiRule := &gatewayv2alpha1.APIRule{Spec: gatewayv2alpha1.APIRuleSpec{Rules: []gatewayv2alpha1.Rule{rule}}}
+
+		//when
+		problems := validateAllowedJwtIssuers(".spec", []string{"https://allowed.example.com"}, apiRule)
+
+		//then
+		Expect(problems).To(HaveLen(1))
+		Expect(problems[0].AttributePath).To(Equal(".spec.rules[0].extAuth.restrictions.authentications[0].issuer"))
+	})
+
+	It("should succeed for rules without JWT", func() {
+		//given
+		apiRule := &gatewayv2alpha1.APIRule{Spec: gatewayv2alpha1.APIRuleSpec{Rules: []gatewayv2alpha1.Rule{{Path: "/health"}}}}
+
+		//when
+		problems := validateAllowedJwtIssuers(".spec", []string{"https://allowed.example.com"}, apiRule)
+
+		//then
+		Expect(problems).To(BeEmpty())
+	})
+})
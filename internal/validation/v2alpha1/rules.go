@@ -12,31 +12,42 @@ import (
 )
 
 func validateRules(ctx context.Context, client client.Client, parentAttributePath string, apiRule *gatewayv2alpha1.APIRule) []validation.Failure {
+	return validateRulesWithChecks(ctx, client, parentAttributePath, apiRule, nil)
+}
+
+func validateRulesWithChecks(ctx context.Context, client client.Client, parentAttributePath string, apiRule *gatewayv2alpha1.APIRule, checks checkSet) []validation.Failure {
 	var problems []validation.Failure
 	rulesAttributePath := parentAttributePath + ".rules"
 
 	rules := apiRule.Spec.Rules
 	if len(rules) == 0 {
-		problems = append(problems, validation.Failure{AttributePath: rulesAttributePath, Message: "No rules defined"})
+		if checks.has(CheckRules) {
+			problems = append(problems, validation.Failure{AttributePath: rulesAttributePath, Message: "No rules defined"})
+		}
 		return problems
 	}
 
 	for i, rule := range rules {
 		ruleAttributePath := fmt.Sprintf("%s[%d]", rulesAttributePath, i)
 
-		if apiRule.Spec.Service == nil && rule.Service == nil {
+		if checks.has(CheckRules) && apiRule.Spec.Service == nil && rule.Service == nil {
 			problems = append(problems, validation.Failure{AttributePath: ruleAttributePath + ".service", Message: "The rule must define a service, because no service is defined on spec level"})
 		}
 
-		problems = append(problems, validateJwt(ruleAttributePath, &rule)...)
-		injectionFailures, err := validateSidecarInjection(ctx, client, ruleAttributePath, apiRule, rule)
-		if err != nil {
-			problems = append(problems, validation.Failure{AttributePath: ruleAttributePath, Message: fmt.Sprintf("Failed to execute sidecar injection validation, err: %s", err)})
+		if checks.has(CheckJwt) {
+			problems = append(problems, validateJwt(ruleAttributePath, &rule)...)
 		}
 
-		problems = append(problems, injectionFailures...)
+		if checks.has(CheckSidecarInjection) {
+			injectionFailures, err := validateSidecarInjection(ctx, client, ruleAttributePath, apiRule, rule)
+			if err != nil {
+				problems = append(problems, validation.Failure{AttributePath: ruleAttributePath, Message: fmt.Sprintf("Failed to execute sidecar injection validation, err: %s", err)})
+			}
+
+			problems = append(problems, injectionFailures...)
+		}
 
-		if rule.ExtAuth != nil {
+		if checks.has(CheckExtAuthProviders) && rule.ExtAuth != nil {
 			extAuthFailures, err := validateExtAuthProviders(ctx, client, ruleAttributePath, rule)
 			if err != nil {
 				problems = append(problems, validation.Failure{AttributePath: ruleAttributePath, Message: fmt.Sprintf("Failed to execute external auth provider validation, err: %s", err)})
@@ -45,13 +56,19 @@ func validateRules(ctx context.Context, client client.Client, parentAttributePat
 			problems = append(problems, extAuthFailures...)
 		}
 
-		problems = append(problems, validatePath(ruleAttributePath, rule.Path)...)
+		if checks.has(CheckRules) {
+			problems = append(problems, validatePath(ruleAttributePath, rule.Path)...)
+		}
 	}
 
-	problems = append(problems, hasPathByMethodConflict(rulesAttributePath, rules)...)
+	if checks.has(CheckPathConflicts) {
+		problems = append(problems, hasPathByMethodConflict(rulesAttributePath, rules)...)
+	}
 
-	jwtAuthFailures := validateJwtAuthenticationEquality(rulesAttributePath, rules)
-	problems = append(problems, jwtAuthFailures...)
+	if checks.has(CheckJwt) {
+		jwtAuthFailures := validateJwtAuthenticationEquality(rulesAttributePath, rules)
+		problems = append(problems, jwtAuthFailures...)
+	}
 
 	return problems
 }
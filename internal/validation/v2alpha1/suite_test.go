@@ -2,7 +2,9 @@ package v2alpha1
 
 import (
 	"fmt"
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	rulev1alpha1 "github.com/kyma-project/api-gateway/internal/types/ory/oathkeeper-maester/api/v1alpha1"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
@@ -66,10 +68,16 @@ func createFakeClient(objs ...client.Object) client.Client {
 	Expect(err).NotTo(HaveOccurred())
 	err = externalv1alpha1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = hostpolicyv1alpha1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = apirulepolicyv1alpha1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
 	err = corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
-	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithIndex(&v2alpha1.APIRule{}, HostsIndexField, IndexHosts).
+		Build()
 }
 
 func getService(name string, namespace ...string) *corev1.Service {
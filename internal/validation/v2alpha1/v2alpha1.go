@@ -6,8 +6,11 @@ import (
 	"reflect"
 
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/tracing"
 	"github.com/kyma-project/api-gateway/internal/validation"
+	"go.opentelemetry.io/otel/attribute"
 	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -18,6 +21,13 @@ const (
 
 type APIRuleValidator struct {
 	ApiRule *gatewayv2alpha1.APIRule
+	// Checks limits the validation to the given checks. If it is nil, all checks are performed.
+	Checks []Check
+	// HostIndexReader reads APIRules by the HostsIndexField. It must be backed by a cache with the index, because the
+	// API server doesn't support field selectors on hosts. If it is nil, the client passed to Validate is used.
+	HostIndexReader client.Reader
+	// AllowedJwtIssuers are the issuers that can be used in JWT authentications. All issuers are allowed if it is empty.
+	AllowedJwtIssuers []string
 }
 
 func NewAPIRuleValidator(apiRule *gatewayv2alpha1.APIRule) validation.ApiRuleValidator {
@@ -26,26 +36,70 @@ func NewAPIRuleValidator(apiRule *gatewayv2alpha1.APIRule) validation.ApiRuleVal
 	}
 }
 
-func (a *APIRuleValidator) Validate(ctx context.Context, client client.Client, vsList networkingv1beta1.VirtualServiceList, gwList networkingv1beta1.GatewayList) []validation.Failure {
-	var failures []validation.Failure
-
-	// Fetch ExternalGateway list
-	var externalGwList externalv1alpha1.ExternalGatewayList
-	if err := client.List(ctx, &externalGwList); err != nil {
-		failures = append(failures, validation.Failure{
-			AttributePath: ".spec",
-			Message:       fmt.Sprintf("Failed to list ExternalGateways: %v", err),
-		})
-	}
+func (a *APIRuleValidator) Validate(ctx context.Context, client client.Client, vsList networkingv1beta1.VirtualServiceList, gwList networkingv1beta1.GatewayList) (failures []validation.Failure) {
+	ctx, span := tracing.Start(ctx, "v2alpha1.APIRuleValidator.Validate", tracing.APIRuleAttributes(a.ApiRule.Name, a.ApiRule.Namespace)...)
+	defer func() {
+		span.SetAttributes(attribute.Int("validation.failures", len(failures)))
+		span.End()
+	}()
+	checks := checkSet(a.Checks)
 
 	if reflect.DeepEqual(a.ApiRule.Spec, gatewayv2alpha1.APIRuleSpec{}) {
 		failures = append(failures, validation.Failure{
 			AttributePath: ".spec",
 			Message:       fmt.Sprintf("APIRule in version v2alpha1 contains an empty spec. To troubleshoot, see %s.", troubleshootingGuideURL),
 		})
-	} else {
-		failures = append(failures, validateRules(ctx, client, ".spec", a.ApiRule)...)
-		failures = append(failures, validateHosts(".spec", vsList, gwList, a.ApiRule)...)
+		return failures
+	}
+
+	failures = append(failures, validateRulesWithChecks(ctx, client, ".spec", a.ApiRule, checks)...)
+	if checks.has(CheckJwt) && len(a.AllowedJwtIssuers) > 0 {
+		failures = append(failures, validateAllowedJwtIssuers(".spec", a.AllowedJwtIssuers, a.ApiRule)...)
+	}
+	if checks.has(CheckAPIRulePolicies) {
+		policies, err := listAPIRulePolicies(ctx, client, a.ApiRule.Namespace)
+		if err != nil {
+			failures = append(failures, validation.Failure{
+				AttributePath: ".spec.rules",
+				Message:       fmt.Sprintf("Failed to list APIRulePolicies: %v", err),
+			})
+		}
+		failures = append(failures, validateAPIRulePolicies(".spec", policies, a.ApiRule)...)
+	}
+	if checks.has(CheckHosts) || checks.has(CheckHostPolicies) {
+		var hostPolicyList hostpolicyv1alpha1.HostPolicyList
+		if checks.has(CheckHostPolicies) {
+			if err := client.List(ctx, &hostPolicyList); err != nil {
+				failures = append(failures, validation.Failure{
+					AttributePath: ".spec.hosts",
+					Message:       fmt.Sprintf("Failed to list HostPolicies: %v", err),
+				})
+			}
+		}
+		failures = append(failures, validateHostsWithChecks(".spec", vsList, gwList, hostPolicyList.Items, a.ApiRule, checks)...)
+	}
+	if checks.has(CheckHostPathConflicts) {
+		hostIndexReader := a.HostIndexReader
+		if hostIndexReader == nil {
+			hostIndexReader = client
+		}
+		conflictFailures, err := validateHostPathConflicts(ctx, hostIndexReader, ".spec", gwList, a.ApiRule)
+		if err != nil {
+			failures = append(failures, validation.Failure{
+				AttributePath: ".spec.rules",
+				Message:       fmt.Sprintf("Failed to execute host path conflict validation, err: %s", err),
+			})
+		}
+		failures = append(failures, conflictFailures...)
+	}
+	if checks.has(CheckGateway) {
+		var externalGwList externalv1alpha1.ExternalGatewayList
+		if err := client.List(ctx, &externalGwList); err != nil {
+			failures = append(failures, validation.Failure{
+				AttributePath: ".spec",
+				Message:       fmt.Sprintf("Failed to list ExternalGateways: %v", err),
+			})
+		}
 		failures = append(failures, validateGateway(".spec", gwList, externalGwList, a.ApiRule)...)
 	}
 
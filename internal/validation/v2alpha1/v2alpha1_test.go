@@ -3,7 +3,9 @@ package v2alpha1_test
 import (
 	"context"
 
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
 	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
 	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	"github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
 	. "github.com/onsi/ginkgo/v2"
@@ -93,10 +95,50 @@ func createFakeClient(objs ...client.Object) client.Client {
 	Expect(err).NotTo(HaveOccurred())
 	err = externalv1alpha1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = hostpolicyv1alpha1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = apirulepolicyv1alpha1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
 	err = corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 	err = networkingv1beta1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
-	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithIndex(&gatewayv2alpha1.APIRule{}, v2alpha1.HostsIndexField, v2alpha1.IndexHosts).
+		Build()
 }
+
+var _ = Describe("Validate with checks", func() {
+	It("should only run the given checks", func() {
+		// given
+		host := gatewayv2alpha1.Host("some-service.test.dev")
+		apiRule := &gatewayv2alpha1.APIRule{
+			Spec: gatewayv2alpha1.APIRuleSpec{
+				Rules: []gatewayv2alpha1.Rule{
+					{Path: "/abc", Methods: []gatewayv2alpha1.HttpMethod{"GET"}, NoAuth: ptr.To(true)},
+					{Path: "/abc", Methods: []gatewayv2alpha1.HttpMethod{"GET"}, NoAuth: ptr.To(true)},
+				},
+				Service: &gatewayv2alpha1.Service{Name: ptr.To("some-service"), Port: ptr.To(uint32(8080))},
+				Hosts:   []*gatewayv2alpha1.Host{&host},
+				Gateway: ptr.To("namespace/gateway"),
+			},
+		}
+		fakeClient := createFakeClient(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-service", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "some-service"}},
+		})
+
+		// when
+		gatewayProblems := (&v2alpha1.APIRuleValidator{ApiRule: apiRule, Checks: []v2alpha1.Check{v2alpha1.CheckGateway}}).Validate(context.Background(), fakeClient, networkingv1beta1.VirtualServiceList{}, networkingv1beta1.GatewayList{})
+		conflictProblems := (&v2alpha1.APIRuleValidator{ApiRule: apiRule, Checks: []v2alpha1.Check{v2alpha1.CheckPathConflicts}}).Validate(context.Background(), fakeClient, networkingv1beta1.VirtualServiceList{}, networkingv1beta1.GatewayList{})
+		allProblems := (&v2alpha1.APIRuleValidator{ApiRule: apiRule}).Validate(context.Background(), fakeClient, networkingv1beta1.VirtualServiceList{}, networkingv1beta1.GatewayList{})
+
+		// then
+		Expect(gatewayProblems).To(HaveLen(1))
+		Expect(gatewayProblems[0].AttributePath).To(Equal(".spec.gateway"))
+		Expect(conflictProblems).To(HaveLen(1))
+		Expect(conflictProblems[0].AttributePath).To(Equal(".spec.rules"))
+		Expect(allProblems).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,106 @@
+package v2alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/apirulesettings"
+	"github.com/kyma-project/api-gateway/internal/validation"
+	v2alpha1validation "github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-gateway-kyma-project-io-v2alpha1-apirule,mutating=false,failurePolicy=fail,sideEffects=None,groups=gateway.kyma-project.io,resources=apirules,verbs=create;update,versions=v2alpha1,name=v2alpha1-validation.apirule.gateway.kyma-project.io,admissionReviewVersions=v1,servicePort=9443,serviceName=api-gateway-webhook-service,serviceNamespace=kyma-system,matchPolicy=Equivalent
+// +kubebuilder:object:generate=false
+
+// ValidatingWebhook runs the APIRule validation of the reconciliation at admission.
+// Depending on the Config, failing checks reject the request or are returned as warnings. The APIRule settings of the
+// APIGateway CR are applied as in the reconciliation, so checks disabled in the CR are not run and JWT issuers that
+// are not allowed by the CR are rejected.
+type ValidatingWebhook struct {
+	Client client.Client
+	// HostIndexReader reads APIRules by the hosts index. If it is nil, the Client is used.
+	HostIndexReader client.Reader
+	Config          ValidationConfig
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (w *ValidatingWebhook) ValidateCreate(ctx context.Context, apiRule *gatewayv2alpha1.APIRule) (admission.Warnings, error) {
+	return w.validate(ctx, apiRule)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (w *ValidatingWebhook) ValidateUpdate(ctx context.Context, oldApiRule, newApiRule *gatewayv2alpha1.APIRule) (admission.Warnings, error) {
+	// Updates that don't change the spec, for example removing the finalizer during deletion, must not be blocked.
+	if !newApiRule.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(oldApiRule.Spec, newApiRule.Spec) {
+		return nil, nil
+	}
+	return w.validate(ctx, newApiRule)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (w *ValidatingWebhook) ValidateDelete(_ context.Context, _ *gatewayv2alpha1.APIRule) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *ValidatingWebhook) validate(ctx context.Context, apiRule *gatewayv2alpha1.APIRule) (admission.Warnings, error) {
+	// APIRules that were created in version v1beta1 are validated by the v1beta1 validation during reconciliation.
+	if apiRule.Annotations["gateway.kyma-project.io/original-version"] == "v1beta1" {
+		return nil, nil
+	}
+
+	var vsList networkingv1beta1.VirtualServiceList
+	if err := w.Client.List(ctx, &vsList); err != nil {
+		return nil, err
+	}
+
+	var gwList networkingv1beta1.GatewayList
+	if err := w.Client.List(ctx, &gwList); err != nil {
+		return nil, err
+	}
+
+	// Invalid settings of the APIGateway CR are reported in its conditions and the valid settings are still applied.
+	settings, err := apirulesettings.Read(ctx, w.Client)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read the APIRule settings of the APIGateway CR, using the valid settings")
+	}
+
+	var warnings admission.Warnings
+	if checks := w.Config.checks(AdmissionModeWarn, settings.Checks); len(checks) > 0 {
+		validator := v2alpha1validation.APIRuleValidator{ApiRule: apiRule, Checks: checks, AllowedJwtIssuers: settings.AllowedJwtIssuers, HostIndexReader: w.HostIndexReader}
+		for _, failure := range validator.Validate(ctx, w.Client, vsList, gwList) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", failure.AttributePath, failure.Message))
+		}
+	}
+
+	if checks := w.Config.checks(AdmissionModeReject, settings.Checks); len(checks) > 0 {
+		validator := v2alpha1validation.APIRuleValidator{ApiRule: apiRule, Checks: checks, AllowedJwtIssuers: settings.AllowedJwtIssuers, HostIndexReader: w.HostIndexReader}
+		if failures := validator.Validate(ctx, w.Client, vsList, gwList); len(failures) > 0 {
+			return warnings, toInvalidError(apiRule, failures)
+		}
+	}
+
+	return warnings, nil
+}
+
+func toInvalidError(apiRule *gatewayv2alpha1.APIRule, failures []validation.Failure) error {
+	var errs field.ErrorList
+	for _, failure := range failures {
+		errs = append(errs, &field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    strings.TrimPrefix(failure.AttributePath, "."),
+			BadValue: field.OmitValueType{},
+			Detail:   failure.Message,
+		})
+	}
+	return apierrs.NewInvalid(gatewayv2alpha1.GroupVersion.WithKind("APIRule").GroupKind(), apiRule.Name, errs)
+}
@@ -0,0 +1,299 @@
+package v2alpha1_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apirulepolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/apirulepolicy/v1alpha1"
+	externalv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/external/v1alpha1"
+	hostpolicyv1alpha1 "github.com/kyma-project/api-gateway/apis/gateway/hostpolicy/v1alpha1"
+	gatewayv2alpha1 "github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	operatorv1alpha1 "github.com/kyma-project/api-gateway/apis/operator/v1alpha1"
+	v2alpha1validation "github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
+	"github.com/kyma-project/api-gateway/internal/webhook/gateway/v2alpha1"
+)
+
+func createFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(networkingv1beta1.AddToScheme(scheme)).To(Succeed())
+	Expect(gatewayv2alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(externalv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(hostpolicyv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(apirulepolicyv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(operatorv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithIndex(&gatewayv2alpha1.APIRule{}, v2alpha1validation.HostsIndexField, v2alpha1validation.IndexHosts).
+		Build()
+}
+
+func getService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "httpbin"}},
+	}
+}
+
+func getGateway() *networkingv1beta1.Gateway {
+	return &networkingv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "kyma-system"},
+	}
+}
+
+func getAPIGateway(config operatorv1alpha1.APIRulesConfig) *operatorv1alpha1.APIGateway {
+	return &operatorv1alpha1.APIGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       operatorv1alpha1.APIGatewaySpec{APIRules: &config},
+	}
+}
+
+func getAPIRule(paths ...string) *gatewayv2alpha1.APIRule {
+	host := gatewayv2alpha1.Host("httpbin.local.kyma.dev")
+	apiRule := &gatewayv2alpha1.APIRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "httpbin", Namespace: "default"},
+		Spec: gatewayv2alpha1.APIRuleSpec{
+			Hosts:   []*gatewayv2alpha1.Host{&host},
+			Gateway: ptr.To("kyma-system/gateway"),
+			Service: &gatewayv2alpha1.Service{Name: ptr.To("httpbin"), Port: ptr.To(uint32(8080))},
+		},
+	}
+	for _, path := range paths {
+		apiRule.Spec.Rules = append(apiRule.Spec.Rules, gatewayv2alpha1.Rule{
+			Path:    path,
+			Methods: []gatewayv2alpha1.HttpMethod{"GET"},
+			NoAuth:  ptr.To(true),
+		})
+	}
+	return apiRule
+}
+
+var _ = Describe("ValidatingWebhook", func() {
+	It("should admit a valid APIRule without warnings", func() {
+		// given
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway()), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		warnings, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject an APIRule with conflicting paths", func() {
+		// given
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway()), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		_, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers", "/headers"))
+
+		// then
+		Expect(apierrs.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.rules: Invalid value"))
+		Expect(err.Error()).To(ContainSubstring("conflicts with at least one of the previous rule paths"))
+	})
+
+	It("should warn when the path conflicts with an existing APIRule on the same host", func() {
+		// given
+		existing := getAPIRule("/{**}")
+		existing.Name = "existing"
+		existing.Namespace = "other"
+		existing.CreationTimestamp = metav1.Now()
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway(), existing), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		warnings, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ContainElement(".spec.rules[0]: Path /headers with method GET conflicts with paths of APIRules other/existing on host httpbin.local.kyma.dev"))
+	})
+
+	It("should reject an APIRule violating a HostPolicy with the default configuration", func() {
+		// given
+		policy := &hostpolicyv1alpha1.HostPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "httpbin"},
+			Spec: hostpolicyv1alpha1.HostPolicySpec{
+				Hosts:             []string{"*.local.kyma.dev"},
+				AllowedNamespaces: []string{"httpbin"},
+			},
+		}
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway(), policy), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		_, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(apierrs.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring(`Host httpbin.local.kyma.dev is not allowed in namespace "default" by HostPolicy "httpbin"`))
+	})
+
+	It("should reject an APIRule violating an APIRulePolicy in its namespace", func() {
+		// given
+		policy := &apirulepolicyv1alpha1.APIRulePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "default"},
+			Spec:       apirulepolicyv1alpha1.APIRulePolicySpec{ForbidNoAuth: true},
+		}
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway(), policy), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		_, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(apierrs.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring(`spec.rules[0].noAuth: Invalid value: noAuth is forbidden by APIRulePolicy "production"`))
+	})
+
+	It("should ignore APIRulePolicies in other namespaces", func() {
+		// given
+		policy := &apirulepolicyv1alpha1.APIRulePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "production"},
+			Spec:       apirulepolicyv1alpha1.APIRulePolicySpec{ForbidNoAuth: true},
+		}
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway(), policy), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		_, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should only warn when the Gateway is missing with the default configuration", func() {
+		// given
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService()), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		warnings, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ContainElement(".spec.gateway: Gateway not found"))
+	})
+
+	It("should reject when the Gateway is missing and the gateway check is configured to reject", func() {
+		// given
+		config, err := v2alpha1.ParseValidationConfig("gateway=reject")
+		Expect(err).NotTo(HaveOccurred())
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService()), Config: config}
+
+		// when
+		_, err = webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(apierrs.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("Gateway not found"))
+	})
+
+	It("should neither warn nor reject when the check is ignored", func() {
+		// given
+		config, err := v2alpha1.ParseValidationConfig("gateway=ignore, hosts=ignore")
+		Expect(err).NotTo(HaveOccurred())
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService()), Config: config}
+
+		// when
+		warnings, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers"))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should not run the checks disabled in the APIGateway CR", func() {
+		// given
+		apiGateway := getAPIGateway(operatorv1alpha1.APIRulesConfig{DisabledValidationChecks: []string{"pathConflicts", "gateway"}})
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), apiGateway), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		warnings, err := webhook.ValidateCreate(context.Background(), getAPIRule("/headers", "/headers"))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject JWT issuers that are not allowed by the APIGateway CR", func() {
+		// given
+		apiGateway := getAPIGateway(operatorv1alpha1.APIRulesConfig{AllowedJWTIssuers: []string{"https://allowed.example.com"}})
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway(), apiGateway), Config: v2alpha1.DefaultValidationConfig()}
+		apiRule := getAPIRule()
+		apiRule.Spec.Rules = []gatewayv2alpha1.Rule{{
+			Path:    "/headers",
+			Methods: []gatewayv2alpha1.HttpMethod{"GET"},
+			Jwt: &gatewayv2alpha1.JwtConfig{Authentications: []*gatewayv2alpha1.JwtAuthentication{
+				{Issuer: "https://other.example.com", JwksUri: "https://other.example.com/keys"},
+			}},
+		}}
+
+		// when
+		_, err := webhook.ValidateCreate(context.Background(), apiRule)
+
+		// then
+		Expect(apierrs.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("Issuer https://other.example.com is not allowed by the APIGateway CR"))
+	})
+
+	It("should not validate updates that don't change the spec", func() {
+		// given
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway()), Config: v2alpha1.DefaultValidationConfig()}
+		oldApiRule := getAPIRule("/headers", "/headers")
+		newApiRule := oldApiRule.DeepCopy()
+		newApiRule.Finalizers = nil
+
+		// when
+		_, err := webhook.ValidateUpdate(context.Background(), oldApiRule, newApiRule)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate updates that change the spec", func() {
+		// given
+		webhook := v2alpha1.ValidatingWebhook{Client: createFakeClient(getService(), getGateway()), Config: v2alpha1.DefaultValidationConfig()}
+
+		// when
+		_, err := webhook.ValidateUpdate(context.Background(), getAPIRule("/headers"), getAPIRule("/headers", "/headers"))
+
+		// then
+		Expect(apierrs.IsInvalid(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ParseValidationConfig", func() {
+	It("should return the default configuration for an empty value", func() {
+		config, err := v2alpha1.ParseValidationConfig("")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(Equal(v2alpha1.DefaultValidationConfig()))
+	})
+
+	It("should override the mode of the given checks", func() {
+		config, err := v2alpha1.ParseValidationConfig("hosts=reject,jwt=warn")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config[v2alpha1validation.CheckHosts]).To(Equal(v2alpha1.AdmissionModeReject))
+		Expect(config[v2alpha1validation.CheckJwt]).To(Equal(v2alpha1.AdmissionModeWarn))
+		Expect(config[v2alpha1validation.CheckRules]).To(Equal(v2alpha1.AdmissionModeReject))
+	})
+
+	DescribeTable("should fail for invalid values",
+		func(raw string) {
+			_, err := v2alpha1.ParseValidationConfig(raw)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("unknown check", "unknown=reject"),
+		Entry("unknown mode", "hosts=block"),
+		Entry("missing mode", "hosts"),
+	)
+})
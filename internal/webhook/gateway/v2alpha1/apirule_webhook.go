@@ -18,8 +18,13 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func SetupWebhookWithManager(mgr ctrl.Manager) error {
+func SetupWebhookWithManager(mgr ctrl.Manager, validationConfig ValidationConfig) error {
 	return ctrl.NewWebhookManagedBy(mgr, &gatewayv2alpha1.APIRule{}).
 		WithDefaulter(&MutatingWebhook{}).
+		WithValidator(&ValidatingWebhook{
+			Client:          mgr.GetClient(),
+			HostIndexReader: mgr.GetCache(),
+			Config:          validationConfig,
+		}).
 		Complete()
 }
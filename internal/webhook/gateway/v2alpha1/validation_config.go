@@ -0,0 +1,93 @@
+package v2alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	v2alpha1validation "github.com/kyma-project/api-gateway/internal/validation/v2alpha1"
+)
+
+// AdmissionMode defines how failures of a validation check are handled at admission.
+type AdmissionMode string
+
+const (
+	// AdmissionModeReject rejects the request if the check fails.
+	AdmissionModeReject AdmissionMode = "reject"
+	// AdmissionModeWarn admits the request and returns the failures of the check as warnings.
+	AdmissionModeWarn AdmissionMode = "warn"
+	// AdmissionModeIgnore doesn't run the check at admission. It is still run during reconciliation.
+	AdmissionModeIgnore AdmissionMode = "ignore"
+)
+
+// ValidationConfig defines the AdmissionMode for each validation check.
+type ValidationConfig map[v2alpha1validation.Check]AdmissionMode
+
+// DefaultValidationConfig returns the configuration that rejects APIRules failing checks that only depend on the APIRule itself.
+// Checks that depend on the cluster state, which can change after the APIRule was admitted, only result in warnings.
+// HostPolicies and APIRulePolicies are always enforced, because they are defined by administrators to isolate tenants
+// and to enforce security requirements.
+func DefaultValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		v2alpha1validation.CheckRules:             AdmissionModeReject,
+		v2alpha1validation.CheckPathConflicts:     AdmissionModeReject,
+		v2alpha1validation.CheckHostPathConflicts: AdmissionModeWarn,
+		v2alpha1validation.CheckJwt:               AdmissionModeReject,
+		v2alpha1validation.CheckSidecarInjection:  AdmissionModeWarn,
+		v2alpha1validation.CheckExtAuthProviders:  AdmissionModeWarn,
+		v2alpha1validation.CheckHosts:             AdmissionModeWarn,
+		v2alpha1validation.CheckHostPolicies:      AdmissionModeReject,
+		v2alpha1validation.CheckGateway:           AdmissionModeWarn,
+		v2alpha1validation.CheckAPIRulePolicies:   AdmissionModeReject,
+	}
+}
+
+// ParseValidationConfig parses a comma separated list of check=mode pairs, for example "hosts=reject,sidecarInjection=ignore".
+// Checks that are not part of raw keep the mode of DefaultValidationConfig.
+func ParseValidationConfig(raw string) (ValidationConfig, error) {
+	config := DefaultValidationConfig()
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, mode, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("expected check=mode, got %q", pair)
+		}
+
+		check, err := v2alpha1validation.ParseCheck(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		switch m := AdmissionMode(strings.TrimSpace(mode)); m {
+		case AdmissionModeReject, AdmissionModeWarn, AdmissionModeIgnore:
+			config[check] = m
+		default:
+			return nil, fmt.Errorf("unknown admission mode %q for check %s, supported modes are %s, %s and %s",
+				m, check, AdmissionModeReject, AdmissionModeWarn, AdmissionModeIgnore)
+		}
+	}
+	return config, nil
+}
+
+// checks returns the checks that are configured with the given mode. If enabled is not nil, only the enabled checks
+// are returned.
+func (c ValidationConfig) checks(mode AdmissionMode, enabled []v2alpha1validation.Check) []v2alpha1validation.Check {
+	checks := make([]v2alpha1validation.Check, 0)
+	for _, check := range v2alpha1validation.AllChecks {
+		if enabled != nil && !slices.Contains(enabled, check) {
+			continue
+		}
+		m, ok := c[check]
+		if !ok {
+			m = DefaultValidationConfig()[check]
+		}
+		if m == mode {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}